@@ -1,6 +1,11 @@
 package config
 
-import "github.com/spf13/viper"
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/viper"
+)
 
 type Config struct {
 	Server struct {
@@ -9,6 +14,63 @@ type Config struct {
 	Database struct {
 		URL string `mapstructure:"url"`
 	} `mapstructure:"database"`
+	Auth struct {
+		Issuer             string        `mapstructure:"issuer"`
+		AccessTokenSecret  string        `mapstructure:"access_token_secret"`
+		RefreshTokenSecret string        `mapstructure:"refresh_token_secret"`
+		AccessTokenTTL     time.Duration `mapstructure:"access_token_ttl"`
+		RefreshTokenTTL    time.Duration `mapstructure:"refresh_token_ttl"`
+	} `mapstructure:"auth"`
+	Storage struct {
+		// Driver selects the blob store: "local" or "s3".
+		Driver       string   `mapstructure:"driver"`
+		LocalPath    string   `mapstructure:"local_path"`
+		MaxFileSize  int64    `mapstructure:"max_file_size"`
+		AllowedTypes []string `mapstructure:"allowed_types"`
+		S3           struct {
+			Endpoint  string `mapstructure:"endpoint"`
+			Region    string `mapstructure:"region"`
+			Bucket    string `mapstructure:"bucket"`
+			AccessKey string `mapstructure:"access_key"`
+			SecretKey string `mapstructure:"secret_key"`
+			UseSSL    bool   `mapstructure:"use_ssl"`
+		} `mapstructure:"s3"`
+	} `mapstructure:"storage"`
+	Notifications struct {
+		// Driver selects how notifications are delivered: "log", "webhook"
+		// or "smtp".
+		Driver  string `mapstructure:"driver"`
+		Webhook struct {
+			URL    string `mapstructure:"url"`
+			Secret string `mapstructure:"secret"`
+		} `mapstructure:"webhook"`
+		SMTP struct {
+			Host     string `mapstructure:"host"`
+			Port     int    `mapstructure:"port"`
+			Username string `mapstructure:"username"`
+			Password string `mapstructure:"password"`
+			From     string `mapstructure:"from"`
+		} `mapstructure:"smtp"`
+	} `mapstructure:"notifications"`
+	Reminders struct {
+		PollInterval time.Duration `mapstructure:"poll_interval"`
+		BatchSize    int           `mapstructure:"batch_size"`
+		MaxAttempts  int           `mapstructure:"max_attempts"`
+	} `mapstructure:"reminders"`
+	Digests struct {
+		PollInterval time.Duration `mapstructure:"poll_interval"`
+		BatchSize    int           `mapstructure:"batch_size"`
+	} `mapstructure:"digests"`
+	Stats struct {
+		// CacheTTL bounds how long statistics may lag behind changes made
+		// through other replicas, or that change which activities a user
+		// can see, such as joining a workspace.
+		CacheTTL time.Duration `mapstructure:"cache_ttl"`
+	} `mapstructure:"stats"`
+	Undo struct {
+		// Window is how long after a change it can be undone.
+		Window time.Duration `mapstructure:"window"`
+	} `mapstructure:"undo"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -18,6 +80,30 @@ func LoadConfig() (*Config, error) {
 	viper.AddConfigPath("..")
 	viper.AutomaticEnv()
 
+	viper.SetDefault("auth.issuer", "todolist-v1")
+	viper.SetDefault("auth.access_token_ttl", "15m")
+	viper.SetDefault("auth.refresh_token_ttl", "720h")
+	viper.SetDefault("storage.driver", "local")
+	viper.SetDefault("storage.local_path", "data/attachments")
+	viper.SetDefault("storage.max_file_size", 10<<20)
+	viper.SetDefault("storage.allowed_types", []string{
+		"image/png", "image/jpeg", "image/gif", "image/webp",
+		"application/pdf", "text/plain", "text/csv",
+		"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	})
+	viper.SetDefault("storage.s3.region", "us-east-1")
+	viper.SetDefault("storage.s3.use_ssl", true)
+	viper.SetDefault("notifications.driver", "log")
+	viper.SetDefault("notifications.smtp.port", 587)
+	viper.SetDefault("reminders.poll_interval", "30s")
+	viper.SetDefault("reminders.batch_size", 100)
+	viper.SetDefault("reminders.max_attempts", 5)
+	viper.SetDefault("digests.poll_interval", "5m")
+	viper.SetDefault("digests.batch_size", 50)
+	viper.SetDefault("stats.cache_ttl", "1m")
+	viper.SetDefault("undo.window", "30s")
+
 	if err := viper.ReadInConfig(); err != nil {
 		return nil, err
 	}
@@ -26,5 +112,23 @@ func LoadConfig() (*Config, error) {
 	if err := viper.Unmarshal(&cfg); err != nil {
 		return nil, err
 	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
 	return &cfg, nil
 }
+
+// minSecretLength is the shortest token secret accepted, in bytes: HS256
+// keys shorter than its 256-bit output weaken it.
+const minSecretLength = 32
+
+// Validate rejects settings the server must not start with.
+func (cfg *Config) Validate() error {
+	if len(cfg.Auth.AccessTokenSecret) < minSecretLength || len(cfg.Auth.RefreshTokenSecret) < minSecretLength {
+		return errors.New("auth.access_token_secret and auth.refresh_token_secret must be at least 32 bytes long")
+	}
+	if cfg.Auth.AccessTokenSecret == cfg.Auth.RefreshTokenSecret {
+		return errors.New("auth.access_token_secret and auth.refresh_token_secret must differ")
+	}
+	return nil
+}
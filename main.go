@@ -3,13 +3,41 @@ package main
 import (
 	"todolist-v1/config"
 	"todolist-v1/pkg/database"
+	"todolist-v1/pkg/events"
+	"todolist-v1/pkg/notifier"
+	"todolist-v1/pkg/scheduler"
 	"todolist-v1/pkg/server"
+	"todolist-v1/pkg/storage"
 
 	"github.com/sirupsen/logrus"
 
+	activityEntities "todolist-v1/modules/activity/entities"
 	activityHandler "todolist-v1/modules/activity/handler"
 	activityRepo "todolist-v1/modules/activity/repository"
 	activityUsecase "todolist-v1/modules/activity/usecase"
+	authHandler "todolist-v1/modules/auth/handler"
+	authMiddleware "todolist-v1/modules/auth/middleware"
+	authRepo "todolist-v1/modules/auth/repository"
+	authUsecase "todolist-v1/modules/auth/usecase"
+	calendarHandler "todolist-v1/modules/calendar/handler"
+	calendarUsecase "todolist-v1/modules/calendar/usecase"
+	digestHandler "todolist-v1/modules/digest/handler"
+	digestRepo "todolist-v1/modules/digest/repository"
+	digestUsecase "todolist-v1/modules/digest/usecase"
+	projectHandler "todolist-v1/modules/project/handler"
+	projectRepo "todolist-v1/modules/project/repository"
+	projectUsecase "todolist-v1/modules/project/usecase"
+	reminderHandler "todolist-v1/modules/reminder/handler"
+	reminderRepo "todolist-v1/modules/reminder/repository"
+	reminderUsecase "todolist-v1/modules/reminder/usecase"
+	statsHandler "todolist-v1/modules/stats/handler"
+	statsUsecase "todolist-v1/modules/stats/usecase"
+	templateHandler "todolist-v1/modules/template/handler"
+	templateRepo "todolist-v1/modules/template/repository"
+	templateUsecase "todolist-v1/modules/template/usecase"
+	workspaceHandler "todolist-v1/modules/workspace/handler"
+	workspaceRepo "todolist-v1/modules/workspace/repository"
+	workspaceUsecase "todolist-v1/modules/workspace/usecase"
 )
 
 func main() {
@@ -31,12 +59,95 @@ func main() {
 
 	srv := server.NewFiberServer(cfg)
 
+	userRepository := authRepo.NewUserRepository(db.Gorm)
+	refreshTokenRepository := authRepo.NewRefreshTokenRepository(db.Gorm)
+	apiKeyRepository := authRepo.NewApiKeyRepository(db.Gorm)
+	auth := authUsecase.NewAuthUsecase(userRepository, refreshTokenRepository, cfg)
+	apiKeys := authUsecase.NewApiKeyUsecase(apiKeyRepository, userRepository)
+	requireAuth := authMiddleware.NewAuthMiddleware(auth, apiKeys)
+	authHandler.NewAuthHttpHandler(srv.GetEngine(), auth, requireAuth).RegisterRoutes()
+	authHandler.NewApiKeyHttpHandler(srv.GetEngine(), apiKeys, requireAuth).RegisterRoutes()
+
+	workspaceRepository := workspaceRepo.NewWorkspaceRepository(db.Gorm)
+	invitationRepository := workspaceRepo.NewInvitationRepository(db.Gorm)
+	workspaces := workspaceUsecase.NewWorkspaceUsecase(workspaceRepository, invitationRepository)
+	workspaceHandler.NewWorkspaceHttpHandler(srv.GetEngine(), workspaces, requireAuth).RegisterRoutes()
+
+	bus := events.NewBus()
+	bus.Subscribe(events.AllEvents, events.NewLogSubscriber(log))
+
 	repo := activityRepo.NewActivityRepository(db.Gorm)
-	usecase := activityUsecase.NewActivityUsecase(repo)
-	handler := activityHandler.NewActivityHttpHandler(srv.GetEngine(), usecase)
+	historyRepository := activityRepo.NewActivityHistoryRepository(db.Gorm)
+	shareRepository := activityRepo.NewActivityShareRepository(db.Gorm)
+	projectRepository := projectRepo.NewProjectRepository(db.Gorm)
+	usecase := activityUsecase.NewActivityUsecase(repo, historyRepository, activityRepo.NewUndoRepository(db.Gorm), shareRepository, workspaceRepository, projectRepository, bus, cfg)
+	handler := activityHandler.NewActivityHttpHandler(srv.GetEngine(), usecase, requireAuth)
 
 	handler.RegisterRoutes()
 
+	shares := activityUsecase.NewActivityShareUsecase(repo, shareRepository, workspaceRepository, userRepository)
+	activityHandler.NewActivityShareHttpHandler(srv.GetEngine(), shares, requireAuth).RegisterRoutes()
+	dependencies := activityUsecase.NewActivityDependencyUsecase(repo, activityRepo.NewActivityDependencyRepository(db.Gorm), shareRepository, workspaceRepository)
+	activityHandler.NewActivityDependencyHttpHandler(srv.GetEngine(), dependencies, requireAuth).RegisterRoutes()
+	timeEntries := activityUsecase.NewTimeEntryUsecase(repo, activityRepo.NewTimeEntryRepository(db.Gorm), shareRepository, workspaceRepository)
+	activityHandler.NewTimeEntryHttpHandler(srv.GetEngine(), timeEntries, requireAuth).RegisterRoutes()
+	savedFilters := activityUsecase.NewSavedFilterUsecase(repo, activityRepo.NewSavedFilterRepository(db.Gorm), shareRepository, workspaceRepository)
+	activityHandler.NewSavedFilterHttpHandler(srv.GetEngine(), savedFilters, requireAuth).RegisterRoutes()
+
+	commentRepository := activityRepo.NewActivityCommentRepository(db.Gorm)
+	comments := activityUsecase.NewActivityCommentUsecase(repo, commentRepository, shareRepository, workspaceRepository, userRepository, bus)
+	activityHandler.NewActivityCommentHttpHandler(srv.GetEngine(), comments, requireAuth).RegisterRoutes()
+
+	store, err := storage.NewBlobStore(cfg)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to set up attachment storage")
+	}
+	attachmentRepository := activityRepo.NewActivityAttachmentRepository(db.Gorm)
+	attachments := activityUsecase.NewActivityAttachmentUsecase(repo, attachmentRepository, shareRepository, workspaceRepository, store, cfg)
+	activityHandler.NewActivityAttachmentHttpHandler(srv.GetEngine(), attachments, requireAuth).RegisterRoutes()
+	bus.Subscribe(activityEntities.EventActivityPurged, func(event events.Event) {
+		if err := attachments.RemoveOrphans(); err != nil {
+			log.WithError(err).WithField("activity_id", event.ActivityId).Error("Failed to remove attachments of a purged activity")
+		}
+	})
+
+	notifications, err := notifier.NewNotifier(cfg, log)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to set up notifications")
+	}
+	reminders := reminderUsecase.NewReminderUsecase(reminderRepo.NewReminderRepository(db.Gorm), repo, notifications, cfg)
+	reminderHandler.NewReminderHttpHandler(srv.GetEngine(), reminders, requireAuth).RegisterRoutes()
+
+	digests := digestUsecase.NewDigestUsecase(digestRepo.NewDigestSubscriptionRepository(db.Gorm), repo, notifications, cfg)
+	digestHandler.NewDigestHttpHandler(srv.GetEngine(), digests, requireAuth).RegisterRoutes()
+
+	projects := projectUsecase.NewProjectUsecase(projectRepository, repo, workspaceRepository)
+	projectHandler.NewProjectHttpHandler(srv.GetEngine(), projects, requireAuth).RegisterRoutes()
+
+	templates := templateUsecase.NewTemplateUsecase(templateRepo.NewTemplateRepository(db.Gorm), usecase, workspaceRepository, userRepository)
+	templateHandler.NewTemplateHttpHandler(srv.GetEngine(), templates, requireAuth).RegisterRoutes()
+
+	calendar := calendarUsecase.NewCalendarUsecase(repo, workspaceRepository)
+	calendarHandler.NewCalendarHttpHandler(srv.GetEngine(), calendar, requireAuth).RegisterRoutes()
+
+	stats := statsUsecase.NewStatsUsecase(activityRepo.NewActivityStatsRepository(db.Gorm), cfg)
+	statsHandler.NewStatsHttpHandler(srv.GetEngine(), stats, requireAuth).RegisterRoutes()
+	bus.Subscribe(events.AllEvents, func(event events.Event) {
+		stats.InvalidateActivity(event.ActorId, event.ActivityId)
+	})
+
+	jobs := scheduler.NewScheduler(log)
+	jobs.Every("reminders", cfg.Reminders.PollInterval, func() error {
+		_, err := reminders.DispatchDue()
+		return err
+	})
+	jobs.Every("digests", cfg.Digests.PollInterval, func() error {
+		_, err := digests.DispatchDue()
+		return err
+	})
+	jobs.Start()
+	defer jobs.Stop()
+
 	log.WithField("port", cfg.Server.Port).Info("Server is running")
 	if err := srv.Start(); err != nil {
 		log.WithError(err).Fatal("Failed to start server")
@@ -0,0 +1,7 @@
+CREATE TABLE users (
+                       id SERIAL PRIMARY KEY,
+                       email VARCHAR(254) NOT NULL UNIQUE,
+                       name VARCHAR(100) NOT NULL,
+                       password_hash TEXT NOT NULL,
+                       created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
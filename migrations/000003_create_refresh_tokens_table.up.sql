@@ -0,0 +1,10 @@
+CREATE TABLE refresh_tokens (
+                                id VARCHAR(64) PRIMARY KEY,
+                                user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                expires_at TIMESTAMPTZ NOT NULL,
+                                revoked_at TIMESTAMPTZ,
+                                replaced_by VARCHAR(64),
+                                created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens(user_id);
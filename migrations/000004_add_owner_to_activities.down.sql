@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_activities_owner_id;
+ALTER TABLE activities DROP COLUMN IF EXISTS owner_id;
+DELETE FROM users WHERE email = 'bootstrap@localhost';
@@ -0,0 +1,16 @@
+-- Activities created before authentication existed have no owner. They are
+-- assigned to a bootstrap user that cannot log in (its password hash is not
+-- a valid bcrypt hash) until an administrator reassigns them.
+INSERT INTO users (email, name, password_hash)
+VALUES ('bootstrap@localhost', 'Bootstrap User', '!')
+ON CONFLICT (email) DO NOTHING;
+
+ALTER TABLE activities ADD COLUMN owner_id INT REFERENCES users(id) ON DELETE CASCADE;
+
+UPDATE activities
+SET owner_id = (SELECT id FROM users WHERE email = 'bootstrap@localhost')
+WHERE owner_id IS NULL;
+
+ALTER TABLE activities ALTER COLUMN owner_id SET NOT NULL;
+
+CREATE INDEX idx_activities_owner_id ON activities(owner_id);
@@ -0,0 +1,13 @@
+CREATE TABLE api_keys (
+                          id SERIAL PRIMARY KEY,
+                          user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                          name VARCHAR(100) NOT NULL,
+                          prefix VARCHAR(16) NOT NULL,
+                          key_hash CHAR(64) NOT NULL UNIQUE,
+                          scopes TEXT[] NOT NULL,
+                          last_used_at TIMESTAMPTZ,
+                          created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                          revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_api_keys_user_id ON api_keys(user_id);
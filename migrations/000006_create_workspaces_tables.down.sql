@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_activities_workspace_id;
+ALTER TABLE activities DROP COLUMN IF EXISTS workspace_id;
+DROP TABLE IF EXISTS workspace_invitations;
+DROP TABLE IF EXISTS workspace_members;
+DROP TABLE IF EXISTS workspaces;
+DROP TYPE IF EXISTS workspace_role;
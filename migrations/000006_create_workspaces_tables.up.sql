@@ -0,0 +1,36 @@
+CREATE TYPE workspace_role AS ENUM ('owner', 'admin', 'member', 'viewer');
+
+CREATE TABLE workspaces (
+                            id SERIAL PRIMARY KEY,
+                            name VARCHAR(100) NOT NULL,
+                            created_by INT NOT NULL REFERENCES users(id),
+                            created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE workspace_members (
+                                   workspace_id INT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+                                   user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                   role workspace_role NOT NULL,
+                                   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                   PRIMARY KEY (workspace_id, user_id)
+);
+
+CREATE INDEX idx_workspace_members_user_id ON workspace_members(user_id);
+
+CREATE TABLE workspace_invitations (
+                                       id SERIAL PRIMARY KEY,
+                                       workspace_id INT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+                                       email VARCHAR(254) NOT NULL,
+                                       role workspace_role NOT NULL,
+                                       token_hash CHAR(64) NOT NULL UNIQUE,
+                                       invited_by INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                       expires_at TIMESTAMPTZ NOT NULL,
+                                       accepted_at TIMESTAMPTZ,
+                                       created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_workspace_invitations_workspace_id ON workspace_invitations(workspace_id);
+
+ALTER TABLE activities ADD COLUMN workspace_id INT REFERENCES workspaces(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_activities_workspace_id ON activities(workspace_id);
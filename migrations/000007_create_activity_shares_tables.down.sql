@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS activity_share_links;
+DROP TABLE IF EXISTS activity_shares;
+DROP TYPE IF EXISTS share_permission;
@@ -0,0 +1,24 @@
+CREATE TYPE share_permission AS ENUM ('read', 'edit');
+
+CREATE TABLE activity_shares (
+                                 activity_id INT NOT NULL REFERENCES activities(id) ON DELETE CASCADE,
+                                 user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                 permission share_permission NOT NULL,
+                                 created_by INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                 created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                 PRIMARY KEY (activity_id, user_id)
+);
+
+CREATE INDEX idx_activity_shares_user_id ON activity_shares(user_id);
+
+CREATE TABLE activity_share_links (
+                                      id SERIAL PRIMARY KEY,
+                                      activity_id INT NOT NULL REFERENCES activities(id) ON DELETE CASCADE,
+                                      token_hash CHAR(64) NOT NULL UNIQUE,
+                                      expires_at TIMESTAMPTZ NOT NULL,
+                                      created_by INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                      created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                      revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_activity_share_links_activity_id ON activity_share_links(activity_id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS activity_history;
+DROP TYPE IF EXISTS history_action;
+DELETE FROM activities WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_activities_deleted_at;
+ALTER TABLE activities DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,19 @@
+-- Deleted activities are kept so that they can be restored from their
+-- history.
+ALTER TABLE activities ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX idx_activities_deleted_at ON activities(deleted_at);
+
+CREATE TYPE history_action AS ENUM ('create', 'update', 'delete', 'restore');
+
+CREATE TABLE activity_history (
+                                  id SERIAL PRIMARY KEY,
+                                  activity_id INT NOT NULL REFERENCES activities(id) ON DELETE CASCADE,
+                                  revision INT NOT NULL,
+                                  actor_id INT REFERENCES users(id) ON DELETE SET NULL,
+                                  action history_action NOT NULL,
+                                  changes JSONB NOT NULL DEFAULT '{}',
+                                  snapshot JSONB NOT NULL,
+                                  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                  UNIQUE (activity_id, revision)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS activity_comment_mentions;
+DROP TABLE IF EXISTS activity_comments;
@@ -0,0 +1,18 @@
+CREATE TABLE activity_comments (
+                                   id SERIAL PRIMARY KEY,
+                                   activity_id INT NOT NULL REFERENCES activities(id) ON DELETE CASCADE,
+                                   author_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                   body TEXT NOT NULL,
+                                   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                   updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_activity_comments_activity_id ON activity_comments(activity_id);
+
+CREATE TABLE activity_comment_mentions (
+                                           comment_id INT NOT NULL REFERENCES activity_comments(id) ON DELETE CASCADE,
+                                           user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                           PRIMARY KEY (comment_id, user_id)
+);
+
+CREATE INDEX idx_activity_comment_mentions_user_id ON activity_comment_mentions(user_id);
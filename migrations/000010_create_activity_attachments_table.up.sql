@@ -0,0 +1,15 @@
+-- activity_id is cleared rather than cascaded when an activity is purged,
+-- so that the stored blobs can still be found and removed afterwards.
+CREATE TABLE activity_attachments (
+                                      id SERIAL PRIMARY KEY,
+                                      activity_id INT REFERENCES activities(id) ON DELETE SET NULL,
+                                      uploaded_by INT REFERENCES users(id) ON DELETE SET NULL,
+                                      file_name VARCHAR(255) NOT NULL,
+                                      content_type VARCHAR(255) NOT NULL,
+                                      size BIGINT NOT NULL,
+                                      checksum_sha256 CHAR(64) NOT NULL,
+                                      storage_key VARCHAR(255) NOT NULL UNIQUE,
+                                      created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_activity_attachments_activity_id ON activity_attachments(activity_id);
@@ -0,0 +1,19 @@
+-- A reminder fires either offset_minutes before the activity's date, following
+-- the activity when it is moved, or at the absolute time remind_at.
+CREATE TABLE reminders (
+                           id SERIAL PRIMARY KEY,
+                           activity_id INT NOT NULL REFERENCES activities(id) ON DELETE CASCADE,
+                           user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                           offset_minutes INT CHECK (offset_minutes >= 0),
+                           remind_at TIMESTAMPTZ,
+                           attempts INT NOT NULL DEFAULT 0,
+                           locked_until TIMESTAMPTZ,
+                           last_error TEXT,
+                           sent_at TIMESTAMPTZ,
+                           failed_at TIMESTAMPTZ,
+                           created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                           CHECK ((offset_minutes IS NULL) <> (remind_at IS NULL))
+);
+
+CREATE INDEX idx_reminders_activity_id ON reminders(activity_id);
+CREATE INDEX idx_reminders_pending ON reminders(activity_id) WHERE sent_at IS NULL AND failed_at IS NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_activities_activity_date;
+DROP TABLE IF EXISTS digest_subscriptions;
@@ -0,0 +1,16 @@
+-- A user receives at most one digest per local day, at or after send_hour in
+-- their timezone. Weekly digests are only sent on weekday (0 = Sunday).
+CREATE TABLE digest_subscriptions (
+                                      user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+                                      frequency VARCHAR(10) NOT NULL CHECK (frequency IN ('daily', 'weekly')),
+                                      timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+                                      send_hour INT NOT NULL DEFAULT 7 CHECK (send_hour BETWEEN 0 AND 23),
+                                      weekday INT NOT NULL DEFAULT 1 CHECK (weekday BETWEEN 0 AND 6),
+                                      last_sent_on DATE,
+                                      locked_until TIMESTAMPTZ,
+                                      created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                      updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Digests look activities up by date range.
+CREATE INDEX idx_activities_activity_date ON activities(activity_date);
@@ -0,0 +1,2 @@
+ALTER TABLE activities DROP COLUMN IF EXISTS reschedule_count;
+ALTER TABLE activities DROP COLUMN IF EXISTS original_activity_date;
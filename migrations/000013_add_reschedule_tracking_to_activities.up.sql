@@ -0,0 +1,4 @@
+-- original_activity_date keeps the date an activity had before it was first
+-- snoozed or rescheduled, reschedule_count how often that happened.
+ALTER TABLE activities ADD COLUMN original_activity_date TIMESTAMPTZ;
+ALTER TABLE activities ADD COLUMN reschedule_count INT NOT NULL DEFAULT 0;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_activities_priority_due_at;
+ALTER TABLE activities DROP CONSTRAINT IF EXISTS activities_schedule_check;
+ALTER TABLE activities DROP COLUMN IF EXISTS due_at;
+ALTER TABLE activities DROP COLUMN IF EXISTS end_at;
+ALTER TABLE activities DROP COLUMN IF EXISTS start_at;
+ALTER TABLE activities DROP COLUMN IF EXISTS priority;
+DROP TYPE IF EXISTS priority;
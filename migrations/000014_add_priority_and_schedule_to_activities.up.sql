@@ -0,0 +1,18 @@
+-- Events span start_at to end_at, tasks are due at due_at. activity_date is
+-- kept as the start of an event or the deadline of a task.
+CREATE TYPE priority AS ENUM ('P1', 'P2', 'P3', 'P4');
+
+ALTER TABLE activities ADD COLUMN priority priority NOT NULL DEFAULT 'P4';
+ALTER TABLE activities ADD COLUMN start_at TIMESTAMPTZ;
+ALTER TABLE activities ADD COLUMN end_at TIMESTAMPTZ;
+ALTER TABLE activities ADD COLUMN due_at TIMESTAMPTZ;
+
+UPDATE activities SET start_at = activity_date WHERE category = 'EVENT';
+UPDATE activities SET due_at = activity_date WHERE category = 'TASK';
+
+ALTER TABLE activities ADD CONSTRAINT activities_schedule_check CHECK (
+    (category = 'EVENT' AND due_at IS NULL AND (end_at IS NULL OR end_at >= start_at)) OR
+    (category = 'TASK' AND start_at IS NULL AND end_at IS NULL)
+);
+
+CREATE INDEX idx_activities_priority_due_at ON activities(priority, due_at);
@@ -0,0 +1,5 @@
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
+ALTER TABLE activities DROP CONSTRAINT IF EXISTS activities_all_day_check;
+ALTER TABLE activities DROP COLUMN IF EXISTS local_date;
+ALTER TABLE activities DROP COLUMN IF EXISTS all_day;
+ALTER TABLE activities DROP COLUMN IF EXISTS timezone;
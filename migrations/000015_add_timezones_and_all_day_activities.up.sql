@@ -0,0 +1,10 @@
+-- timezone is the IANA zone an activity was planned in. All-day activities
+-- keep their day in local_date; activity_date is then the start of that day
+-- in the activity's timezone.
+ALTER TABLE activities ADD COLUMN timezone VARCHAR(64);
+ALTER TABLE activities ADD COLUMN all_day BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE activities ADD COLUMN local_date DATE;
+ALTER TABLE activities ADD CONSTRAINT activities_all_day_check CHECK (all_day = (local_date IS NOT NULL));
+
+-- A user's timezone is used to render dates when a request does not name one.
+ALTER TABLE users ADD COLUMN timezone VARCHAR(64);
@@ -0,0 +1,5 @@
+DROP FUNCTION IF EXISTS activity_occurrences;
+ALTER TABLE activities DROP COLUMN IF EXISTS recurrence_until;
+ALTER TABLE activities DROP COLUMN IF EXISTS recurrence_interval;
+ALTER TABLE activities DROP COLUMN IF EXISTS recurrence_frequency;
+DROP TYPE IF EXISTS recurrence_frequency;
@@ -0,0 +1,44 @@
+-- A recurring activity repeats every recurrence_interval days, weeks or
+-- months from its activity_date, at the same wall clock time in its
+-- timezone, until recurrence_until.
+CREATE TYPE recurrence_frequency AS ENUM ('daily', 'weekly', 'monthly');
+
+ALTER TABLE activities ADD COLUMN recurrence_frequency recurrence_frequency;
+ALTER TABLE activities ADD COLUMN recurrence_interval INT NOT NULL DEFAULT 1 CHECK (recurrence_interval >= 1);
+ALTER TABLE activities ADD COLUMN recurrence_until TIMESTAMPTZ;
+
+-- activity_occurrences returns the times in [range_from, range_to) at which an
+-- activity starting at first_at takes place. Months are added to the first
+-- date rather than to the previous occurrence so that an activity on the
+-- 31st comes back on the 31st where the month has one.
+CREATE FUNCTION activity_occurrences(
+    first_at TIMESTAMPTZ,
+    zone TEXT,
+    frequency recurrence_frequency,
+    step INT,
+    until_at TIMESTAMPTZ,
+    range_from TIMESTAMPTZ,
+    range_to TIMESTAMPTZ
+) RETURNS TABLE (occurs_at TIMESTAMPTZ) LANGUAGE sql STABLE AS $$
+    SELECT first_at
+    WHERE frequency IS NULL AND first_at >= range_from AND first_at < range_to
+    UNION ALL
+    SELECT series.occurs_at FROM (
+        SELECT ((first_at AT TIME ZONE zone) + CASE frequency
+                WHEN 'daily' THEN make_interval(days => step * k)
+                WHEN 'weekly' THEN make_interval(weeks => step * k)
+                ELSE make_interval(months => step * k)
+            END) AT TIME ZONE zone AS occurs_at
+        FROM generate_series(
+            -- Bounds on the repetitions that can fall into the range, with a
+            -- margin for daylight saving time and months of unequal length.
+            GREATEST(0, FLOOR(EXTRACT(EPOCH FROM range_from - first_at) / 86400 /
+                (step * CASE frequency WHEN 'daily' THEN 1 WHEN 'weekly' THEN 7 ELSE 31 END))::INT - 1),
+            CEIL(EXTRACT(EPOCH FROM LEAST(range_to, COALESCE(until_at, range_to)) - first_at) / 86400 /
+                (step * CASE frequency WHEN 'daily' THEN 1 WHEN 'weekly' THEN 7 ELSE 28 END))::INT + 1
+        ) AS k
+        WHERE frequency IS NOT NULL
+    ) AS series
+    WHERE series.occurs_at >= range_from AND series.occurs_at < range_to
+        AND (until_at IS NULL OR series.occurs_at <= until_at)
+$$;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_activities_period;
+ALTER TABLE activities DROP COLUMN IF EXISTS period;
+DROP FUNCTION IF EXISTS event_period;
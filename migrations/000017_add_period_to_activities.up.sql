@@ -0,0 +1,17 @@
+-- event_period is the time an event occupies. Events without an end, or
+-- ending when they start, occupy their start instant.
+CREATE FUNCTION event_period(start_at TIMESTAMPTZ, end_at TIMESTAMPTZ) RETURNS TSTZRANGE
+LANGUAGE sql IMMUTABLE AS $$
+    SELECT CASE WHEN end_at IS NULL OR end_at <= start_at
+        THEN tstzrange(start_at, start_at, '[]')
+        ELSE tstzrange(start_at, end_at)
+    END
+$$;
+
+-- Only timed events keep their owner busy; tasks have no start_at and
+-- all-day events do not block the day.
+ALTER TABLE activities ADD COLUMN period TSTZRANGE GENERATED ALWAYS AS (
+    CASE WHEN start_at IS NOT NULL AND NOT all_day THEN event_period(start_at, end_at) END
+) STORED;
+
+CREATE INDEX idx_activities_period ON activities USING GIST (period) WHERE deleted_at IS NULL;
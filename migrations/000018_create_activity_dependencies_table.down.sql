@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS activity_dependencies;
+
+-- Enum values cannot be dropped; the type is recreated without DONE and
+-- finished activities become EXPIRED.
+ALTER TABLE activities ALTER COLUMN status DROP DEFAULT;
+UPDATE activities SET status = 'EXPIRED' WHERE status = 'DONE';
+ALTER TYPE status RENAME TO status_with_done;
+CREATE TYPE status AS ENUM ('NEW', 'ON PROGRESS', 'EXPIRED');
+ALTER TABLE activities ALTER COLUMN status TYPE status USING status::text::status;
+ALTER TABLE activities ALTER COLUMN status SET DEFAULT 'NEW';
+DROP TYPE status_with_done;
@@ -0,0 +1,13 @@
+ALTER TYPE status ADD VALUE IF NOT EXISTS 'DONE';
+
+-- An activity cannot be started while any of its blockers is still open.
+CREATE TABLE activity_dependencies (
+                                       activity_id INT NOT NULL REFERENCES activities(id) ON DELETE CASCADE,
+                                       blocker_id INT NOT NULL REFERENCES activities(id) ON DELETE CASCADE,
+                                       created_by INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                       created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                       PRIMARY KEY (activity_id, blocker_id),
+                                       CHECK (activity_id <> blocker_id)
+);
+
+CREATE INDEX idx_activity_dependencies_blocker_id ON activity_dependencies(blocker_id);
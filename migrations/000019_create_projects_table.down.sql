@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_activities_project_board;
+ALTER TABLE activities DROP COLUMN IF EXISTS rank;
+ALTER TABLE activities DROP COLUMN IF EXISTS project_id;
+DROP TABLE IF EXISTS projects;
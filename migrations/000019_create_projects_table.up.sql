@@ -0,0 +1,17 @@
+CREATE TABLE projects (
+                          id SERIAL PRIMARY KEY,
+                          owner_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                          workspace_id INT REFERENCES workspaces(id) ON DELETE CASCADE,
+                          name VARCHAR(100) NOT NULL,
+                          created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                          updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_projects_owner_id ON projects(owner_id);
+CREATE INDEX idx_projects_workspace_id ON projects(workspace_id);
+
+-- rank orders the activities of a board column. Ranks compare bytewise.
+ALTER TABLE activities ADD COLUMN project_id INT REFERENCES projects(id) ON DELETE SET NULL;
+ALTER TABLE activities ADD COLUMN rank VARCHAR(255) COLLATE "C";
+
+CREATE INDEX idx_activities_project_board ON activities(project_id, status, rank);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS time_entries;
+
+DROP INDEX IF EXISTS idx_activities_tags;
+ALTER TABLE activities DROP COLUMN IF EXISTS tags;
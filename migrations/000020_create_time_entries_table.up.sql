@@ -0,0 +1,20 @@
+ALTER TABLE activities ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_activities_tags ON activities USING GIN (tags);
+
+-- A running timer has no ended_at. Overlaps between a user's entries are
+-- checked by the application under a per-user advisory lock.
+CREATE TABLE time_entries (
+                              id SERIAL PRIMARY KEY,
+                              activity_id INT NOT NULL REFERENCES activities(id) ON DELETE CASCADE,
+                              user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                              started_at TIMESTAMPTZ NOT NULL,
+                              ended_at TIMESTAMPTZ,
+                              note VARCHAR(500) NOT NULL DEFAULT '',
+                              created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                              CHECK (ended_at IS NULL OR ended_at >= started_at)
+);
+
+CREATE INDEX idx_time_entries_activity_id ON time_entries(activity_id);
+CREATE INDEX idx_time_entries_user_started_at ON time_entries(user_id, started_at);
+CREATE UNIQUE INDEX idx_time_entries_running ON time_entries(user_id) WHERE ended_at IS NULL;
@@ -0,0 +1,3 @@
+-- Statistics look up the moves to DONE recorded in a period.
+CREATE INDEX idx_activity_history_completed ON activity_history(created_at)
+    WHERE changes -> 'status' ->> 'new' = 'DONE';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_activities_parent_id;
+ALTER TABLE activities DROP COLUMN IF EXISTS parent_id;
+
+DROP TABLE IF EXISTS templates;
@@ -0,0 +1,21 @@
+CREATE TABLE templates (
+                           id SERIAL PRIMARY KEY,
+                           owner_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                           workspace_id INT REFERENCES workspaces(id) ON DELETE CASCADE,
+                           name VARCHAR(100) NOT NULL,
+                           title_pattern VARCHAR(250) NOT NULL,
+                           category category_type NOT NULL,
+                           description TEXT NOT NULL DEFAULT '',
+                           checklist TEXT[] NOT NULL DEFAULT '{}',
+                           created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                           updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_templates_owner_id ON templates(owner_id);
+CREATE INDEX idx_templates_workspace_id ON templates(workspace_id);
+
+-- Activities created from a template's checklist point to the activity
+-- they are steps of.
+ALTER TABLE activities ADD COLUMN parent_id INT REFERENCES activities(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_activities_parent_id ON activities(parent_id);
@@ -0,0 +1,12 @@
+CREATE TABLE saved_filters (
+                               id SERIAL PRIMARY KEY,
+                               owner_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                               workspace_id INT REFERENCES workspaces(id) ON DELETE CASCADE,
+                               name VARCHAR(100) NOT NULL,
+                               filter JSONB NOT NULL DEFAULT '{}',
+                               created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                               updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_saved_filters_owner_id ON saved_filters(owner_id);
+CREATE INDEX idx_saved_filters_workspace_id ON saved_filters(workspace_id);
@@ -0,0 +1,2 @@
+ALTER TABLE activities DROP COLUMN IF EXISTS pinned;
+ALTER TABLE activities DROP COLUMN IF EXISTS archived;
@@ -0,0 +1,4 @@
+-- Archived activities are left out of the activity list unless asked for;
+-- pinned ones are listed first.
+ALTER TABLE activities ADD COLUMN archived BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE activities ADD COLUMN pinned BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,11 @@
+-- The changes each user may still undo. Every replica reads the same
+-- commands, so a change can be undone through any of them.
+CREATE TABLE undo_commands (
+                               id SERIAL PRIMARY KEY,
+                               user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                               revisions JSONB NOT NULL,
+                               pushed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_undo_commands_user_id ON undo_commands(user_id, id);
+CREATE INDEX idx_undo_commands_pushed_at ON undo_commands(pushed_at);
@@ -0,0 +1,20 @@
+package entities
+
+import "time"
+
+// ActivityAttachment describes a file attached to an activity. The content
+// itself lives in the blob store under StorageKey. ActivityId is nil once
+// the activity has been purged and the blob is waiting to be removed.
+type ActivityAttachment struct {
+	Id             int       `json:"id"              gorm:"column:id;primaryKey;autoIncrement"`
+	ActivityId     *int      `json:"activity_id"     gorm:"column:activity_id"`
+	UploadedBy     *int      `json:"uploaded_by"     gorm:"column:uploaded_by"`
+	FileName       string    `json:"file_name"       gorm:"column:file_name;size:255;not null"`
+	ContentType    string    `json:"content_type"    gorm:"column:content_type;size:255;not null"`
+	Size           int64     `json:"size"            gorm:"column:size;not null"`
+	ChecksumSha256 string    `json:"checksum_sha256" gorm:"column:checksum_sha256;size:64;not null"`
+	StorageKey     string    `json:"-"               gorm:"column:storage_key;size:255;not null;unique"`
+	CreatedAt      time.Time `json:"created_at"      gorm:"column:created_at;autoCreateTime"`
+}
+
+func (ActivityAttachment) TableName() string { return "activity_attachments" }
@@ -0,0 +1,29 @@
+package entities
+
+import "time"
+
+// ActivityComment is a Markdown comment on an activity. The body is stored
+// as written; rendering it is left to clients.
+type ActivityComment struct {
+	Id          int              `json:"id"           gorm:"column:id;primaryKey;autoIncrement"`
+	ActivityId  int              `json:"activity_id"  gorm:"column:activity_id;not null"`
+	AuthorId    int              `json:"author_id"    gorm:"column:author_id;not null"`
+	Body        string           `json:"body"         gorm:"column:body;type:text;not null"`
+	CreatedAt   time.Time        `json:"created_at"   gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time        `json:"updated_at"   gorm:"column:updated_at;autoUpdateTime"`
+	AuthorEmail string           `json:"author_email" gorm:"column:author_email;->"`
+	AuthorName  string           `json:"author_name"  gorm:"column:author_name;->"`
+	Mentions    []CommentMention `json:"mentions"     gorm:"-"`
+}
+
+func (ActivityComment) TableName() string { return "activity_comments" }
+
+// CommentMention is a user mentioned in a comment.
+type CommentMention struct {
+	CommentId int    `json:"comment_id" gorm:"column:comment_id;primaryKey"`
+	UserId    int    `json:"user_id"    gorm:"column:user_id;primaryKey"`
+	Email     string `json:"email"      gorm:"column:email;->"`
+	Name      string `json:"name"       gorm:"column:name;->"`
+}
+
+func (CommentMention) TableName() string { return "activity_comment_mentions" }
@@ -0,0 +1,12 @@
+package entities
+
+// ConflictPolicy decides what happens when a timed event overlaps other
+// events of its owner.
+type ConflictPolicy int
+
+const (
+	// ConflictsWarn saves the event and reports the overlapping events.
+	ConflictsWarn ConflictPolicy = iota
+	// ConflictsReject refuses to save the event.
+	ConflictsReject
+)
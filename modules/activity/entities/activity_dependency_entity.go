@@ -0,0 +1,23 @@
+package entities
+
+import "time"
+
+// ActivityDependency records that ActivityId cannot start before BlockerId
+// is finished.
+type ActivityDependency struct {
+	ActivityId int       `json:"activity_id" gorm:"column:activity_id;primaryKey"`
+	BlockerId  int       `json:"blocker_id"  gorm:"column:blocker_id;primaryKey"`
+	CreatedBy  int       `json:"created_by"  gorm:"column:created_by;not null"`
+	CreatedAt  time.Time `json:"created_at"  gorm:"column:created_at;autoCreateTime"`
+}
+
+func (ActivityDependency) TableName() string { return "activity_dependencies" }
+
+// NextActivity places an open activity in the order work can proceed in.
+// Depth is 0 for activities that can start now and otherwise one more than
+// the deepest of its open blockers.
+type NextActivity struct {
+	Activity     Activity
+	Depth        int
+	OpenBlockers int
+}
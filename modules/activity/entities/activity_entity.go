@@ -1,14 +1,94 @@
 package entities
 
-import "time"
+import (
+	"time"
+	"todolist-v1/pkg/date"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+const (
+	CategoryTask  = "TASK"
+	CategoryEvent = "EVENT"
+)
+
+const (
+	RecurrenceDaily   = "daily"
+	RecurrenceWeekly  = "weekly"
+	RecurrenceMonthly = "monthly"
+)
+
+// Statuses of an activity. New and in-progress activities are open; an
+// open activity blocks the activities depending on it.
+const (
+	StatusNew        = "NEW"
+	StatusInProgress = "ON PROGRESS"
+	StatusExpired    = "EXPIRED"
+	StatusDone       = "DONE"
+)
+
+var OpenStatuses = []string{StatusNew, StatusInProgress}
+
+// PriorityDefault is the priority of activities created without one. P1 is
+// the most urgent.
+const PriorityDefault = "P4"
 
 type Activity struct {
-	Id           int       `json:"id"            gorm:"column:id;primaryKey;autoIncrement"`
-	Title        string    `json:"title"         gorm:"column:title;size:250;not null"`
-	Category     string    `json:"category"      gorm:"column:category;not null"`
-	Description  string    `json:"description"   gorm:"column:description;type:text;not null"`
-	ActivityDate time.Time `json:"activity_date" gorm:"column:activity_date;not null"`
-	Status       string    `json:"status"        gorm:"column:status;not null;default:NEW"`
+	Id             int            `json:"id"            gorm:"column:id;primaryKey;autoIncrement"`
+	OwnerId        int            `json:"owner_id"      gorm:"column:owner_id;not null"`
+	WorkspaceId    *int           `json:"workspace_id"  gorm:"column:workspace_id"`
+	Title          string         `json:"title"         gorm:"column:title;size:250;not null"`
+	Category       string         `json:"category"      gorm:"column:category;not null"`
+	Description    string         `json:"description"   gorm:"column:description;type:text;not null"`
+	ActivityDate   time.Time      `json:"activity_date" gorm:"column:activity_date;not null"`
+	Status         string         `json:"status"        gorm:"column:status;not null;default:NEW"`
+	Priority       string         `json:"priority"      gorm:"column:priority;not null;default:P4"`
+	DeletedAt      gorm.DeletedAt `json:"-"             gorm:"column:deleted_at;index"`
+	CommentCount   int            `json:"-"             gorm:"column:comment_count;->"`
+	TrackedSeconds int64          `json:"-"             gorm:"column:tracked_seconds;->"`
+
+	// StartAt and EndAt are only set for events, DueAt only for tasks.
+	// ActivityDate follows the start of an event and the deadline of a task.
+	StartAt *time.Time `json:"start_at" gorm:"column:start_at"`
+	EndAt   *time.Time `json:"end_at"   gorm:"column:end_at"`
+	DueAt   *time.Time `json:"due_at"   gorm:"column:due_at"`
+
+	// Timezone is the IANA zone the activity was planned in. All-day
+	// activities keep their day in LocalDate and start at its midnight in
+	// Timezone.
+	Timezone  *string    `json:"timezone"   gorm:"column:timezone;size:64"`
+	AllDay    bool       `json:"all_day"    gorm:"column:all_day;not null;default:false"`
+	LocalDate *date.Date `json:"local_date" gorm:"column:local_date;type:date"`
+
+	// OriginalActivityDate is the date before the activity was first snoozed
+	// or rescheduled; RescheduleCount counts how often that happened.
+	OriginalActivityDate *time.Time `json:"original_activity_date" gorm:"column:original_activity_date"`
+	RescheduleCount      int        `json:"reschedule_count"       gorm:"column:reschedule_count;not null;default:0"`
+
+	// A recurring activity repeats every RecurrenceInterval days, weeks or
+	// months from ActivityDate, at the same time of day in Timezone, up to
+	// and including RecurrenceUntil.
+	RecurrenceFrequency *string    `json:"recurrence_frequency" gorm:"column:recurrence_frequency"`
+	RecurrenceInterval  int        `json:"recurrence_interval"  gorm:"column:recurrence_interval;not null;default:1"`
+	RecurrenceUntil     *time.Time `json:"recurrence_until"     gorm:"column:recurrence_until"`
+
+	// Activities of a project are ordered within their board column by
+	// Rank, which compares bytewise.
+	ProjectId *int    `json:"project_id" gorm:"column:project_id"`
+	Rank      *string `json:"rank"       gorm:"column:rank;size:255"`
+
+	// ParentId points to the activity this one is a step of, such as an
+	// item of a template's checklist.
+	ParentId *int `json:"parent_id" gorm:"column:parent_id"`
+
+	// Tags are lowercase labels without the leading #.
+	Tags pq.StringArray `json:"tags" gorm:"column:tags;type:text[];not null"`
+
+	// Archived activities are left out of the list unless asked for;
+	// pinned ones are listed first.
+	Archived bool `json:"archived" gorm:"column:archived;not null;default:false"`
+	Pinned   bool `json:"pinned"   gorm:"column:pinned;not null;default:false"`
 }
 
 func (Activity) TableName() string { return "activities" }
@@ -0,0 +1,13 @@
+package entities
+
+// Names of the events published for activities and their comments.
+const (
+	EventActivityCreated  = "activity.created"
+	EventActivityUpdated  = "activity.updated"
+	EventActivityDeleted  = "activity.deleted"
+	EventActivityRestored = "activity.restored"
+	EventActivityPurged   = "activity.purged"
+	EventCommentCreated   = "comment.created"
+	EventCommentUpdated   = "comment.updated"
+	EventCommentDeleted   = "comment.deleted"
+)
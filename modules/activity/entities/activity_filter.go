@@ -0,0 +1,38 @@
+package entities
+
+import "time"
+
+// Orders the activity list can be sorted in.
+const (
+	// SortPriority lists the most urgent activities first, by priority and
+	// then by due date. Except in rank order, pinned activities come first.
+	SortPriority = "priority"
+	// SortDueDate lists activities by due date and then by priority.
+	SortDueDate = "due_at"
+	// SortRank lists activities in their manual board order.
+	SortRank = "rank"
+)
+
+// ActivityFilter narrows down and orders the activities listed for a user.
+type ActivityFilter struct {
+	WorkspaceId *int
+	ProjectId   *int
+	// ParentId keeps only the children of an activity when set.
+	ParentId *int
+	// Tags keeps only activities carrying all of the tags when set.
+	Tags []string
+	// Statuses, Categories and Priorities keep only activities with one of
+	// the listed values when set.
+	Statuses   []string
+	Categories []string
+	Priorities []string
+	// Overdue keeps only open activities whose date has passed.
+	Overdue bool
+	// Archived lists the archived activities instead of the others.
+	Archived bool
+	// Query is a filter expression, as read by package filterquery, whose
+	// days start at midnight in Location, or in UTC when it is nil.
+	Query    string
+	Location *time.Location
+	Sort     string
+}
@@ -0,0 +1,34 @@
+package entities
+
+import "time"
+
+const (
+	HistoryActionCreate  = "create"
+	HistoryActionUpdate  = "update"
+	HistoryActionDelete  = "delete"
+	HistoryActionRestore = "restore"
+)
+
+// FieldChange is the value of one field before and after a change. Old is
+// nil for fields set by the creation of the activity.
+type FieldChange struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// ActivityHistory is one revision of an activity. Snapshot holds the whole
+// activity as it was after the change, Changes only the fields that differ
+// from the previous revision.
+type ActivityHistory struct {
+	Id         int                    `json:"id"          gorm:"column:id;primaryKey;autoIncrement"`
+	ActivityId int                    `json:"activity_id" gorm:"column:activity_id;not null"`
+	Revision   int                    `json:"revision"    gorm:"column:revision;not null"`
+	ActorId    *int                   `json:"actor_id"    gorm:"column:actor_id"`
+	Action     string                 `json:"action"      gorm:"column:action;not null"`
+	Changes    map[string]FieldChange `json:"changes"     gorm:"column:changes;type:jsonb;serializer:json"`
+	Snapshot   Activity               `json:"snapshot"    gorm:"column:snapshot;type:jsonb;serializer:json"`
+	CreatedAt  time.Time              `json:"created_at"  gorm:"column:created_at;autoCreateTime"`
+	ActorEmail string                 `json:"actor_email" gorm:"column:actor_email;->"`
+}
+
+func (ActivityHistory) TableName() string { return "activity_history" }
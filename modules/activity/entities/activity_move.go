@@ -0,0 +1,11 @@
+package entities
+
+// Move puts an activity in the board column of Status, in ProjectId or, when
+// nil, its current project. It lands right after AfterId and/or right before
+// BeforeId, which must be in that column, or at the bottom without either.
+type Move struct {
+	Status    string
+	ProjectId *int
+	AfterId   *int
+	BeforeId  *int
+}
@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"time"
+	"todolist-v1/pkg/date"
+)
+
+// ActivityOccurrence is one time an activity takes place: its date for a
+// single activity, each repetition for a recurring one. Day is the calendar
+// day the occurrence falls on.
+type ActivityOccurrence struct {
+	Activity
+	OccursAt time.Time `gorm:"column:occurs_at"`
+	Day      date.Date `gorm:"column:day"`
+}
+
+// DayStatusCount is the number of occurrences with the given status on a day.
+type DayStatusCount struct {
+	Day    date.Date `gorm:"column:day"`
+	Status string    `gorm:"column:status"`
+	Count  int       `gorm:"column:count"`
+}
+
+// BusyPeriod is a time taken by an event.
+type BusyPeriod struct {
+	Start time.Time `gorm:"column:start_at"`
+	End   time.Time `gorm:"column:end_at"`
+}
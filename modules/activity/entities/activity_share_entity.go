@@ -0,0 +1,32 @@
+package entities
+
+import "time"
+
+const (
+	SharePermissionRead = "read"
+	SharePermissionEdit = "edit"
+)
+
+type ActivityShare struct {
+	ActivityId int       `json:"activity_id" gorm:"column:activity_id;primaryKey"`
+	UserId     int       `json:"user_id"     gorm:"column:user_id;primaryKey"`
+	Permission string    `json:"permission"  gorm:"column:permission;not null"`
+	CreatedBy  int       `json:"created_by"  gorm:"column:created_by;not null"`
+	CreatedAt  time.Time `json:"created_at"  gorm:"column:created_at;autoCreateTime"`
+	Email      string    `json:"email"       gorm:"column:email;->"`
+	Name       string    `json:"name"        gorm:"column:name;->"`
+}
+
+func (ActivityShare) TableName() string { return "activity_shares" }
+
+type ActivityShareLink struct {
+	Id         int        `json:"id"          gorm:"column:id;primaryKey;autoIncrement"`
+	ActivityId int        `json:"activity_id" gorm:"column:activity_id;not null"`
+	TokenHash  string     `json:"-"           gorm:"column:token_hash;size:64;not null;unique"`
+	ExpiresAt  time.Time  `json:"expires_at"  gorm:"column:expires_at;not null"`
+	CreatedBy  int        `json:"created_by"  gorm:"column:created_by;not null"`
+	CreatedAt  time.Time  `json:"created_at"  gorm:"column:created_at;autoCreateTime"`
+	RevokedAt  *time.Time `json:"revoked_at"  gorm:"column:revoked_at"`
+}
+
+func (ActivityShareLink) TableName() string { return "activity_share_links" }
@@ -0,0 +1,18 @@
+package entities
+
+import "time"
+
+// Snooze presets, resolved in the caller's timezone.
+const (
+	SnoozeLaterToday      = "later_today"
+	SnoozeTomorrowMorning = "tomorrow_morning"
+	SnoozeNextWeek        = "next_week"
+)
+
+// Snooze moves an activity either to a preset time or by Duration from now.
+// Exactly one of Preset and Duration is set.
+type Snooze struct {
+	Preset   string
+	Duration time.Duration
+	Location *time.Location
+}
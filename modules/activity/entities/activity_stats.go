@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"time"
+	"todolist-v1/pkg/date"
+)
+
+// DayCompletion counts the activities dated on a day and how many of them
+// are done.
+type DayCompletion struct {
+	Day  date.Date `gorm:"column:day"`
+	Due  int       `gorm:"column:due"`
+	Done int       `gorm:"column:done"`
+}
+
+// CategoryStatusCount counts activities by category and status.
+type CategoryStatusCount struct {
+	Category string `gorm:"column:category"`
+	Status   string `gorm:"column:status"`
+	Count    int    `gorm:"column:count"`
+}
+
+// CompletionSummary counts the moves to DONE in a period, as recorded in
+// the history, and how long after their creation the activities were done
+// on average. AverageSeconds is nil when nothing was completed.
+type CompletionSummary struct {
+	Completed      int    `gorm:"column:completed"`
+	AverageSeconds *int64 `gorm:"column:average_seconds"`
+}
+
+// ActivityStats describes the activities dated from From to To, inclusive,
+// with days taken in Location. Overdue counts those of them still open
+// although their date passed.
+type ActivityStats struct {
+	From        date.Date
+	To          date.Date
+	Location    *time.Location
+	Days        []DayCompletion
+	Overdue     int
+	Completions CompletionSummary
+	ByCategory  map[string]int
+	ByStatus    map[string]int
+}
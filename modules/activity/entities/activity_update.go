@@ -0,0 +1,13 @@
+package entities
+
+import "time"
+
+// ActivityUpdate replaces an activity with Activity. Clients unaware of
+// some fields leave them out, which keeps their current values: a nil
+// AllDay, and an empty Priority, nil Tags or nil Timezone in Activity. An
+// all-day activity left without a timezone gets Location.
+type ActivityUpdate struct {
+	Activity Activity
+	AllDay   *bool
+	Location *time.Location
+}
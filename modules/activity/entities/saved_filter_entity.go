@@ -0,0 +1,46 @@
+package entities
+
+import "time"
+
+// FilterDocument is the stored form of an activity filter. Leaving a field
+// out leaves that criterion out.
+type FilterDocument struct {
+	WorkspaceId *int     `json:"workspace_id,omitempty"`
+	ProjectId   *int     `json:"project_id,omitempty"`
+	Statuses    []string `json:"statuses,omitempty"`
+	Categories  []string `json:"categories,omitempty"`
+	Priorities  []string `json:"priorities,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Overdue     bool     `json:"overdue,omitempty"`
+	Sort        string   `json:"sort,omitempty"`
+}
+
+// ActivityFilter returns the filter the list of activities is narrowed
+// down with.
+func (document FilterDocument) ActivityFilter() ActivityFilter {
+	return ActivityFilter{
+		WorkspaceId: document.WorkspaceId,
+		ProjectId:   document.ProjectId,
+		Tags:        document.Tags,
+		Statuses:    document.Statuses,
+		Categories:  document.Categories,
+		Priorities:  document.Priorities,
+		Overdue:     document.Overdue,
+		Sort:        document.Sort,
+	}
+}
+
+// SavedFilter is a named filter, such as "overdue P1 tasks tagged
+// backend". Personal filters belong to their owner, workspace filters are
+// shared with every member, who each see the activities visible to them.
+type SavedFilter struct {
+	Id          int            `json:"id"           gorm:"column:id;primaryKey;autoIncrement"`
+	OwnerId     int            `json:"owner_id"     gorm:"column:owner_id;not null"`
+	WorkspaceId *int           `json:"workspace_id" gorm:"column:workspace_id"`
+	Name        string         `json:"name"         gorm:"column:name;size:100;not null"`
+	Filter      FilterDocument `json:"filter"       gorm:"column:filter;type:jsonb;serializer:json;not null"`
+	CreatedAt   time.Time      `json:"created_at"   gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time      `json:"updated_at"   gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (SavedFilter) TableName() string { return "saved_filters" }
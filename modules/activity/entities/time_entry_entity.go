@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"time"
+	"todolist-v1/pkg/date"
+)
+
+// TimeEntry is time UserId spent on an activity, either tracked with a timer
+// or logged by hand. A running timer has no EndedAt.
+type TimeEntry struct {
+	Id         int        `json:"id"          gorm:"column:id;primaryKey;autoIncrement"`
+	ActivityId int        `json:"activity_id" gorm:"column:activity_id;not null"`
+	UserId     int        `json:"user_id"     gorm:"column:user_id;not null"`
+	StartedAt  time.Time  `json:"started_at"  gorm:"column:started_at;not null"`
+	EndedAt    *time.Time `json:"ended_at"    gorm:"column:ended_at"`
+	Note       string     `json:"note"        gorm:"column:note;size:500;not null"`
+	CreatedAt  time.Time  `json:"created_at"  gorm:"column:created_at;autoCreateTime"`
+}
+
+func (TimeEntry) TableName() string { return "time_entries" }
+
+// Dimensions a timesheet can be grouped by. An entry counts towards the day
+// it started on and towards every tag of its activity.
+const (
+	TimesheetByDay      = "day"
+	TimesheetByCategory = "category"
+	TimesheetByTag      = "tag"
+)
+
+// TimesheetQuery asks for the time a user tracked on the days From to To,
+// inclusive, taken in Location.
+type TimesheetQuery struct {
+	From     date.Date
+	To       date.Date
+	Location *time.Location
+	GroupBy  []string
+}
+
+// TimesheetRow is the time tracked in one group. Dimensions the timesheet is
+// not grouped by are nil, as is the tag of untagged activities.
+type TimesheetRow struct {
+	Day      *date.Date `gorm:"column:day"`
+	Category *string    `gorm:"column:category"`
+	Tag      *string    `gorm:"column:tag"`
+	Seconds  int64      `gorm:"column:seconds"`
+	Entries  int        `gorm:"column:entries"`
+}
+
+// Timesheet totals the finished time entries of a user. TotalSeconds counts
+// every entry once, even when rows grouped by tag count it several times.
+type Timesheet struct {
+	TimesheetQuery
+	TotalSeconds int64
+	Rows         []TimesheetRow
+}
@@ -0,0 +1,20 @@
+package entities
+
+import "time"
+
+// RevisionRef points to a revision recorded by a change.
+type RevisionRef struct {
+	ActivityId int `json:"activity_id"`
+	Revision   int `json:"revision"`
+}
+
+// UndoCommand is what one change by a user recorded: the revisions, in
+// order. Undoing it brings every activity back to the revision before.
+type UndoCommand struct {
+	Id        int           `json:"id"         gorm:"column:id;primaryKey;autoIncrement"`
+	UserId    int           `json:"user_id"    gorm:"column:user_id;not null"`
+	Revisions []RevisionRef `json:"revisions"  gorm:"column:revisions;type:jsonb;serializer:json;not null"`
+	PushedAt  time.Time     `json:"pushed_at"  gorm:"column:pushed_at;not null"`
+}
+
+func (UndoCommand) TableName() string { return "undo_commands" }
@@ -0,0 +1,11 @@
+package handler
+
+import "github.com/gofiber/fiber/v2"
+
+type ActivityAttachmentHandler interface {
+	GetAll(ctx *fiber.Ctx) error
+	Upload(ctx *fiber.Ctx) error
+	Download(ctx *fiber.Ctx) error
+	Delete(ctx *fiber.Ctx) error
+	RegisterRoutes()
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"strconv"
+	"todolist-v1/modules/activity/entities"
+	"todolist-v1/modules/activity/models"
+	"todolist-v1/modules/activity/usecase"
+	authEntities "todolist-v1/modules/auth/entities"
+	"todolist-v1/modules/auth/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type activityAttachmentHandlerHttp struct {
+	app            *fiber.App
+	usecase        usecase.ActivityAttachmentUsecase
+	authMiddleware fiber.Handler
+}
+
+func NewActivityAttachmentHttpHandler(app *fiber.App, usecase usecase.ActivityAttachmentUsecase, authMiddleware fiber.Handler) ActivityAttachmentHandler {
+	return &activityAttachmentHandlerHttp{
+		app:            app,
+		usecase:        usecase,
+		authMiddleware: authMiddleware,
+	}
+}
+
+func (handler *activityAttachmentHandlerHttp) GetAll(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+
+	attachments, err := handler.usecase.GetAll(principal.UserId, id)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	attachmentResponses := make([]models.AttachmentResponse, 0, len(attachments))
+	for _, attachment := range attachments {
+		attachmentResponses = append(attachmentResponses, toAttachmentResponse(attachment))
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        attachmentResponses,
+		"status_code": fiber.StatusOK,
+		"message":     "Attachments retrieved successfully",
+	})
+}
+
+func (handler *activityAttachmentHandlerHttp) Upload(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+
+	file, err := ctx.FormFile("file")
+	if err != nil {
+		return badRequest(ctx, "A file must be uploaded in the \"file\" field")
+	}
+	content, err := file.Open()
+	if err != nil {
+		return fail(ctx, err)
+	}
+	defer content.Close()
+
+	attachment, err := handler.usecase.Upload(principal.UserId, id, file.Filename, content, file.Size)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data":        toAttachmentResponse(attachment),
+		"status_code": fiber.StatusCreated,
+		"message":     "Attachment uploaded successfully",
+	})
+}
+
+func (handler *activityAttachmentHandlerHttp) Download(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+	attachmentId, err := strconv.Atoi(ctx.Params("attachmentId"))
+	if err != nil {
+		return badRequest(ctx, "Invalid attachment ID")
+	}
+
+	attachment, content, err := handler.usecase.Download(principal.UserId, id, attachmentId)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	ctx.Attachment(attachment.FileName)
+	ctx.Set(fiber.HeaderContentType, attachment.ContentType)
+	ctx.Set("X-Checksum-Sha256", attachment.ChecksumSha256)
+	// The stream is closed once it has been sent.
+	return ctx.SendStream(content, int(attachment.Size))
+}
+
+func (handler *activityAttachmentHandlerHttp) Delete(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+	attachmentId, err := strconv.Atoi(ctx.Params("attachmentId"))
+	if err != nil {
+		return badRequest(ctx, "Invalid attachment ID")
+	}
+
+	if err := handler.usecase.Delete(principal.UserId, id, attachmentId); err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusOK,
+		"message":     "Attachment deleted successfully",
+	})
+}
+
+func (handler *activityAttachmentHandlerHttp) RegisterRoutes() {
+	activities := handler.app.Group("/api/activities/:id")
+	canRead := middleware.RequireScope(authEntities.ScopeActivitiesRead)
+	canWrite := middleware.RequireScope(authEntities.ScopeActivitiesWrite)
+
+	activities.Get("/attachments", handler.authMiddleware, canRead, handler.GetAll)
+	activities.Post("/attachments", handler.authMiddleware, canWrite, handler.Upload)
+	activities.Get("/attachments/:attachmentId", handler.authMiddleware, canRead, handler.Download)
+	activities.Delete("/attachments/:attachmentId", handler.authMiddleware, canWrite, handler.Delete)
+}
+
+func toAttachmentResponse(attachment entities.ActivityAttachment) models.AttachmentResponse {
+	return models.AttachmentResponse{
+		Id:             attachment.Id,
+		FileName:       attachment.FileName,
+		ContentType:    attachment.ContentType,
+		Size:           attachment.Size,
+		ChecksumSha256: attachment.ChecksumSha256,
+		UploadedBy:     attachment.UploadedBy,
+		CreatedAt:      attachment.CreatedAt,
+	}
+}
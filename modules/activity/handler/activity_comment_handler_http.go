@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"strconv"
+	"todolist-v1/modules/activity/entities"
+	"todolist-v1/modules/activity/models"
+	"todolist-v1/modules/activity/usecase"
+	authEntities "todolist-v1/modules/auth/entities"
+	"todolist-v1/modules/auth/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type activityCommentHandlerHttp struct {
+	app            *fiber.App
+	usecase        usecase.ActivityCommentUsecase
+	authMiddleware fiber.Handler
+	validate       *validator.Validate
+}
+
+func NewActivityCommentHttpHandler(app *fiber.App, usecase usecase.ActivityCommentUsecase, authMiddleware fiber.Handler) ActivityCommentHandler {
+	return &activityCommentHandlerHttp{
+		app:            app,
+		usecase:        usecase,
+		authMiddleware: authMiddleware,
+		validate:       validator.New(),
+	}
+}
+
+func (handler *activityCommentHandlerHttp) GetAll(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+
+	comments, err := handler.usecase.GetAll(principal.UserId, id)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	commentResponses := make([]models.CommentResponse, 0, len(comments))
+	for _, comment := range comments {
+		commentResponses = append(commentResponses, toCommentResponse(comment))
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        commentResponses,
+		"status_code": fiber.StatusOK,
+		"message":     "Comments retrieved successfully",
+	})
+}
+
+func (handler *activityCommentHandlerHttp) Create(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+
+	var request models.CommentRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return badRequest(ctx, "Cannot parse JSON")
+	}
+	if err := handler.validate.Struct(request); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+
+	comment, err := handler.usecase.Create(principal.UserId, id, request.Body)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data":        toCommentResponse(comment),
+		"status_code": fiber.StatusCreated,
+		"message":     "Comment created successfully",
+	})
+}
+
+func (handler *activityCommentHandlerHttp) Update(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+	commentId, err := strconv.Atoi(ctx.Params("commentId"))
+	if err != nil {
+		return badRequest(ctx, "Invalid comment ID")
+	}
+
+	var request models.CommentRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return badRequest(ctx, "Cannot parse JSON")
+	}
+	if err := handler.validate.Struct(request); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+
+	comment, err := handler.usecase.Update(principal.UserId, id, commentId, request.Body)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        toCommentResponse(comment),
+		"status_code": fiber.StatusOK,
+		"message":     "Comment updated successfully",
+	})
+}
+
+func (handler *activityCommentHandlerHttp) Delete(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+	commentId, err := strconv.Atoi(ctx.Params("commentId"))
+	if err != nil {
+		return badRequest(ctx, "Invalid comment ID")
+	}
+
+	if err := handler.usecase.Delete(principal.UserId, id, commentId); err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusOK,
+		"message":     "Comment deleted successfully",
+	})
+}
+
+func (handler *activityCommentHandlerHttp) RegisterRoutes() {
+	activities := handler.app.Group("/api/activities/:id")
+	canRead := middleware.RequireScope(authEntities.ScopeActivitiesRead)
+	canWrite := middleware.RequireScope(authEntities.ScopeActivitiesWrite)
+
+	activities.Get("/comments", handler.authMiddleware, canRead, handler.GetAll)
+	activities.Post("/comments", handler.authMiddleware, canWrite, handler.Create)
+	activities.Put("/comments/:commentId", handler.authMiddleware, canWrite, handler.Update)
+	activities.Delete("/comments/:commentId", handler.authMiddleware, canWrite, handler.Delete)
+}
+
+func toCommentResponse(comment entities.ActivityComment) models.CommentResponse {
+	mentions := make([]models.MentionResponse, 0, len(comment.Mentions))
+	for _, mention := range comment.Mentions {
+		mentions = append(mentions, models.MentionResponse{
+			UserId: mention.UserId,
+			Email:  mention.Email,
+			Name:   mention.Name,
+		})
+	}
+
+	return models.CommentResponse{
+		Id:          comment.Id,
+		ActivityId:  comment.ActivityId,
+		AuthorId:    comment.AuthorId,
+		AuthorEmail: comment.AuthorEmail,
+		AuthorName:  comment.AuthorName,
+		Body:        comment.Body,
+		Mentions:    mentions,
+		CreatedAt:   comment.CreatedAt,
+		UpdatedAt:   comment.UpdatedAt,
+	}
+}
@@ -0,0 +1,12 @@
+package handler
+
+import "github.com/gofiber/fiber/v2"
+
+type ActivityDependencyHandler interface {
+	GetBlockers(ctx *fiber.Ctx) error
+	GetDependents(ctx *fiber.Ctx) error
+	AddBlocker(ctx *fiber.Ctx) error
+	RemoveBlocker(ctx *fiber.Ctx) error
+	Next(ctx *fiber.Ctx) error
+	RegisterRoutes()
+}
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"strconv"
+	"time"
+	"todolist-v1/modules/activity/entities"
+	"todolist-v1/modules/activity/models"
+	"todolist-v1/modules/activity/usecase"
+	authEntities "todolist-v1/modules/auth/entities"
+	"todolist-v1/modules/auth/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type activityDependencyHandlerHttp struct {
+	app            *fiber.App
+	usecase        usecase.ActivityDependencyUsecase
+	authMiddleware fiber.Handler
+	validate       *validator.Validate
+}
+
+func NewActivityDependencyHttpHandler(app *fiber.App, usecase usecase.ActivityDependencyUsecase, authMiddleware fiber.Handler) ActivityDependencyHandler {
+	return &activityDependencyHandlerHttp{
+		app:            app,
+		usecase:        usecase,
+		authMiddleware: authMiddleware,
+		validate:       validator.New(),
+	}
+}
+
+func (handler *activityDependencyHandlerHttp) GetBlockers(ctx *fiber.Ctx) error {
+	return handler.list(ctx, handler.usecase.GetBlockers, "Blockers retrieved successfully")
+}
+
+func (handler *activityDependencyHandlerHttp) GetDependents(ctx *fiber.Ctx) error {
+	return handler.list(ctx, handler.usecase.GetDependents, "Dependents retrieved successfully")
+}
+
+func (handler *activityDependencyHandlerHttp) list(ctx *fiber.Ctx, find func(userId int, activityId int) ([]entities.Activity, error), message string) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+
+	activities, err := find(principal.UserId, id)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	responses := make([]models.ActivityResponse, 0, len(activities))
+	for _, activity := range activities {
+		responses = append(responses, toActivityResponse(activity, location))
+	}
+	return ctx.JSON(fiber.Map{
+		"data":        responses,
+		"status_code": fiber.StatusOK,
+		"message":     message,
+	})
+}
+
+func (handler *activityDependencyHandlerHttp) AddBlocker(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+
+	var request models.BlockerRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return badRequest(ctx, "Cannot parse JSON")
+	}
+	if err := handler.validate.Struct(request); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+
+	dependency, err := handler.usecase.AddBlocker(principal.UserId, id, request.BlockerId)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data": models.DependencyResponse{
+			ActivityId: dependency.ActivityId,
+			BlockerId:  dependency.BlockerId,
+			CreatedBy:  dependency.CreatedBy,
+			CreatedAt:  dependency.CreatedAt,
+		},
+		"status_code": fiber.StatusCreated,
+		"message":     "Blocker added successfully",
+	})
+}
+
+func (handler *activityDependencyHandlerHttp) RemoveBlocker(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+	blockerId, err := strconv.Atoi(ctx.Params("blockerId"))
+	if err != nil {
+		return badRequest(ctx, "Invalid blocker ID")
+	}
+
+	if err := handler.usecase.RemoveBlocker(principal.UserId, id, blockerId); err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusOK,
+		"message":     "Blocker removed successfully",
+	})
+}
+
+func (handler *activityDependencyHandlerHttp) Next(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	next, err := handler.usecase.Next(principal.UserId)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        toNextActivityResponses(next, location),
+		"status_code": fiber.StatusOK,
+		"message":     "Next activities retrieved successfully",
+	})
+}
+
+func (handler *activityDependencyHandlerHttp) RegisterRoutes() {
+	activities := handler.app.Group("/api/activities")
+	canRead := middleware.RequireScope(authEntities.ScopeActivitiesRead)
+	canWrite := middleware.RequireScope(authEntities.ScopeActivitiesWrite)
+
+	activities.Get("/next", handler.authMiddleware, canRead, handler.Next)
+	activities.Get("/:id/blockers", handler.authMiddleware, canRead, handler.GetBlockers)
+	activities.Post("/:id/blockers", handler.authMiddleware, canWrite, handler.AddBlocker)
+	activities.Delete("/:id/blockers/:blockerId", handler.authMiddleware, canWrite, handler.RemoveBlocker)
+	activities.Get("/:id/dependents", handler.authMiddleware, canRead, handler.GetDependents)
+}
+
+func toNextActivityResponses(next []entities.NextActivity, location *time.Location) []models.NextActivityResponse {
+	responses := make([]models.NextActivityResponse, 0, len(next))
+	for _, item := range next {
+		responses = append(responses, models.NextActivityResponse{
+			Activity:     toActivityResponse(item.Activity, location),
+			Depth:        item.Depth,
+			OpenBlockers: item.OpenBlockers,
+		})
+	}
+	return responses
+}
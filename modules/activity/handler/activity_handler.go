@@ -5,7 +5,20 @@ import "github.com/gofiber/fiber/v2"
 type ActivityHandler interface {
 	GetAll(ctx *fiber.Ctx) error
 	Create(ctx *fiber.Ctx) error
+	QuickAdd(ctx *fiber.Ctx) error
 	Update(ctx *fiber.Ctx) error
 	Delete(ctx *fiber.Ctx) error
+	GetHistory(ctx *fiber.Ctx) error
+	RestoreRevision(ctx *fiber.Ctx) error
+	Purge(ctx *fiber.Ctx) error
+	Snooze(ctx *fiber.Ctx) error
+	RescheduleOverdue(ctx *fiber.Ctx) error
+	Move(ctx *fiber.Ctx) error
+	Archive(ctx *fiber.Ctx) error
+	Unarchive(ctx *fiber.Ctx) error
+	Pin(ctx *fiber.Ctx) error
+	Unpin(ctx *fiber.Ctx) error
+	ArchiveMatching(ctx *fiber.Ctx) error
+	Undo(ctx *fiber.Ctx) error
 	RegisterRoutes()
 }
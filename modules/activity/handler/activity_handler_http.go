@@ -3,49 +3,108 @@ package handler
 import (
 	"errors"
 	"strconv"
+	"strings"
+	"time"
 	"todolist-v1/modules/activity/entities"
 	"todolist-v1/modules/activity/models"
 	"todolist-v1/modules/activity/repository"
 	"todolist-v1/modules/activity/usecase"
+	authEntities "todolist-v1/modules/auth/entities"
+	"todolist-v1/modules/auth/middleware"
+	authRepo "todolist-v1/modules/auth/repository"
+	projectRepo "todolist-v1/modules/project/repository"
+	"todolist-v1/pkg/date"
+	"todolist-v1/pkg/filterquery"
+	"todolist-v1/pkg/quickadd"
+	"todolist-v1/pkg/storage"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 )
 
 type activityHandlerHttp struct {
-	app      *fiber.App
-	usecase  usecase.ActivityUsecase
-	validate *validator.Validate
+	app            *fiber.App
+	usecase        usecase.ActivityUsecase
+	authMiddleware fiber.Handler
+	validate       *validator.Validate
 }
 
-func NewActivityHttpHandler(app *fiber.App, usecase usecase.ActivityUsecase) ActivityHandler {
+func NewActivityHttpHandler(app *fiber.App, usecase usecase.ActivityUsecase, authMiddleware fiber.Handler) ActivityHandler {
 	return &activityHandlerHttp{
-		app:      app,
-		usecase:  usecase,
-		validate: validator.New(),
+		app:            app,
+		usecase:        usecase,
+		authMiddleware: authMiddleware,
+		validate:       validator.New(),
 	}
 }
 
 func (handler *activityHandlerHttp) GetAll(ctx *fiber.Ctx) error {
-	activities, err := handler.usecase.GetAll()
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
 	if err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"data":        nil,
-			"status_code": fiber.StatusInternalServerError,
-			"message":     err.Error(),
-		})
+		return badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	var workspaceId *int
+	if raw := ctx.Query("workspace_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"data":        nil,
+				"status_code": fiber.StatusBadRequest,
+				"message":     "Invalid workspace_id",
+			})
+		}
+		workspaceId = &id
+	}
+	var projectId *int
+	if raw := ctx.Query("project_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil {
+			return badRequest(ctx, "Invalid project_id")
+		}
+		projectId = &id
+	}
+	var tags []string
+	if tag := strings.ToLower(strings.TrimPrefix(ctx.Query("tag"), "#")); tag != "" {
+		tags = []string{tag}
+	}
+	var parentId *int
+	if raw := ctx.Query("parent_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil {
+			return badRequest(ctx, "Invalid parent_id")
+		}
+		parentId = &id
+	}
+	archived := false
+	if raw := ctx.Query("archived"); raw != "" {
+		if archived, err = strconv.ParseBool(raw); err != nil {
+			return badRequest(ctx, "Invalid archived")
+		}
+	}
+	sort := ctx.Query("sort")
+	if sort != "" && sort != entities.SortPriority && sort != entities.SortDueDate && sort != entities.SortRank {
+		return badRequest(ctx, "Invalid sort, expected priority, due_at or rank")
+	}
+
+	activities, err := handler.usecase.GetAll(principal.UserId, entities.ActivityFilter{
+		WorkspaceId: workspaceId,
+		ProjectId:   projectId,
+		ParentId:    parentId,
+		Tags:        tags,
+		Query:       strings.TrimSpace(ctx.Query("q")),
+		Location:    location,
+		Archived:    archived,
+		Sort:        sort,
+	})
+	if err != nil {
+		return fail(ctx, err)
 	}
 
 	var activityResponses []models.ActivityResponse
 	for _, a := range activities {
-		activityResponses = append(activityResponses, models.ActivityResponse{
-			Id:           a.Id,
-			Title:        a.Title,
-			Category:     a.Category,
-			Description:  a.Description,
-			ActivityDate: a.ActivityDate,
-			Status:       a.Status,
-		})
+		activityResponses = append(activityResponses, toActivityResponse(a, location))
 	}
 
 	return ctx.JSON(fiber.Map{
@@ -56,6 +115,12 @@ func (handler *activityHandlerHttp) GetAll(ctx *fiber.Ctx) error {
 }
 
 func (handler *activityHandlerHttp) Create(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return badRequest(ctx, "Invalid X-Timezone header")
+	}
+
 	var request models.ActivityCreateRequest
 	if err := ctx.BodyParser(&request); err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -73,37 +138,101 @@ func (handler *activityHandlerHttp) Create(ctx *fiber.Ctx) error {
 		})
 	}
 
+	return handler.create(ctx, principal.UserId, request, location)
+}
+
+// QuickAdd creates an activity from a single line of text, reading its day
+// and time in the caller's preferred timezone. The line is kept as the
+// description.
+func (handler *activityHandlerHttp) QuickAdd(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	var request models.QuickAddRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return badRequest(ctx, "Cannot parse JSON")
+	}
+	if err := handler.validate.Struct(request); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+
+	parsed, err := quickadd.Parse(request.Text, time.Now().In(location))
+	if err != nil {
+		return badRequest(ctx, err.Error())
+	}
+	create := models.ActivityCreateRequest{
+		WorkspaceId: request.WorkspaceId,
+		ProjectId:   request.ProjectId,
+		Title:       parsed.Title,
+		Category:    parsed.Category,
+		Description: request.Text,
+		Priority:    parsed.Priority,
+		AllDay:      parsed.AllDay,
+		Tags:        parsed.Tags,
+	}
+	if parsed.Category == entities.CategoryEvent {
+		create.StartAt = &parsed.At
+	} else {
+		create.DueAt = &parsed.At
+	}
+	// The parsed activity goes through the same checks as a typed one.
+	if err := handler.validate.Struct(create); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+
+	return handler.create(ctx, principal.UserId, create, location)
+}
+
+func (handler *activityHandlerHttp) create(ctx *fiber.Ctx, userId int, request models.ActivityCreateRequest, location *time.Location) error {
 	activityEntity := entities.Activity{
+		WorkspaceId:  request.WorkspaceId,
+		ProjectId:    request.ProjectId,
 		Title:        request.Title,
 		Category:     request.Category,
 		Description:  request.Description,
 		ActivityDate: request.ActivityDate,
+		Priority:     request.Priority,
+		StartAt:      request.StartAt,
+		EndAt:        request.EndAt,
+		DueAt:        request.DueAt,
+		Timezone:     request.Timezone,
+		AllDay:       request.AllDay,
+		Tags:         request.Tags,
+	}
+	if activityEntity.AllDay && activityEntity.Timezone == nil {
+		timezone := location.String()
+		activityEntity.Timezone = &timezone
+	}
+	if request.Recurrence != nil {
+		activityEntity.RecurrenceFrequency = &request.Recurrence.Frequency
+		activityEntity.RecurrenceInterval = request.Recurrence.Interval
+		activityEntity.RecurrenceUntil = request.Recurrence.Until
 	}
 
-	newActivity, err := handler.usecase.Create(activityEntity)
+	newActivity, conflicts, err := handler.usecase.Create(userId, activityEntity, conflictPolicy(request.RejectConflicts))
 	if err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"data":        nil,
-			"status_code": fiber.StatusInternalServerError,
-			"message":     err.Error(),
-		})
+		return failWrite(ctx, err, location)
 	}
 
+	response := toActivityResponse(newActivity, location)
+	response.Conflicts = toConflictResponses(conflicts, location)
 	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"data": models.ActivityResponse{
-			Id:           newActivity.Id,
-			Title:        newActivity.Title,
-			Category:     newActivity.Category,
-			Description:  newActivity.Description,
-			ActivityDate: newActivity.ActivityDate,
-			Status:       newActivity.Status,
-		},
+		"data":        response,
 		"status_code": fiber.StatusCreated,
 		"message":     "Activity created successfully",
 	})
 }
 
 func (handler *activityHandlerHttp) Update(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return badRequest(ctx, "Invalid X-Timezone header")
+	}
+
 	id, err := strconv.Atoi(ctx.Params("id"))
 	if err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -136,40 +265,40 @@ func (handler *activityHandlerHttp) Update(ctx *fiber.Ctx) error {
 		Description:  request.Description,
 		ActivityDate: request.ActivityDate,
 		Status:       request.Status,
+		Priority:     request.Priority,
+		StartAt:      request.StartAt,
+		EndAt:        request.EndAt,
+		DueAt:        request.DueAt,
+		Timezone:     request.Timezone,
+		Tags:         request.Tags,
+	}
+	if request.Recurrence != nil {
+		activityEntity.RecurrenceFrequency = &request.Recurrence.Frequency
+		activityEntity.RecurrenceInterval = request.Recurrence.Interval
+		activityEntity.RecurrenceUntil = request.Recurrence.Until
 	}
 
-	updatedActivity, err := handler.usecase.Update(id, activityEntity)
+	updatedActivity, conflicts, err := handler.usecase.Update(principal.UserId, id, entities.ActivityUpdate{
+		Activity: activityEntity,
+		AllDay:   request.AllDay,
+		Location: location,
+	}, conflictPolicy(request.RejectConflicts))
 	if err != nil {
-		if errors.Is(err, repository.ErrActivityNotFound) {
-			return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"data":        nil,
-				"status_code": fiber.StatusNotFound,
-				"message":     err.Error(),
-			})
-		}
-
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"data":        nil,
-			"status_code": fiber.StatusInternalServerError,
-			"message":     err.Error(),
-		})
+		return failWrite(ctx, err, location)
 	}
 
+	response := toActivityResponse(updatedActivity, location)
+	response.Conflicts = toConflictResponses(conflicts, location)
 	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
-		"data": models.ActivityResponse{
-			Id:           updatedActivity.Id,
-			Title:        updatedActivity.Title,
-			Category:     updatedActivity.Category,
-			Description:  updatedActivity.Description,
-			ActivityDate: updatedActivity.ActivityDate,
-			Status:       updatedActivity.Status,
-		},
+		"data":        response,
 		"status_code": fiber.StatusOK,
 		"message":     "Activity updated successfully",
 	})
 }
 
 func (handler *activityHandlerHttp) Delete(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
 	id, err := strconv.Atoi(ctx.Params("id"))
 	if err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -179,20 +308,8 @@ func (handler *activityHandlerHttp) Delete(ctx *fiber.Ctx) error {
 		})
 	}
 
-	if err := handler.usecase.Delete(id); err != nil {
-		if errors.Is(err, repository.ErrActivityNotFound) {
-			return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"data":        nil,
-				"status_code": fiber.StatusNotFound,
-				"message":     "Activity not found",
-			})
-		}
-
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"data":        nil,
-			"status_code": fiber.StatusInternalServerError,
-			"message":     err.Error(),
-		})
+	if err := handler.usecase.Delete(principal.UserId, id); err != nil {
+		return fail(ctx, err)
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -202,9 +319,519 @@ func (handler *activityHandlerHttp) Delete(ctx *fiber.Ctx) error {
 	})
 }
 
+func (handler *activityHandlerHttp) GetHistory(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+
+	history, err := handler.usecase.GetHistory(principal.UserId, id)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	historyResponses := make([]models.HistoryResponse, 0, len(history))
+	for _, entry := range history {
+		changes := make(map[string]models.FieldChangeResponse, len(entry.Changes))
+		for field, change := range entry.Changes {
+			changes[field] = models.FieldChangeResponse{Old: change.Old, New: change.New}
+		}
+		historyResponses = append(historyResponses, models.HistoryResponse{
+			Revision:   entry.Revision,
+			Action:     entry.Action,
+			ActorId:    entry.ActorId,
+			ActorEmail: entry.ActorEmail,
+			Changes:    changes,
+			CreatedAt:  entry.CreatedAt,
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        historyResponses,
+		"status_code": fiber.StatusOK,
+		"message":     "History retrieved successfully",
+	})
+}
+
+func (handler *activityHandlerHttp) RestoreRevision(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+	revision, err := strconv.Atoi(ctx.Params("revision"))
+	if err != nil {
+		return badRequest(ctx, "Invalid revision")
+	}
+
+	restored, conflicts, err := handler.usecase.RestoreRevision(principal.UserId, id, revision)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	response := toActivityResponse(restored, location)
+	response.Conflicts = toConflictResponses(conflicts, location)
+	return ctx.JSON(fiber.Map{
+		"data":        response,
+		"status_code": fiber.StatusOK,
+		"message":     "Activity restored successfully",
+	})
+}
+
+func (handler *activityHandlerHttp) Purge(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+
+	if err := handler.usecase.Purge(principal.UserId, id); err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusOK,
+		"message":     "Activity purged successfully",
+	})
+}
+
+func (handler *activityHandlerHttp) Snooze(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+
+	var request models.SnoozeRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return badRequest(ctx, "Cannot parse JSON")
+	}
+	if err := handler.validate.Struct(request); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+	snoozeLocation := location
+	if request.Timezone != "" {
+		if snoozeLocation, err = date.LoadLocation(request.Timezone); err != nil {
+			return badRequest(ctx, "Invalid timezone")
+		}
+	}
+
+	snoozed, conflicts, err := handler.usecase.Snooze(principal.UserId, id, entities.Snooze{
+		Preset:   request.Preset,
+		Duration: time.Duration(request.Minutes) * time.Minute,
+		Location: snoozeLocation,
+	})
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	response := toActivityResponse(snoozed, location)
+	response.Conflicts = toConflictResponses(conflicts, location)
+	return ctx.JSON(fiber.Map{
+		"data":        response,
+		"status_code": fiber.StatusOK,
+		"message":     "Activity snoozed successfully",
+	})
+}
+
+func (handler *activityHandlerHttp) RescheduleOverdue(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	var request models.RescheduleRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return badRequest(ctx, "Cannot parse JSON")
+	}
+	if err := handler.validate.Struct(request); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+
+	rescheduled, conflicts, err := handler.usecase.RescheduleOverdue(principal.UserId, request.Days, request.WorkspaceId)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	activityResponses := make([]models.ActivityResponse, 0, len(rescheduled))
+	for _, activity := range rescheduled {
+		response := toActivityResponse(activity, location)
+		response.Conflicts = toConflictResponses(conflicts[activity.Id], location)
+		activityResponses = append(activityResponses, response)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        activityResponses,
+		"status_code": fiber.StatusOK,
+		"message":     "Overdue activities rescheduled successfully",
+	})
+}
+
+// Move changes the board column and position of an activity at once.
+func (handler *activityHandlerHttp) Move(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+
+	var request models.MoveRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return badRequest(ctx, "Cannot parse JSON")
+	}
+	if err := handler.validate.Struct(request); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+
+	moved, err := handler.usecase.Move(principal.UserId, id, entities.Move{
+		Status:    request.Status,
+		ProjectId: request.ProjectId,
+		AfterId:   request.AfterId,
+		BeforeId:  request.BeforeId,
+	})
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        toActivityResponse(moved, location),
+		"status_code": fiber.StatusOK,
+		"message":     "Activity moved successfully",
+	})
+}
+
+func (handler *activityHandlerHttp) Archive(ctx *fiber.Ctx) error {
+	return handler.setFlag(ctx, "Activity archived successfully", func(userId int, id int) (entities.Activity, error) {
+		return handler.usecase.Archive(userId, id, true)
+	})
+}
+
+func (handler *activityHandlerHttp) Unarchive(ctx *fiber.Ctx) error {
+	return handler.setFlag(ctx, "Activity unarchived successfully", func(userId int, id int) (entities.Activity, error) {
+		return handler.usecase.Archive(userId, id, false)
+	})
+}
+
+func (handler *activityHandlerHttp) Pin(ctx *fiber.Ctx) error {
+	return handler.setFlag(ctx, "Activity pinned successfully", func(userId int, id int) (entities.Activity, error) {
+		return handler.usecase.Pin(userId, id, true)
+	})
+}
+
+func (handler *activityHandlerHttp) Unpin(ctx *fiber.Ctx) error {
+	return handler.setFlag(ctx, "Activity unpinned successfully", func(userId int, id int) (entities.Activity, error) {
+		return handler.usecase.Pin(userId, id, false)
+	})
+}
+
+// setFlag serves the endpoints setting or clearing a flag of the activity
+// in the path.
+func (handler *activityHandlerHttp) setFlag(ctx *fiber.Ctx, message string, apply func(userId int, id int) (entities.Activity, error)) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+
+	activity, err := apply(principal.UserId, id)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        toActivityResponse(activity, location),
+		"status_code": fiber.StatusOK,
+		"message":     message,
+	})
+}
+
+func (handler *activityHandlerHttp) ArchiveMatching(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	var request models.ArchiveRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return badRequest(ctx, "Cannot parse JSON")
+	}
+	if err := handler.validate.Struct(request); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+
+	archived, err := handler.usecase.ArchiveMatching(principal.UserId, entities.ActivityFilter{
+		WorkspaceId: request.WorkspaceId,
+		Query:       request.Query,
+		Location:    location,
+	})
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	activityResponses := make([]models.ActivityResponse, 0, len(archived))
+	for _, activity := range archived {
+		activityResponses = append(activityResponses, toActivityResponse(activity, location))
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        activityResponses,
+		"status_code": fiber.StatusOK,
+		"message":     "Activities archived successfully",
+	})
+}
+
+func (handler *activityHandlerHttp) Undo(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	reverted, conflicts, err := handler.usecase.Undo(principal.UserId)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	activityResponses := make([]models.ActivityResponse, 0, len(reverted))
+	for _, activity := range reverted {
+		response := toActivityResponse(activity, location)
+		response.Conflicts = toConflictResponses(conflicts[activity.Id], location)
+		activityResponses = append(activityResponses, response)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        activityResponses,
+		"status_code": fiber.StatusOK,
+		"message":     "Change undone successfully",
+	})
+}
+
 func (handler *activityHandlerHttp) RegisterRoutes() {
-	handler.app.Get("/api/activities", handler.GetAll)
-	handler.app.Post("/api/activities", handler.Create)
-	handler.app.Put("/api/activities/:id", handler.Update)
-	handler.app.Delete("/api/activities/:id", handler.Delete)
+	// Middleware is attached per route rather than to the group: other
+	// handlers register routes below /api/activities too, and a group-level
+	// middleware would authenticate their requests a second time.
+	activities := handler.app.Group("/api/activities")
+	canRead := middleware.RequireScope(authEntities.ScopeActivitiesRead)
+	canWrite := middleware.RequireScope(authEntities.ScopeActivitiesWrite)
+
+	activities.Get("/", handler.authMiddleware, canRead, handler.GetAll)
+	activities.Post("/", handler.authMiddleware, canWrite, handler.Create)
+	activities.Post("/quick", handler.authMiddleware, canWrite, handler.QuickAdd)
+	activities.Put("/:id", handler.authMiddleware, canWrite, handler.Update)
+	activities.Delete("/:id", handler.authMiddleware, canWrite, handler.Delete)
+	activities.Get("/:id/history", handler.authMiddleware, canRead, handler.GetHistory)
+	activities.Post("/:id/history/:revision/restore", handler.authMiddleware, canWrite, handler.RestoreRevision)
+	activities.Post("/:id/purge", handler.authMiddleware, canWrite, handler.Purge)
+	activities.Post("/:id/snooze", handler.authMiddleware, canWrite, handler.Snooze)
+	activities.Post("/:id/move", handler.authMiddleware, canWrite, handler.Move)
+	activities.Post("/:id/archive", handler.authMiddleware, canWrite, handler.Archive)
+	activities.Post("/:id/unarchive", handler.authMiddleware, canWrite, handler.Unarchive)
+	activities.Post("/:id/pin", handler.authMiddleware, canWrite, handler.Pin)
+	activities.Post("/:id/unpin", handler.authMiddleware, canWrite, handler.Unpin)
+	activities.Post("/reschedule", handler.authMiddleware, canWrite, handler.RescheduleOverdue)
+	activities.Post("/archive", handler.authMiddleware, canWrite, handler.ArchiveMatching)
+	handler.app.Post("/api/undo", handler.authMiddleware, canWrite, handler.Undo)
+}
+
+// fail writes the response for an error returned by the usecases.
+func fail(ctx *fiber.Ctx, err error) error {
+	var queryErr *filterquery.Error
+	if errors.As(err, &queryErr) {
+		return failQuery(ctx, queryErr)
+	}
+
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, repository.ErrActivityNotFound),
+		errors.Is(err, repository.ErrShareNotFound),
+		errors.Is(err, repository.ErrShareLinkNotFound),
+		errors.Is(err, repository.ErrRevisionNotFound),
+		errors.Is(err, repository.ErrCommentNotFound),
+		errors.Is(err, repository.ErrAttachmentNotFound),
+		errors.Is(err, repository.ErrDependencyNotFound),
+		errors.Is(err, repository.ErrTimeEntryNotFound),
+		errors.Is(err, repository.ErrSavedFilterNotFound),
+		errors.Is(err, repository.ErrNoRunningTimer),
+		errors.Is(err, usecase.ErrNothingToUndo),
+		errors.Is(err, storage.ErrBlobNotFound),
+		errors.Is(err, authRepo.ErrUserNotFound),
+		errors.Is(err, projectRepo.ErrProjectNotFound),
+		errors.Is(err, usecase.ErrWorkspaceNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, usecase.ErrForbidden),
+		errors.Is(err, usecase.ErrNotCommentAuthor),
+		errors.Is(err, usecase.ErrNotEntryOwner):
+		status = fiber.StatusForbidden
+	case errors.Is(err, usecase.ErrBlocked),
+		errors.Is(err, usecase.ErrDependencyCycle),
+		errors.Is(err, usecase.ErrTimerRunning),
+		errors.Is(err, usecase.ErrTimeOverlap),
+		errors.Is(err, usecase.ErrModifiedSince):
+		status = fiber.StatusConflict
+	case errors.Is(err, usecase.ErrShareWithSelf),
+		errors.Is(err, usecase.ErrBlockSelf),
+		errors.Is(err, usecase.ErrInvalidSnooze),
+		errors.Is(err, usecase.ErrMissingDate),
+		errors.Is(err, usecase.ErrTaskTimeRange),
+		errors.Is(err, usecase.ErrEventDueDate),
+		errors.Is(err, usecase.ErrEndBeforeStart),
+		errors.Is(err, usecase.ErrInvalidTimezone),
+		errors.Is(err, usecase.ErrRecurrenceUntil),
+		errors.Is(err, usecase.ErrProjectMismatch),
+		errors.Is(err, usecase.ErrNoProject),
+		errors.Is(err, usecase.ErrInvalidPosition),
+		errors.Is(err, usecase.ErrInvalidTag),
+		errors.Is(err, usecase.ErrInvalidTimeEntry),
+		errors.Is(err, usecase.ErrInvalidTimesheet),
+		errors.Is(err, usecase.ErrInvalidDimensions):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, usecase.ErrAttachmentTooLarge):
+		status = fiber.StatusRequestEntityTooLarge
+	case errors.Is(err, usecase.ErrUnsupportedFileType):
+		status = fiber.StatusUnsupportedMediaType
+	}
+
+	return ctx.Status(status).JSON(fiber.Map{
+		"data":        nil,
+		"status_code": status,
+		"message":     err.Error(),
+	})
+}
+
+// failQuery reports an invalid filter expression along with the position
+// of the error in it.
+func failQuery(ctx *fiber.Ctx, err *filterquery.Error) error {
+	return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"data":        fiber.Map{"position": err.Position},
+		"status_code": fiber.StatusBadRequest,
+		"message":     err.Error(),
+	})
+}
+
+// failWrite reports a rejected overlap along with the conflicting events and
+// any other error as fail does.
+func failWrite(ctx *fiber.Ctx, err error, location *time.Location) error {
+	var conflict *usecase.ConflictError
+	if !errors.As(err, &conflict) {
+		return fail(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+		"data":        fiber.Map{"conflicts": toConflictResponses(conflict.Conflicts, location)},
+		"status_code": fiber.StatusConflict,
+		"message":     err.Error(),
+	})
+}
+
+func conflictPolicy(reject bool) entities.ConflictPolicy {
+	if reject {
+		return entities.ConflictsReject
+	}
+	return entities.ConflictsWarn
+}
+
+// toConflictResponses renders the conflicting occurrences in location. A
+// repetition of a recurring event keeps the event's duration.
+func toConflictResponses(conflicts []entities.ActivityOccurrence, location *time.Location) []models.ConflictResponse {
+	responses := make([]models.ConflictResponse, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		response := models.ConflictResponse{
+			ActivityId: conflict.Id,
+			Title:      conflict.Title,
+			StartAt:    conflict.OccursAt.In(location),
+		}
+		if conflict.EndAt != nil {
+			end := response.StartAt.Add(conflict.EndAt.Sub(conflict.ActivityDate))
+			response.EndAt = &end
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
+
+// toActivityResponse renders the activity's dates in location.
+func toActivityResponse(activity entities.Activity, location *time.Location) models.ActivityResponse {
+	in := func(t *time.Time) *time.Time {
+		if t == nil {
+			return nil
+		}
+		local := t.In(location)
+		return &local
+	}
+
+	response := models.ActivityResponse{
+		Id:           activity.Id,
+		OwnerId:      activity.OwnerId,
+		WorkspaceId:  activity.WorkspaceId,
+		Title:        activity.Title,
+		Category:     activity.Category,
+		Description:  activity.Description,
+		ActivityDate: activity.ActivityDate.In(location),
+		Status:       activity.Status,
+		CommentCount: activity.CommentCount,
+		Tags:         activity.Tags,
+
+		TrackedSeconds: activity.TrackedSeconds,
+
+		Priority: activity.Priority,
+		StartAt:  in(activity.StartAt),
+		EndAt:    in(activity.EndAt),
+		DueAt:    in(activity.DueAt),
+
+		Timezone:  activity.Timezone,
+		AllDay:    activity.AllDay,
+		LocalDate: activity.LocalDate,
+
+		OriginalActivityDate: in(activity.OriginalActivityDate),
+		RescheduleCount:      activity.RescheduleCount,
+
+		ProjectId: activity.ProjectId,
+		Rank:      activity.Rank,
+		ParentId:  activity.ParentId,
+
+		Archived: activity.Archived,
+		Pinned:   activity.Pinned,
+	}
+	if activity.RecurrenceFrequency != nil {
+		response.Recurrence = &models.RecurrenceResponse{
+			Frequency: *activity.RecurrenceFrequency,
+			Interval:  activity.RecurrenceInterval,
+			Until:     in(activity.RecurrenceUntil),
+		}
+	}
+	return response
+}
+
+func badRequest(ctx *fiber.Ctx, message string) error {
+	return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusBadRequest,
+		"message":     message,
+	})
 }
@@ -0,0 +1,14 @@
+package handler
+
+import "github.com/gofiber/fiber/v2"
+
+type ActivityShareHandler interface {
+	GetShares(ctx *fiber.Ctx) error
+	Share(ctx *fiber.Ctx) error
+	Unshare(ctx *fiber.Ctx) error
+	GetLinks(ctx *fiber.Ctx) error
+	CreateLink(ctx *fiber.Ctx) error
+	RevokeLink(ctx *fiber.Ctx) error
+	GetPublic(ctx *fiber.Ctx) error
+	RegisterRoutes()
+}
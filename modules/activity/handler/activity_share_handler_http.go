@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"strconv"
+	"time"
+	"todolist-v1/modules/activity/models"
+	"todolist-v1/modules/activity/usecase"
+	authEntities "todolist-v1/modules/auth/entities"
+	"todolist-v1/modules/auth/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+const defaultShareLinkTTL = 7 * 24 * time.Hour
+
+type activityShareHandlerHttp struct {
+	app            *fiber.App
+	usecase        usecase.ActivityShareUsecase
+	authMiddleware fiber.Handler
+	validate       *validator.Validate
+}
+
+func NewActivityShareHttpHandler(app *fiber.App, usecase usecase.ActivityShareUsecase, authMiddleware fiber.Handler) ActivityShareHandler {
+	return &activityShareHandlerHttp{
+		app:            app,
+		usecase:        usecase,
+		authMiddleware: authMiddleware,
+		validate:       validator.New(),
+	}
+}
+
+func (handler *activityShareHandlerHttp) GetShares(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+
+	shares, err := handler.usecase.GetShares(principal.UserId, id)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	var shareResponses []models.ShareResponse
+	for _, share := range shares {
+		shareResponses = append(shareResponses, models.ShareResponse{
+			UserId:     share.UserId,
+			Email:      share.Email,
+			Name:       share.Name,
+			Permission: share.Permission,
+			CreatedAt:  share.CreatedAt,
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        shareResponses,
+		"status_code": fiber.StatusOK,
+		"message":     "Shares retrieved successfully",
+	})
+}
+
+func (handler *activityShareHandlerHttp) Share(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+
+	var request models.ShareRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return badRequest(ctx, "Cannot parse JSON")
+	}
+	if err := handler.validate.Struct(request); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+
+	share, err := handler.usecase.Share(principal.UserId, id, request.Email, request.Permission)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data": models.ShareResponse{
+			UserId:     share.UserId,
+			Email:      share.Email,
+			Name:       share.Name,
+			Permission: share.Permission,
+			CreatedAt:  share.CreatedAt,
+		},
+		"status_code": fiber.StatusCreated,
+		"message":     "Activity shared successfully",
+	})
+}
+
+func (handler *activityShareHandlerHttp) Unshare(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+	targetUserId, err := strconv.Atoi(ctx.Params("userId"))
+	if err != nil {
+		return badRequest(ctx, "Invalid user ID")
+	}
+
+	if err := handler.usecase.Unshare(principal.UserId, id, targetUserId); err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusOK,
+		"message":     "Share removed successfully",
+	})
+}
+
+func (handler *activityShareHandlerHttp) GetLinks(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+
+	links, err := handler.usecase.GetLinks(principal.UserId, id)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	var linkResponses []models.ShareLinkResponse
+	for _, link := range links {
+		linkResponses = append(linkResponses, models.ShareLinkResponse{
+			Id:        link.Id,
+			ExpiresAt: link.ExpiresAt,
+			CreatedAt: link.CreatedAt,
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        linkResponses,
+		"status_code": fiber.StatusOK,
+		"message":     "Share links retrieved successfully",
+	})
+}
+
+func (handler *activityShareHandlerHttp) CreateLink(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+
+	var request models.ShareLinkRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&request); err != nil {
+			return badRequest(ctx, "Cannot parse JSON")
+		}
+	}
+	if err := handler.validate.Struct(request); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+
+	ttl := defaultShareLinkTTL
+	if request.ExpiresInHours > 0 {
+		ttl = time.Duration(request.ExpiresInHours) * time.Hour
+	}
+
+	link, token, err := handler.usecase.CreateLink(principal.UserId, id, ttl)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data": models.ShareLinkResponse{
+			Id:        link.Id,
+			ExpiresAt: link.ExpiresAt,
+			CreatedAt: link.CreatedAt,
+			Token:     token,
+		},
+		"status_code": fiber.StatusCreated,
+		"message":     "Share link created successfully",
+	})
+}
+
+func (handler *activityShareHandlerHttp) RevokeLink(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+	linkId, err := strconv.Atoi(ctx.Params("linkId"))
+	if err != nil {
+		return badRequest(ctx, "Invalid link ID")
+	}
+
+	if err := handler.usecase.RevokeLink(principal.UserId, id, linkId); err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusOK,
+		"message":     "Share link revoked successfully",
+	})
+}
+
+func (handler *activityShareHandlerHttp) GetPublic(ctx *fiber.Ctx) error {
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	activity, err := handler.usecase.GetByLinkToken(ctx.Params("token"))
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        toActivityResponse(activity, location),
+		"status_code": fiber.StatusOK,
+		"message":     "Activity retrieved successfully",
+	})
+}
+
+func (handler *activityShareHandlerHttp) RegisterRoutes() {
+	activities := handler.app.Group("/api/activities/:id")
+	canRead := middleware.RequireScope(authEntities.ScopeActivitiesRead)
+	canWrite := middleware.RequireScope(authEntities.ScopeActivitiesWrite)
+
+	activities.Get("/shares", handler.authMiddleware, canRead, handler.GetShares)
+	activities.Post("/shares", handler.authMiddleware, canWrite, handler.Share)
+	activities.Delete("/shares/:userId", handler.authMiddleware, canWrite, handler.Unshare)
+	activities.Get("/share-links", handler.authMiddleware, canRead, handler.GetLinks)
+	activities.Post("/share-links", handler.authMiddleware, canWrite, handler.CreateLink)
+	activities.Delete("/share-links/:linkId", handler.authMiddleware, canWrite, handler.RevokeLink)
+
+	handler.app.Get("/api/public/activities/:token", handler.GetPublic)
+}
@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"strconv"
+	"todolist-v1/modules/activity/entities"
+	"todolist-v1/modules/activity/models"
+	"todolist-v1/modules/activity/usecase"
+	authEntities "todolist-v1/modules/auth/entities"
+	"todolist-v1/modules/auth/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type savedFilterHandlerHttp struct {
+	app            *fiber.App
+	usecase        usecase.SavedFilterUsecase
+	authMiddleware fiber.Handler
+	validate       *validator.Validate
+}
+
+func NewSavedFilterHttpHandler(app *fiber.App, usecase usecase.SavedFilterUsecase, authMiddleware fiber.Handler) SavedFilterHandler {
+	return &savedFilterHandlerHttp{
+		app:            app,
+		usecase:        usecase,
+		authMiddleware: authMiddleware,
+		validate:       validator.New(),
+	}
+}
+
+func (handler *savedFilterHandlerHttp) GetAll(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	filters, err := handler.usecase.GetAll(principal.UserId)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	filterResponses := make([]models.SavedFilterResponse, 0, len(filters))
+	for _, filter := range filters {
+		filterResponses = append(filterResponses, toSavedFilterResponse(filter))
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        filterResponses,
+		"status_code": fiber.StatusOK,
+		"message":     "Saved filters retrieved successfully",
+	})
+}
+
+func (handler *savedFilterHandlerHttp) Get(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+
+	filter, err := handler.usecase.Get(principal.UserId, id)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        toSavedFilterResponse(filter),
+		"status_code": fiber.StatusOK,
+		"message":     "Saved filter retrieved successfully",
+	})
+}
+
+func (handler *savedFilterHandlerHttp) Create(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	var request models.SavedFilterRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return badRequest(ctx, "Cannot parse JSON")
+	}
+	if err := handler.validate.Struct(request); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+
+	filter, err := handler.usecase.Create(principal.UserId, entities.SavedFilter{
+		WorkspaceId: request.WorkspaceId,
+		Name:        request.Name,
+		Filter:      toFilterDocument(request.Filter),
+	})
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data":        toSavedFilterResponse(filter),
+		"status_code": fiber.StatusCreated,
+		"message":     "Saved filter created successfully",
+	})
+}
+
+func (handler *savedFilterHandlerHttp) Update(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+
+	var request models.SavedFilterUpdateRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return badRequest(ctx, "Cannot parse JSON")
+	}
+	if err := handler.validate.Struct(request); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+
+	filter, err := handler.usecase.Update(principal.UserId, id, entities.SavedFilter{
+		Name:   request.Name,
+		Filter: toFilterDocument(request.Filter),
+	})
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        toSavedFilterResponse(filter),
+		"status_code": fiber.StatusOK,
+		"message":     "Saved filter updated successfully",
+	})
+}
+
+func (handler *savedFilterHandlerHttp) Delete(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+
+	if err := handler.usecase.Delete(principal.UserId, id); err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusOK,
+		"message":     "Saved filter deleted successfully",
+	})
+}
+
+// Activities runs a saved filter and lists the matching activities the way
+// the activity list does.
+func (handler *savedFilterHandlerHttp) Activities(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+
+	activities, err := handler.usecase.Activities(principal.UserId, id)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	activityResponses := make([]models.ActivityResponse, 0, len(activities))
+	for _, activity := range activities {
+		activityResponses = append(activityResponses, toActivityResponse(activity, location))
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        activityResponses,
+		"status_code": fiber.StatusOK,
+		"message":     "Activities retrieved successfully",
+	})
+}
+
+func (handler *savedFilterHandlerHttp) RegisterRoutes() {
+	canRead := middleware.RequireScope(authEntities.ScopeActivitiesRead)
+	canWrite := middleware.RequireScope(authEntities.ScopeActivitiesWrite)
+
+	filters := handler.app.Group("/api/filters", handler.authMiddleware)
+	filters.Get("/", canRead, handler.GetAll)
+	filters.Post("/", canWrite, handler.Create)
+	filters.Get("/:id", canRead, handler.Get)
+	filters.Put("/:id", canWrite, handler.Update)
+	filters.Delete("/:id", canWrite, handler.Delete)
+	filters.Get("/:id/activities", canRead, handler.Activities)
+}
+
+func toFilterDocument(request models.FilterDocumentRequest) entities.FilterDocument {
+	return entities.FilterDocument{
+		WorkspaceId: request.WorkspaceId,
+		ProjectId:   request.ProjectId,
+		Statuses:    request.Statuses,
+		Categories:  request.Categories,
+		Priorities:  request.Priorities,
+		Tags:        request.Tags,
+		Overdue:     request.Overdue,
+		Sort:        request.Sort,
+	}
+}
+
+func toSavedFilterResponse(filter entities.SavedFilter) models.SavedFilterResponse {
+	// Lists are rendered empty rather than null when left out.
+	orEmpty := func(values []string) []string {
+		if values == nil {
+			return []string{}
+		}
+		return values
+	}
+	return models.SavedFilterResponse{
+		Id:          filter.Id,
+		OwnerId:     filter.OwnerId,
+		WorkspaceId: filter.WorkspaceId,
+		Name:        filter.Name,
+		Filter: models.FilterDocumentResponse{
+			WorkspaceId: filter.Filter.WorkspaceId,
+			ProjectId:   filter.Filter.ProjectId,
+			Statuses:    orEmpty(filter.Filter.Statuses),
+			Categories:  orEmpty(filter.Filter.Categories),
+			Priorities:  orEmpty(filter.Filter.Priorities),
+			Tags:        orEmpty(filter.Filter.Tags),
+			Overdue:     filter.Filter.Overdue,
+			Sort:        filter.Filter.Sort,
+		},
+		CreatedAt: filter.CreatedAt,
+		UpdatedAt: filter.UpdatedAt,
+	}
+}
@@ -0,0 +1,14 @@
+package handler
+
+import "github.com/gofiber/fiber/v2"
+
+type TimeEntryHandler interface {
+	GetAll(ctx *fiber.Ctx) error
+	Log(ctx *fiber.Ctx) error
+	Delete(ctx *fiber.Ctx) error
+	Start(ctx *fiber.Ctx) error
+	Stop(ctx *fiber.Ctx) error
+	Running(ctx *fiber.Ctx) error
+	Timesheet(ctx *fiber.Ctx) error
+	RegisterRoutes()
+}
@@ -0,0 +1,290 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"todolist-v1/modules/activity/entities"
+	"todolist-v1/modules/activity/models"
+	"todolist-v1/modules/activity/usecase"
+	authEntities "todolist-v1/modules/auth/entities"
+	"todolist-v1/modules/auth/middleware"
+	"todolist-v1/pkg/date"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// defaultTimesheetDays is how many days up to today a timesheet covers
+// without a from.
+const defaultTimesheetDays = 7
+
+type timeEntryHandlerHttp struct {
+	app            *fiber.App
+	usecase        usecase.TimeEntryUsecase
+	authMiddleware fiber.Handler
+	validate       *validator.Validate
+}
+
+func NewTimeEntryHttpHandler(app *fiber.App, usecase usecase.TimeEntryUsecase, authMiddleware fiber.Handler) TimeEntryHandler {
+	return &timeEntryHandlerHttp{
+		app:            app,
+		usecase:        usecase,
+		authMiddleware: authMiddleware,
+		validate:       validator.New(),
+	}
+}
+
+func (handler *timeEntryHandlerHttp) GetAll(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+
+	entries, err := handler.usecase.GetAll(principal.UserId, id)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	entryResponses := make([]models.TimeEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		entryResponses = append(entryResponses, toTimeEntryResponse(entry, location))
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        entryResponses,
+		"status_code": fiber.StatusOK,
+		"message":     "Time entries retrieved successfully",
+	})
+}
+
+func (handler *timeEntryHandlerHttp) Log(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+
+	var request models.TimeEntryRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return badRequest(ctx, "Cannot parse JSON")
+	}
+	if err := handler.validate.Struct(request); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+
+	entry, err := handler.usecase.Log(principal.UserId, id, entities.TimeEntry{
+		StartedAt: request.StartedAt,
+		EndedAt:   &request.EndedAt,
+		Note:      request.Note,
+	})
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data":        toTimeEntryResponse(entry, location),
+		"status_code": fiber.StatusCreated,
+		"message":     "Time entry logged successfully",
+	})
+}
+
+func (handler *timeEntryHandlerHttp) Delete(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+	entryId, err := strconv.Atoi(ctx.Params("entryId"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+
+	if err := handler.usecase.Delete(principal.UserId, id, entryId); err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusOK,
+		"message":     "Time entry deleted successfully",
+	})
+}
+
+func (handler *timeEntryHandlerHttp) Start(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return badRequest(ctx, "Invalid ID")
+	}
+
+	// The body is optional.
+	var request models.TimerStartRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&request); err != nil {
+			return badRequest(ctx, "Cannot parse JSON")
+		}
+		if err := handler.validate.Struct(request); err != nil {
+			return badRequest(ctx, err.Error())
+		}
+	}
+
+	entry, err := handler.usecase.Start(principal.UserId, id, request.Note)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data":        toTimeEntryResponse(entry, location),
+		"status_code": fiber.StatusCreated,
+		"message":     "Timer started successfully",
+	})
+}
+
+func (handler *timeEntryHandlerHttp) Stop(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	entry, err := handler.usecase.Stop(principal.UserId)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        toTimeEntryResponse(entry, location),
+		"status_code": fiber.StatusOK,
+		"message":     "Timer stopped successfully",
+	})
+}
+
+func (handler *timeEntryHandlerHttp) Running(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	entry, err := handler.usecase.Running(principal.UserId)
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        toTimeEntryResponse(entry, location),
+		"status_code": fiber.StatusOK,
+		"message":     "Running timer retrieved successfully",
+	})
+}
+
+// Timesheet sums the caller's time from from to to, the last week by
+// default, with days taken in the caller's preferred timezone.
+func (handler *timeEntryHandlerHttp) Timesheet(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	to := date.Of(time.Now().In(location))
+	if value := ctx.Query("to"); value != "" {
+		if to, err = date.Parse(value); err != nil {
+			return badRequest(ctx, "Invalid to, expected YYYY-MM-DD")
+		}
+	}
+	from := to.AddDays(1 - defaultTimesheetDays)
+	if value := ctx.Query("from"); value != "" {
+		if from, err = date.Parse(value); err != nil {
+			return badRequest(ctx, "Invalid from, expected YYYY-MM-DD")
+		}
+	}
+	var groupBy []string
+	if value := ctx.Query("group_by"); value != "" {
+		groupBy = strings.Split(value, ",")
+	}
+
+	timesheet, err := handler.usecase.Timesheet(principal.UserId, entities.TimesheetQuery{
+		From:     from,
+		To:       to,
+		Location: location,
+		GroupBy:  groupBy,
+	})
+	if err != nil {
+		return fail(ctx, err)
+	}
+
+	rows := make([]models.TimesheetRowResponse, 0, len(timesheet.Rows))
+	for _, row := range timesheet.Rows {
+		rows = append(rows, models.TimesheetRowResponse{
+			Day:      row.Day,
+			Category: row.Category,
+			Tag:      row.Tag,
+			Seconds:  row.Seconds,
+			Entries:  row.Entries,
+		})
+	}
+	return ctx.JSON(fiber.Map{
+		"data": models.TimesheetResponse{
+			From:         timesheet.From,
+			To:           timesheet.To,
+			GroupBy:      timesheet.GroupBy,
+			TotalSeconds: timesheet.TotalSeconds,
+			Rows:         rows,
+		},
+		"status_code": fiber.StatusOK,
+		"message":     "Timesheet retrieved successfully",
+	})
+}
+
+func (handler *timeEntryHandlerHttp) RegisterRoutes() {
+	activities := handler.app.Group("/api/activities/:id")
+	canRead := middleware.RequireScope(authEntities.ScopeActivitiesRead)
+	canWrite := middleware.RequireScope(authEntities.ScopeActivitiesWrite)
+
+	activities.Get("/time-entries", handler.authMiddleware, canRead, handler.GetAll)
+	activities.Post("/time-entries", handler.authMiddleware, canWrite, handler.Log)
+	activities.Delete("/time-entries/:entryId", handler.authMiddleware, canWrite, handler.Delete)
+	activities.Post("/timer/start", handler.authMiddleware, canWrite, handler.Start)
+
+	handler.app.Get("/api/timer", handler.authMiddleware, canRead, handler.Running)
+	handler.app.Post("/api/timer/stop", handler.authMiddleware, canWrite, handler.Stop)
+	handler.app.Get("/api/timesheet", handler.authMiddleware, canRead, handler.Timesheet)
+}
+
+// toTimeEntryResponse renders the entry's times in location.
+func toTimeEntryResponse(entry entities.TimeEntry, location *time.Location) models.TimeEntryResponse {
+	response := models.TimeEntryResponse{
+		Id:         entry.Id,
+		ActivityId: entry.ActivityId,
+		UserId:     entry.UserId,
+		StartedAt:  entry.StartedAt.In(location),
+		Note:       entry.Note,
+		CreatedAt:  entry.CreatedAt,
+	}
+	if entry.EndedAt != nil {
+		ended := entry.EndedAt.In(location)
+		seconds := int64(entry.EndedAt.Sub(entry.StartedAt).Seconds())
+		response.EndedAt = &ended
+		response.DurationSeconds = &seconds
+	}
+	return response
+}
@@ -1,27 +1,338 @@
 package models
 
-import "time"
+import (
+	"time"
+	"todolist-v1/pkg/date"
+)
 
+// ActivityCreateRequest dates an event with start_at and end_at and a task
+// with due_at. activity_date is accepted instead of start_at or due_at.
+// All-day activities only keep the day, taken in Timezone, which defaults to
+// the caller's preferred timezone.
 type ActivityCreateRequest struct {
-	Title        string    `json:"title" validate:"required,max=250,min=3"`
-	Category     string    `json:"category" validate:"required,oneof=TASK EVENT"`
-	Description  string    `json:"description" validate:"required"`
-	ActivityDate time.Time `json:"activity_date" validate:"required"`
+	WorkspaceId  *int       `json:"workspace_id" validate:"omitempty,min=1"`
+	ProjectId    *int       `json:"project_id" validate:"omitempty,min=1"`
+	Title        string     `json:"title" validate:"required,max=250,min=3"`
+	Category     string     `json:"category" validate:"required,oneof=TASK EVENT"`
+	Description  string     `json:"description" validate:"required"`
+	ActivityDate time.Time  `json:"activity_date" validate:"required_without_all=StartAt DueAt"`
+	Priority     string     `json:"priority" validate:"omitempty,oneof=P1 P2 P3 P4"`
+	StartAt      *time.Time `json:"start_at"`
+	EndAt        *time.Time `json:"end_at"`
+	DueAt        *time.Time `json:"due_at"`
+	Timezone     *string    `json:"timezone" validate:"omitempty,min=1,max=64"`
+	AllDay       bool       `json:"all_day"`
+	Tags         []string   `json:"tags" validate:"omitempty,max=20"`
+	// Recurrence makes the activity repeat; leaving it out makes it a single
+	// activity.
+	Recurrence *RecurrenceRequest `json:"recurrence"`
+	// RejectConflicts refuses a timed event overlapping other events of its
+	// owner instead of saving it and listing them.
+	RejectConflicts bool `json:"reject_conflicts"`
 }
 
+// QuickAddRequest creates an activity from a line such as "Dentist
+// appointment next Tuesday 3pm #health !p1".
+type QuickAddRequest struct {
+	Text        string `json:"text" validate:"required,max=500"`
+	WorkspaceId *int   `json:"workspace_id" validate:"omitempty,min=1"`
+	ProjectId   *int   `json:"project_id" validate:"omitempty,min=1"`
+}
+
+// ActivityUpdateRequest replaces the activity. Leaving out priority, tags,
+// timezone or all_day keeps the current ones.
 type ActivityUpdateRequest struct {
-	Title        string    `json:"title" validate:"required,max=250"`
-	Category     string    `json:"category" validate:"required,oneof=TASK EVENT"`
-	Description  string    `json:"description" validate:"required"`
-	ActivityDate time.Time `json:"activity_date" validate:"required"`
-	Status       string    `json:"status" validate:"required,oneof=NEW 'ON PROGRESS' EXPIRED"`
+	Title        string     `json:"title" validate:"required,max=250"`
+	Category     string     `json:"category" validate:"required,oneof=TASK EVENT"`
+	Description  string     `json:"description" validate:"required"`
+	ActivityDate time.Time  `json:"activity_date" validate:"required_without_all=StartAt DueAt"`
+	Status       string     `json:"status" validate:"required,oneof=NEW 'ON PROGRESS' EXPIRED DONE"`
+	Priority     string     `json:"priority" validate:"omitempty,oneof=P1 P2 P3 P4"`
+	StartAt      *time.Time `json:"start_at"`
+	EndAt        *time.Time `json:"end_at"`
+	DueAt        *time.Time `json:"due_at"`
+	Timezone     *string    `json:"timezone" validate:"omitempty,min=1,max=64"`
+	AllDay       *bool      `json:"all_day"`
+	Tags         []string   `json:"tags" validate:"omitempty,max=20"`
+	// Recurrence makes the activity repeat; leaving it out makes it a single
+	// activity.
+	Recurrence *RecurrenceRequest `json:"recurrence"`
+	// RejectConflicts refuses a timed event overlapping other events of its
+	// owner instead of saving it and listing them.
+	RejectConflicts bool `json:"reject_conflicts"`
 }
 
 type ActivityResponse struct {
 	Id           int       `json:"id"`
+	OwnerId      int       `json:"owner_id"`
+	WorkspaceId  *int      `json:"workspace_id"`
 	Title        string    `json:"title"`
 	Category     string    `json:"category"`
 	Description  string    `json:"description"`
 	ActivityDate time.Time `json:"activity_date"`
 	Status       string    `json:"status"`
+	CommentCount int       `json:"comment_count"`
+	Tags         []string  `json:"tags"`
+	// TrackedSeconds is the time logged on the activity by everyone,
+	// running timers excluded.
+	TrackedSeconds int64 `json:"tracked_seconds"`
+
+	Priority string     `json:"priority"`
+	StartAt  *time.Time `json:"start_at"`
+	EndAt    *time.Time `json:"end_at"`
+	DueAt    *time.Time `json:"due_at"`
+
+	Timezone  *string    `json:"timezone"`
+	AllDay    bool       `json:"all_day"`
+	LocalDate *date.Date `json:"local_date"`
+
+	OriginalActivityDate *time.Time `json:"original_activity_date"`
+	RescheduleCount      int        `json:"reschedule_count"`
+
+	Recurrence *RecurrenceResponse `json:"recurrence"`
+
+	ProjectId *int    `json:"project_id"`
+	Rank      *string `json:"rank"`
+	ParentId  *int    `json:"parent_id"`
+
+	Archived bool `json:"archived"`
+	Pinned   bool `json:"pinned"`
+
+	// Conflicts lists the events overlapping an event just written.
+	Conflicts []ConflictResponse `json:"conflicts,omitempty"`
+}
+
+// ConflictResponse is an occurrence of an event overlapping another one.
+type ConflictResponse struct {
+	ActivityId int        `json:"activity_id"`
+	Title      string     `json:"title"`
+	StartAt    time.Time  `json:"start_at"`
+	EndAt      *time.Time `json:"end_at"`
+}
+
+// RecurrenceRequest repeats an activity every Interval days, weeks or months
+// from its date, up to and including Until.
+type RecurrenceRequest struct {
+	Frequency string     `json:"frequency" validate:"required,oneof=daily weekly monthly"`
+	Interval  int        `json:"interval" validate:"omitempty,min=1,max=365"`
+	Until     *time.Time `json:"until"`
+}
+
+type RecurrenceResponse struct {
+	Frequency string     `json:"frequency"`
+	Interval  int        `json:"interval"`
+	Until     *time.Time `json:"until"`
+}
+
+// SnoozeRequest moves an activity to a preset time or by a number of
+// minutes from now. Presets are resolved in Timezone, UTC by default.
+type SnoozeRequest struct {
+	Preset   string `json:"preset" validate:"required_without=Minutes,excluded_with=Minutes,omitempty,oneof=later_today tomorrow_morning next_week"`
+	Minutes  int    `json:"minutes" validate:"required_without=Preset,omitempty,min=1,max=525600"`
+	Timezone string `json:"timezone" validate:"omitempty,max=64"`
+}
+
+// ArchiveRequest archives the activities matching a filter expression, as
+// taken by the q parameter of the activity list, within a workspace when
+// workspace_id is set.
+type ArchiveRequest struct {
+	Query       string `json:"q" validate:"required,max=1000"`
+	WorkspaceId *int   `json:"workspace_id" validate:"omitempty,min=1"`
+}
+
+type RescheduleRequest struct {
+	Days        int  `json:"days" validate:"required,min=1,max=365"`
+	WorkspaceId *int `json:"workspace_id" validate:"omitempty,min=1"`
+}
+
+// MoveRequest puts an activity in the board column of status, in project_id
+// or its current project, right after after_id and/or right before
+// before_id, or at the bottom of the column without either.
+type MoveRequest struct {
+	Status    string `json:"status" validate:"required,oneof=NEW 'ON PROGRESS' EXPIRED DONE"`
+	ProjectId *int   `json:"project_id" validate:"omitempty,min=1"`
+	AfterId   *int   `json:"after_id" validate:"omitempty,min=1"`
+	BeforeId  *int   `json:"before_id" validate:"omitempty,min=1"`
+}
+
+// TimeEntryRequest logs time spent on an activity by hand.
+type TimeEntryRequest struct {
+	StartedAt time.Time `json:"started_at" validate:"required"`
+	EndedAt   time.Time `json:"ended_at" validate:"required"`
+	Note      string    `json:"note" validate:"max=500"`
+}
+
+type TimerStartRequest struct {
+	Note string `json:"note" validate:"max=500"`
+}
+
+// TimeEntryResponse leaves ended_at and duration_seconds empty while the
+// timer runs.
+type TimeEntryResponse struct {
+	Id              int        `json:"id"`
+	ActivityId      int        `json:"activity_id"`
+	UserId          int        `json:"user_id"`
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at"`
+	DurationSeconds *int64     `json:"duration_seconds"`
+	Note            string     `json:"note"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type TimesheetResponse struct {
+	From         date.Date              `json:"from"`
+	To           date.Date              `json:"to"`
+	GroupBy      []string               `json:"group_by"`
+	TotalSeconds int64                  `json:"total_seconds"`
+	Rows         []TimesheetRowResponse `json:"rows"`
+}
+
+// TimesheetRowResponse has null for the dimensions the timesheet is not
+// grouped by. Untagged activities are summed under a null tag.
+type TimesheetRowResponse struct {
+	Day      *date.Date `json:"day"`
+	Category *string    `json:"category"`
+	Tag      *string    `json:"tag"`
+	Seconds  int64      `json:"seconds"`
+	Entries  int        `json:"entries"`
+}
+
+type BlockerRequest struct {
+	BlockerId int `json:"blocker_id" validate:"required,min=1"`
+}
+
+type DependencyResponse struct {
+	ActivityId int       `json:"activity_id"`
+	BlockerId  int       `json:"blocker_id"`
+	CreatedBy  int       `json:"created_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// NextActivityResponse is an open activity in the order work can proceed
+// in. Depth 0 means it can start now.
+type NextActivityResponse struct {
+	Activity     ActivityResponse `json:"activity"`
+	Depth        int              `json:"depth"`
+	OpenBlockers int              `json:"open_blockers"`
+}
+
+type FieldChangeResponse struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+type HistoryResponse struct {
+	Revision   int                            `json:"revision"`
+	Action     string                         `json:"action"`
+	ActorId    *int                           `json:"actor_id"`
+	ActorEmail string                         `json:"actor_email"`
+	Changes    map[string]FieldChangeResponse `json:"changes"`
+	CreatedAt  time.Time                      `json:"created_at"`
+}
+
+type CommentRequest struct {
+	// Body is Markdown. Users can be mentioned as @user@example.com.
+	Body string `json:"body" validate:"required,max=10000"`
+}
+
+type MentionResponse struct {
+	UserId int    `json:"user_id"`
+	Email  string `json:"email"`
+	Name   string `json:"name"`
+}
+
+type CommentResponse struct {
+	Id          int               `json:"id"`
+	ActivityId  int               `json:"activity_id"`
+	AuthorId    int               `json:"author_id"`
+	AuthorEmail string            `json:"author_email"`
+	AuthorName  string            `json:"author_name"`
+	Body        string            `json:"body"`
+	Mentions    []MentionResponse `json:"mentions"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+type AttachmentResponse struct {
+	Id             int       `json:"id"`
+	FileName       string    `json:"file_name"`
+	ContentType    string    `json:"content_type"`
+	Size           int64     `json:"size"`
+	ChecksumSha256 string    `json:"checksum_sha256"`
+	UploadedBy     *int      `json:"uploaded_by"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type ShareRequest struct {
+	Email      string `json:"email" validate:"required,email"`
+	Permission string `json:"permission" validate:"required,oneof=read edit"`
+}
+
+type ShareLinkRequest struct {
+	// ExpiresInHours defaults to one week when omitted.
+	ExpiresInHours int `json:"expires_in_hours" validate:"omitempty,min=1,max=8760"`
+}
+
+type ShareResponse struct {
+	UserId     int       `json:"user_id"`
+	Email      string    `json:"email"`
+	Name       string    `json:"name"`
+	Permission string    `json:"permission"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ShareLinkResponse struct {
+	Id        int       `json:"id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+	// Token is only returned when the link is created.
+	Token string `json:"token,omitempty"`
+}
+
+// FilterDocumentRequest narrows down activities like the list's query
+// parameters do; leaving a field out leaves that criterion out. Tags must
+// all be carried; the other lists match any of their values.
+type FilterDocumentRequest struct {
+	WorkspaceId *int     `json:"workspace_id" validate:"omitempty,min=1"`
+	ProjectId   *int     `json:"project_id" validate:"omitempty,min=1"`
+	Statuses    []string `json:"statuses" validate:"dive,oneof=NEW 'ON PROGRESS' EXPIRED DONE"`
+	Categories  []string `json:"categories" validate:"dive,oneof=TASK EVENT"`
+	Priorities  []string `json:"priorities" validate:"dive,oneof=P1 P2 P3 P4"`
+	Tags        []string `json:"tags" validate:"max=20"`
+	Overdue     bool     `json:"overdue"`
+	Sort        string   `json:"sort" validate:"omitempty,oneof=priority due_at rank"`
+}
+
+// SavedFilterRequest creates a filter, shared with the members of
+// workspace_id when set.
+type SavedFilterRequest struct {
+	WorkspaceId *int                  `json:"workspace_id" validate:"omitempty,min=1"`
+	Name        string                `json:"name" validate:"required,max=100"`
+	Filter      FilterDocumentRequest `json:"filter"`
+}
+
+type SavedFilterUpdateRequest struct {
+	Name   string                `json:"name" validate:"required,max=100"`
+	Filter FilterDocumentRequest `json:"filter"`
+}
+
+type SavedFilterResponse struct {
+	Id          int                    `json:"id"`
+	OwnerId     int                    `json:"owner_id"`
+	WorkspaceId *int                   `json:"workspace_id"`
+	Name        string                 `json:"name"`
+	Filter      FilterDocumentResponse `json:"filter"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+type FilterDocumentResponse struct {
+	WorkspaceId *int     `json:"workspace_id"`
+	ProjectId   *int     `json:"project_id"`
+	Statuses    []string `json:"statuses"`
+	Categories  []string `json:"categories"`
+	Priorities  []string `json:"priorities"`
+	Tags        []string `json:"tags"`
+	Overdue     bool     `json:"overdue"`
+	Sort        string   `json:"sort"`
 }
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"errors"
+	"todolist-v1/modules/activity/entities"
+)
+
+var ErrAttachmentNotFound = errors.New("attachment not found")
+
+type ActivityAttachmentRepository interface {
+	FindAll(activityId int) ([]entities.ActivityAttachment, error)
+	FindById(activityId int, id int) (entities.ActivityAttachment, error)
+	Save(attachment entities.ActivityAttachment) (entities.ActivityAttachment, error)
+	// Delete removes the attachment and returns it so that its blob can be
+	// removed as well.
+	Delete(activityId int, id int) (entities.ActivityAttachment, error)
+	// FindOrphans returns the attachments of purged activities.
+	FindOrphans() ([]entities.ActivityAttachment, error)
+	DeleteOrphan(id int) error
+}
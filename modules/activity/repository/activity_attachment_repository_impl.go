@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"errors"
+	"todolist-v1/modules/activity/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type activityAttachmentRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewActivityAttachmentRepository(db *gorm.DB) ActivityAttachmentRepository {
+	return &activityAttachmentRepositoryImpl{DB: db}
+}
+
+func (repository *activityAttachmentRepositoryImpl) FindAll(activityId int) ([]entities.ActivityAttachment, error) {
+	var attachments []entities.ActivityAttachment
+	err := repository.DB.
+		Where("activity_id = ?", activityId).
+		Order("created_at, id").
+		Find(&attachments).Error
+	if err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (repository *activityAttachmentRepositoryImpl) FindById(activityId int, id int) (entities.ActivityAttachment, error) {
+	var attachment entities.ActivityAttachment
+	err := repository.DB.
+		Where("id = ? AND activity_id = ?", id, activityId).
+		First(&attachment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.ActivityAttachment{}, ErrAttachmentNotFound
+		}
+		return entities.ActivityAttachment{}, err
+	}
+	return attachment, nil
+}
+
+func (repository *activityAttachmentRepositoryImpl) Save(attachment entities.ActivityAttachment) (entities.ActivityAttachment, error) {
+	if err := repository.DB.Create(&attachment).Error; err != nil {
+		return entities.ActivityAttachment{}, err
+	}
+	return attachment, nil
+}
+
+func (repository *activityAttachmentRepositoryImpl) Delete(activityId int, id int) (entities.ActivityAttachment, error) {
+	var deleted []entities.ActivityAttachment
+	result := repository.DB.
+		Clauses(clause.Returning{}).
+		Where("id = ? AND activity_id = ?", id, activityId).
+		Delete(&deleted)
+	if result.Error != nil {
+		return entities.ActivityAttachment{}, result.Error
+	}
+	if len(deleted) == 0 {
+		return entities.ActivityAttachment{}, ErrAttachmentNotFound
+	}
+	return deleted[0], nil
+}
+
+func (repository *activityAttachmentRepositoryImpl) FindOrphans() ([]entities.ActivityAttachment, error) {
+	var attachments []entities.ActivityAttachment
+	if err := repository.DB.Where("activity_id IS NULL").Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (repository *activityAttachmentRepositoryImpl) DeleteOrphan(id int) error {
+	return repository.DB.Where("id = ? AND activity_id IS NULL", id).Delete(&entities.ActivityAttachment{}).Error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"errors"
+	"todolist-v1/modules/activity/entities"
+)
+
+var ErrCommentNotFound = errors.New("comment not found")
+
+// ActivityCommentRepository stores comments together with the users they
+// mention. Comments are returned with their author and mentions resolved.
+type ActivityCommentRepository interface {
+	// FindAll returns the comments on an activity, oldest first.
+	FindAll(activityId int) ([]entities.ActivityComment, error)
+	FindById(activityId int, id int) (entities.ActivityComment, error)
+	Save(comment entities.ActivityComment) (entities.ActivityComment, error)
+	// Update replaces the body and the mentions of a comment.
+	Update(comment entities.ActivityComment) (entities.ActivityComment, error)
+	Delete(activityId int, id int) error
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"errors"
+	"todolist-v1/modules/activity/entities"
+
+	"gorm.io/gorm"
+)
+
+type activityCommentRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewActivityCommentRepository(db *gorm.DB) ActivityCommentRepository {
+	return &activityCommentRepositoryImpl{DB: db}
+}
+
+func withAuthor(db *gorm.DB) *gorm.DB {
+	return db.
+		Select("activity_comments.*, users.email AS author_email, users.name AS author_name").
+		Joins("JOIN users ON users.id = activity_comments.author_id")
+}
+
+func (repository *activityCommentRepositoryImpl) FindAll(activityId int) ([]entities.ActivityComment, error) {
+	var comments []entities.ActivityComment
+	err := repository.DB.Scopes(withAuthor).
+		Where("activity_comments.activity_id = ?", activityId).
+		Order("activity_comments.created_at, activity_comments.id").
+		Find(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := repository.loadMentions(comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (repository *activityCommentRepositoryImpl) FindById(activityId int, id int) (entities.ActivityComment, error) {
+	var comment entities.ActivityComment
+	err := repository.DB.Scopes(withAuthor).
+		Where("activity_comments.activity_id = ? AND activity_comments.id = ?", activityId, id).
+		First(&comment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.ActivityComment{}, ErrCommentNotFound
+		}
+		return entities.ActivityComment{}, err
+	}
+
+	comments := []entities.ActivityComment{comment}
+	if err := repository.loadMentions(comments); err != nil {
+		return entities.ActivityComment{}, err
+	}
+	return comments[0], nil
+}
+
+func (repository *activityCommentRepositoryImpl) Save(comment entities.ActivityComment) (entities.ActivityComment, error) {
+	err := repository.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&comment).Error; err != nil {
+			return err
+		}
+		return saveMentions(tx, comment.Id, comment.Mentions)
+	})
+	if err != nil {
+		return entities.ActivityComment{}, err
+	}
+	return repository.FindById(comment.ActivityId, comment.Id)
+}
+
+func (repository *activityCommentRepositoryImpl) Update(comment entities.ActivityComment) (entities.ActivityComment, error) {
+	err := repository.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.ActivityComment{}).
+			Where("id = ? AND activity_id = ?", comment.Id, comment.ActivityId).
+			Update("body", comment.Body)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrCommentNotFound
+		}
+
+		if err := tx.Where("comment_id = ?", comment.Id).Delete(&entities.CommentMention{}).Error; err != nil {
+			return err
+		}
+		return saveMentions(tx, comment.Id, comment.Mentions)
+	})
+	if err != nil {
+		return entities.ActivityComment{}, err
+	}
+	return repository.FindById(comment.ActivityId, comment.Id)
+}
+
+func (repository *activityCommentRepositoryImpl) Delete(activityId int, id int) error {
+	result := repository.DB.
+		Where("id = ? AND activity_id = ?", id, activityId).
+		Delete(&entities.ActivityComment{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCommentNotFound
+	}
+	return nil
+}
+
+// loadMentions fills in the mentions of the given comments.
+func (repository *activityCommentRepositoryImpl) loadMentions(comments []entities.ActivityComment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(comments))
+	for i, comment := range comments {
+		ids[i] = comment.Id
+	}
+
+	var mentions []entities.CommentMention
+	err := repository.DB.
+		Select("activity_comment_mentions.*, users.email, users.name").
+		Joins("JOIN users ON users.id = activity_comment_mentions.user_id").
+		Where("activity_comment_mentions.comment_id IN ?", ids).
+		Order("users.email").
+		Find(&mentions).Error
+	if err != nil {
+		return err
+	}
+
+	byComment := make(map[int][]entities.CommentMention)
+	for _, mention := range mentions {
+		byComment[mention.CommentId] = append(byComment[mention.CommentId], mention)
+	}
+	for i := range comments {
+		comments[i].Mentions = byComment[comments[i].Id]
+	}
+	return nil
+}
+
+func saveMentions(tx *gorm.DB, commentId int, mentions []entities.CommentMention) error {
+	if len(mentions) == 0 {
+		return nil
+	}
+	for i := range mentions {
+		mentions[i].CommentId = commentId
+	}
+	return tx.Create(&mentions).Error
+}
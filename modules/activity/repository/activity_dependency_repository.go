@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"errors"
+	"todolist-v1/modules/activity/entities"
+)
+
+var ErrDependencyNotFound = errors.New("dependency not found")
+
+type ActivityDependencyRepository interface {
+	// FindBlockers returns the visible activities blocking the activity.
+	FindBlockers(userId int, activityId int) ([]entities.Activity, error)
+	// FindDependents returns the visible activities the activity blocks.
+	FindDependents(userId int, activityId int) ([]entities.Activity, error)
+	// FindBlockedBy returns the dependencies of the given activities, visible
+	// or not.
+	FindBlockedBy(activityIds []int) ([]entities.ActivityDependency, error)
+	// FindOpenGraph returns the dependencies between open activities whose
+	// blocked activity is visible to the user.
+	FindOpenGraph(userId int) ([]entities.ActivityDependency, error)
+	// Save adds the dependency; adding it again changes nothing.
+	Save(dependency entities.ActivityDependency) (entities.ActivityDependency, error)
+	Delete(activityId int, blockerId int) error
+	// Transaction runs fn with a repository bound to a single database
+	// transaction in which no other transaction changes dependencies.
+	Transaction(fn func(dependencies ActivityDependencyRepository) error) error
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"todolist-v1/modules/activity/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type activityDependencyRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewActivityDependencyRepository(db *gorm.DB) ActivityDependencyRepository {
+	return &activityDependencyRepositoryImpl{DB: db}
+}
+
+func (repository *activityDependencyRepositoryImpl) FindBlockers(userId int, activityId int) ([]entities.Activity, error) {
+	var activities []entities.Activity
+	err := repository.DB.Scopes(visibleTo(userId), withTotals).
+		Joins("JOIN activity_dependencies ON activity_dependencies.blocker_id = activities.id").
+		Where("activity_dependencies.activity_id = ?", activityId).
+		Order("activities.id").
+		Find(&activities).Error
+	if err != nil {
+		return nil, err
+	}
+	return activities, nil
+}
+
+func (repository *activityDependencyRepositoryImpl) FindDependents(userId int, activityId int) ([]entities.Activity, error) {
+	var activities []entities.Activity
+	err := repository.DB.Scopes(visibleTo(userId), withTotals).
+		Joins("JOIN activity_dependencies ON activity_dependencies.activity_id = activities.id").
+		Where("activity_dependencies.blocker_id = ?", activityId).
+		Order("activities.id").
+		Find(&activities).Error
+	if err != nil {
+		return nil, err
+	}
+	return activities, nil
+}
+
+func (repository *activityDependencyRepositoryImpl) FindBlockedBy(activityIds []int) ([]entities.ActivityDependency, error) {
+	var dependencies []entities.ActivityDependency
+	if err := repository.DB.Where("activity_id IN ?", activityIds).Find(&dependencies).Error; err != nil {
+		return nil, err
+	}
+	return dependencies, nil
+}
+
+func (repository *activityDependencyRepositoryImpl) FindOpenGraph(userId int) ([]entities.ActivityDependency, error) {
+	var dependencies []entities.ActivityDependency
+	err := repository.DB.Model(&entities.Activity{}).Scopes(visibleTo(userId)).
+		Select("activity_dependencies.*").
+		Joins("JOIN activity_dependencies ON activity_dependencies.activity_id = activities.id").
+		Joins("JOIN activities AS blockers ON blockers.id = activity_dependencies.blocker_id AND blockers.deleted_at IS NULL").
+		Where("activities.status IN ? AND blockers.status IN ?", entities.OpenStatuses, entities.OpenStatuses).
+		Scan(&dependencies).Error
+	if err != nil {
+		return nil, err
+	}
+	return dependencies, nil
+}
+
+func (repository *activityDependencyRepositoryImpl) Save(dependency entities.ActivityDependency) (entities.ActivityDependency, error) {
+	err := repository.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&dependency).Error
+	if err != nil {
+		return entities.ActivityDependency{}, err
+	}
+	return dependency, nil
+}
+
+func (repository *activityDependencyRepositoryImpl) Delete(activityId int, blockerId int) error {
+	result := repository.DB.
+		Where("activity_id = ? AND blocker_id = ?", activityId, blockerId).
+		Delete(&entities.ActivityDependency{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrDependencyNotFound
+	}
+	return nil
+}
+
+func (repository *activityDependencyRepositoryImpl) Transaction(fn func(dependencies ActivityDependencyRepository) error) error {
+	return repository.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext('activity_dependencies'))").Error; err != nil {
+			return err
+		}
+		return fn(&activityDependencyRepositoryImpl{DB: tx})
+	})
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"errors"
+	"todolist-v1/modules/activity/entities"
+)
+
+var ErrRevisionNotFound = errors.New("revision not found")
+
+type ActivityHistoryRepository interface {
+	// FindAll returns the revisions of an activity, newest first.
+	FindAll(activityId int) ([]entities.ActivityHistory, error)
+	FindRevision(activityId int, revision int) (entities.ActivityHistory, error)
+	// FindLatest returns the newest revision of an activity.
+	FindLatest(activityId int) (entities.ActivityHistory, error)
+	// Append stores entry as the next revision of its activity. It must run
+	// in the transaction that changed the activity, whose row lock keeps
+	// concurrent changes from claiming the same revision.
+	Append(entry entities.ActivityHistory) (entities.ActivityHistory, error)
+}
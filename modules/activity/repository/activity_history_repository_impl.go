@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"errors"
+	"todolist-v1/modules/activity/entities"
+
+	"gorm.io/gorm"
+)
+
+type activityHistoryRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewActivityHistoryRepository(db *gorm.DB) ActivityHistoryRepository {
+	return &activityHistoryRepositoryImpl{DB: db}
+}
+
+func (repository *activityHistoryRepositoryImpl) FindAll(activityId int) ([]entities.ActivityHistory, error) {
+	var history []entities.ActivityHistory
+	err := repository.DB.
+		Select("activity_history.*, users.email AS actor_email").
+		Joins("LEFT JOIN users ON users.id = activity_history.actor_id").
+		Where("activity_history.activity_id = ?", activityId).
+		Order("activity_history.revision DESC").
+		Find(&history).Error
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+func (repository *activityHistoryRepositoryImpl) FindRevision(activityId int, revision int) (entities.ActivityHistory, error) {
+	var entry entities.ActivityHistory
+	err := repository.DB.
+		Where("activity_id = ? AND revision = ?", activityId, revision).
+		First(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.ActivityHistory{}, ErrRevisionNotFound
+		}
+		return entities.ActivityHistory{}, err
+	}
+	return entry, nil
+}
+
+func (repository *activityHistoryRepositoryImpl) FindLatest(activityId int) (entities.ActivityHistory, error) {
+	var entry entities.ActivityHistory
+	err := repository.DB.
+		Where("activity_id = ?", activityId).
+		Order("revision DESC").
+		First(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.ActivityHistory{}, ErrRevisionNotFound
+		}
+		return entities.ActivityHistory{}, err
+	}
+	return entry, nil
+}
+
+func (repository *activityHistoryRepositoryImpl) Append(entry entities.ActivityHistory) (entities.ActivityHistory, error) {
+	err := repository.DB.Model(&entities.ActivityHistory{}).
+		Select("COALESCE(MAX(revision), 0) + 1").
+		Where("activity_id = ?", entry.ActivityId).
+		Scan(&entry.Revision).Error
+	if err != nil {
+		return entities.ActivityHistory{}, err
+	}
+
+	if err := repository.DB.Create(&entry).Error; err != nil {
+		return entities.ActivityHistory{}, err
+	}
+	return entry, nil
+}
@@ -2,14 +2,89 @@ package repository
 
 import (
 	"errors"
+	"time"
 	"todolist-v1/modules/activity/entities"
 )
 
 var ErrActivityNotFound = errors.New("activity not found")
 
+// ActivityRepository scopes every query to the activities visible to the
+// given user: their personal activities, those of the workspaces they are a
+// member of and those shared with them. Rows outside that set are reported as
+// ErrActivityNotFound. Deleted activities are kept and only found by
+// FindByIdWithDeleted until they are restored.
 type ActivityRepository interface {
-	FindAll() ([]entities.Activity, error)
+	// FindAll returns the visible activities matching the filter, in the
+	// order it asks for.
+	FindAll(userId int, filter entities.ActivityFilter) ([]entities.Activity, error)
+	FindById(userId int, id int) (entities.Activity, error)
+	// FindBetween returns the visible, unarchived activities taking place
+	// in [from, to), ordered by date. A recurring activity is returned once
+	// for each of its occurrences, dated at the occurrence.
+	FindBetween(userId int, from time.Time, to time.Time) ([]entities.Activity, error)
+	// FindOverdue returns the visible, unarchived activities dated before
+	// the given time that are still open, ordered by date.
+	FindOverdue(userId int, before time.Time) ([]entities.Activity, error)
+	// FindOccurrences returns the times the visible activities take place in
+	// [from, to), repeating recurring activities, ordered by time. Days are
+	// taken in zone, except for all-day activities which keep their own.
+	FindOccurrences(userId int, from time.Time, to time.Time, zone string) ([]entities.ActivityOccurrence, error)
+	// CountOccurrences counts what FindOccurrences returns by day and status.
+	CountOccurrences(userId int, from time.Time, to time.Time, zone string) ([]entities.DayStatusCount, error)
+	// FindConflicts returns the occurrences of the timed events of the
+	// owner of event that overlap an occurrence of event starting before
+	// to. The event is expanded as saved. It does not check visibility.
+	FindConflicts(event entities.Activity, to time.Time) ([]entities.ActivityOccurrence, error)
+	// FindBusy returns the periods in [from, to) taken by occurrences of
+	// the owner's timed events, ordered by start. It does not check
+	// visibility.
+	FindBusy(ownerId int, from time.Time, to time.Time) ([]entities.BusyPeriod, error)
+	// CountOpenBlockers counts the open activities blocking the activity,
+	// visible or not.
+	CountOpenBlockers(id int) (int64, error)
+	// LockSchedule holds back other transactions locking the owner's
+	// schedule until the surrounding transaction ends.
+	LockSchedule(ownerId int) error
+	// LockBoard holds back other transactions locking the project's board
+	// until the surrounding transaction ends.
+	LockBoard(projectId int) error
+	// FindLastRank returns the highest rank in the board column of the
+	// project with the given status, other than excludeId's, or "" for an
+	// empty column. FindRankAfter and FindRankBefore return the nearest rank
+	// above and below rank in the column, or "" when there is none. They do
+	// not check visibility.
+	FindLastRank(projectId int, status string, excludeId int) (string, error)
+	FindRankAfter(projectId int, status string, rank string, excludeId int) (string, error)
+	FindRankBefore(projectId int, status string, rank string, excludeId int) (string, error)
+	// FindColumn returns the ids of the ranked activities in the board
+	// column, other than excludeId, by rank. SetRank changes the rank of an
+	// activity. Neither checks visibility.
+	FindColumn(projectId int, status string, excludeId int) ([]int, error)
+	SetRank(id int, rank string) error
 	Save(activity entities.Activity) (entities.Activity, error)
-	Update(id int, activity entities.Activity) (entities.Activity, error)
-	Delete(id int) error
+	Update(userId int, id int, activity entities.Activity) (entities.Activity, error)
+	Delete(userId int, id int) error
+	FindByIdWithDeleted(userId int, id int) (entities.Activity, error)
+	// Purge removes the activity, deleted or not, for good.
+	Purge(userId int, id int) error
+	// Lock returns the activity, deleted or not, and locks its row until the
+	// surrounding transaction ends.
+	Lock(userId int, id int) (entities.Activity, error)
+	// Reschedule moves the activity to date, along with its start, end and
+	// due dates, remembering its first date and counting the move.
+	Reschedule(userId int, id int, date time.Time) (entities.Activity, error)
+	// Move puts the activity in the board column of the project with the
+	// given status at rank.
+	Move(userId int, id int, status string, projectId int, rank string) (entities.Activity, error)
+	// Archive and Pin set the archived and pinned flags of the activity.
+	Archive(userId int, id int, archived bool) (entities.Activity, error)
+	Pin(userId int, id int, pinned bool) (entities.Activity, error)
+	// Restore overwrites the activity with the given fields and undeletes it.
+	Restore(userId int, id int, activity entities.Activity) (entities.Activity, error)
+	// Revert overwrites the activity, deleted or not, with every field the
+	// usecases change, its project and rank included, and undeletes it.
+	Revert(userId int, id int, activity entities.Activity) (entities.Activity, error)
+	// Transaction runs fn with repositories bound to a single database
+	// transaction, which is committed when fn returns nil.
+	Transaction(fn func(activities ActivityRepository, history ActivityHistoryRepository) error) error
 }
@@ -2,9 +2,13 @@ package repository
 
 import (
 	"errors"
+	"time"
 	"todolist-v1/modules/activity/entities"
+	"todolist-v1/pkg/filterquery"
 
+	"github.com/lib/pq"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type activityRepositoryImpl struct {
@@ -15,14 +19,357 @@ func NewActivityRepository(db *gorm.DB) ActivityRepository {
 	return &activityRepositoryImpl{DB: db}
 }
 
-func (repository *activityRepositoryImpl) FindAll() ([]entities.Activity, error) {
+// visibleTo limits a query to the activities userId may see: their personal
+// activities, those of their workspaces and those explicitly shared with them.
+func visibleTo(userId int) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(
+			"((activities.workspace_id IS NULL AND activities.owner_id = ?) OR "+
+				"activities.workspace_id IN (SELECT workspace_id FROM workspace_members WHERE user_id = ?) OR "+
+				"activities.id IN (SELECT activity_id FROM activity_shares WHERE user_id = ?))",
+			userId, userId, userId,
+		)
+	}
+}
+
+// withTotals selects the activity columns along with the number of
+// comments on each activity and the time tracked on it.
+func withTotals(db *gorm.DB) *gorm.DB {
+	return db.Select("activities.*, " + totals)
+}
+
+// totals only counts finished time entries, so that the tracked time of an
+// activity does not change while a timer runs.
+const totals = "(SELECT COUNT(*) FROM activity_comments WHERE activity_comments.activity_id = activities.id) AS comment_count, " +
+	"(SELECT COALESCE(SUM(EXTRACT(EPOCH FROM time_entries.ended_at - time_entries.started_at)), 0)::bigint " +
+	"FROM time_entries WHERE time_entries.activity_id = activities.id AND time_entries.ended_at IS NOT NULL) AS tracked_seconds"
+
+// occurring joins each activity with the times it takes place in [from, to),
+// as expanded by the activity_occurrences database function.
+func occurring(from time.Time, to time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Joins("CROSS JOIN LATERAL activity_occurrences("+
+			"activities.activity_date, COALESCE(activities.timezone, 'UTC'), activities.recurrence_frequency, "+
+			"activities.recurrence_interval, activities.recurrence_until, ?, ?) AS occurrence", from, to)
+	}
+}
+
+// occurrenceDay is the day an occurrence falls on in the zone bound to its
+// placeholder. All-day activities keep the day of their own timezone.
+const occurrenceDay = "(CASE WHEN activities.all_day " +
+	"THEN (occurrence.occurs_at AT TIME ZONE COALESCE(activities.timezone, 'UTC'))::date " +
+	"ELSE (occurrence.occurs_at AT TIME ZONE ?)::date END)"
+
+// sortOrders maps the sort options of ActivityFilter to ORDER BY clauses.
+// Board order leaves pins out, so that it follows ranks alone.
+var sortOrders = map[string]string{
+	"":                    "activities.pinned DESC, activities.id",
+	entities.SortPriority: "activities.pinned DESC, activities.priority, activities.due_at NULLS LAST, activities.activity_date, activities.id",
+	entities.SortDueDate:  "activities.pinned DESC, activities.due_at NULLS LAST, activities.priority, activities.activity_date, activities.id",
+	entities.SortRank:     "activities.rank NULLS LAST, activities.id",
+}
+
+// queryFields are the fields filter expressions compare activities on.
+var queryFields = filterquery.Schema{
+	"status": {Column: "activities.status", Kind: filterquery.Enum,
+		Values: []string{entities.StatusNew, entities.StatusInProgress, entities.StatusExpired, entities.StatusDone}},
+	"category": {Column: "activities.category", Kind: filterquery.Enum,
+		Values: []string{entities.CategoryTask, entities.CategoryEvent}},
+	"priority":  {Column: "activities.priority", Kind: filterquery.Enum, Values: []string{"P1", "P2", "P3", "P4"}, Ordered: true},
+	"tag":       {Column: "activities.tags", Kind: filterquery.Tag},
+	"title":     {Column: "activities.title", Kind: filterquery.Text},
+	"date":      {Column: "activities.activity_date", Kind: filterquery.Date},
+	"due":       {Column: "activities.due_at", Kind: filterquery.Date},
+	"project":   {Column: "activities.project_id", Kind: filterquery.Number},
+	"workspace": {Column: "activities.workspace_id", Kind: filterquery.Number},
+}
+
+func (repository *activityRepositoryImpl) FindAll(userId int, filter entities.ActivityFilter) ([]entities.Activity, error) {
+	query := repository.DB.Scopes(visibleTo(userId), withTotals).Order(sortOrders[filter.Sort]).
+		Where("activities.archived = ?", filter.Archived)
+	if filter.WorkspaceId != nil {
+		query = query.Where("activities.workspace_id = ?", *filter.WorkspaceId)
+	}
+	if filter.ProjectId != nil {
+		query = query.Where("activities.project_id = ?", *filter.ProjectId)
+	}
+	if filter.ParentId != nil {
+		query = query.Where("activities.parent_id = ?", *filter.ParentId)
+	}
+	if len(filter.Tags) > 0 {
+		query = query.Where("activities.tags @> ?::text[]", pq.StringArray(filter.Tags))
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("activities.status IN ?", filter.Statuses)
+	}
+	if len(filter.Categories) > 0 {
+		query = query.Where("activities.category IN ?", filter.Categories)
+	}
+	if len(filter.Priorities) > 0 {
+		query = query.Where("activities.priority IN ?", filter.Priorities)
+	}
+	if filter.Overdue {
+		query = query.Where("activities.activity_date < NOW() AND activities.status IN ?", entities.OpenStatuses)
+	}
+	if filter.Query != "" {
+		node, err := filterquery.Parse(filter.Query)
+		if err != nil {
+			return nil, err
+		}
+		location := filter.Location
+		if location == nil {
+			location = time.UTC
+		}
+		condition, err := filterquery.Compile(node, queryFields, location)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(condition)
+	}
+
+	var activities []entities.Activity
+	if err := query.Find(&activities).Error; err != nil {
+		return nil, err
+	}
+	return activities, nil
+}
+
+func (repository *activityRepositoryImpl) FindById(userId int, id int) (entities.Activity, error) {
+	var activity entities.Activity
+	if err := repository.DB.Scopes(visibleTo(userId), withTotals).First(&activity, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.Activity{}, ErrActivityNotFound
+		}
+		return entities.Activity{}, err
+	}
+	return activity, nil
+}
+
+func (repository *activityRepositoryImpl) FindBetween(userId int, from time.Time, to time.Time) ([]entities.Activity, error) {
+	var occurrences []entities.ActivityOccurrence
+	err := repository.DB.Model(&entities.Activity{}).Scopes(visibleTo(userId), occurring(from, to)).
+		Select("activities.*, "+totals+", occurrence.occurs_at").
+		Where("activities.archived = ?", false).
+		Order("occurrence.occurs_at, activities.id").
+		Find(&occurrences).Error
+	if err != nil {
+		return nil, err
+	}
+
+	activities := make([]entities.Activity, len(occurrences))
+	for i, occurrence := range occurrences {
+		activities[i] = occurrence.Activity
+		activities[i].ActivityDate = occurrence.OccursAt
+	}
+	return activities, nil
+}
+
+func (repository *activityRepositoryImpl) FindOverdue(userId int, before time.Time) ([]entities.Activity, error) {
 	var activities []entities.Activity
-	if err := repository.DB.Find(&activities).Error; err != nil {
+	err := repository.DB.Scopes(visibleTo(userId), withTotals).
+		Where("activities.activity_date < ? AND activities.status IN ? AND activities.archived = ?", before, entities.OpenStatuses, false).
+		Order("activities.activity_date, activities.id").
+		Find(&activities).Error
+	if err != nil {
 		return nil, err
 	}
 	return activities, nil
 }
 
+func (repository *activityRepositoryImpl) FindOccurrences(userId int, from time.Time, to time.Time, zone string) ([]entities.ActivityOccurrence, error) {
+	var occurrences []entities.ActivityOccurrence
+	err := repository.DB.Model(&entities.Activity{}).Scopes(visibleTo(userId), occurring(from, to)).
+		Select("activities.*, "+totals+", occurrence.occurs_at, "+occurrenceDay+" AS day", zone).
+		Order("occurrence.occurs_at, activities.id").
+		Find(&occurrences).Error
+	if err != nil {
+		return nil, err
+	}
+	return occurrences, nil
+}
+
+func (repository *activityRepositoryImpl) CountOccurrences(userId int, from time.Time, to time.Time, zone string) ([]entities.DayStatusCount, error) {
+	var counts []entities.DayStatusCount
+	err := repository.DB.Model(&entities.Activity{}).Scopes(visibleTo(userId), occurring(from, to)).
+		Select(occurrenceDay+" AS day, activities.status, COUNT(*) AS count", zone).
+		Group("day, activities.status").
+		Order("day, activities.status").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// Single events are matched on their period, which is indexed. Recurring
+// events are expanded first, starting early enough to catch a repetition
+// that began before the range and still lasts into it.
+const (
+	eventDuration = "COALESCE(activities.end_at - activities.start_at, INTERVAL '0')"
+
+	recurringOccurrences = "CROSS JOIN LATERAL activity_occurrences(" +
+		"activities.activity_date, COALESCE(activities.timezone, 'UTC'), activities.recurrence_frequency, " +
+		"activities.recurrence_interval, activities.recurrence_until, @from::timestamptz - " + eventDuration + ", @to) AS occurrence"
+
+	occurrencePeriod = "event_period(occurrence.occurs_at, occurrence.occurs_at + " + eventDuration + ")"
+
+	// checkedPeriods are the periods of the occurrences of the event being
+	// checked that start in [@from, @horizon).
+	checkedPeriods = "WITH checked AS (SELECT " + occurrencePeriod + " AS period FROM activities " +
+		"CROSS JOIN LATERAL activity_occurrences(" +
+		"activities.activity_date, COALESCE(activities.timezone, 'UTC'), activities.recurrence_frequency, " +
+		"activities.recurrence_interval, activities.recurrence_until, @from, @horizon) AS occurrence " +
+		"WHERE activities.id = @event) "
+
+	conflictsQuery = checkedPeriods +
+		"SELECT activities.*, activities.activity_date AS occurs_at FROM activities " +
+		"WHERE activities.owner_id = @owner AND activities.id <> @event AND activities.deleted_at IS NULL " +
+		"AND activities.recurrence_frequency IS NULL " +
+		"AND activities.period && (SELECT tstzrange(MIN(lower(period)), MAX(upper(period)), '[]') FROM checked) " +
+		"AND EXISTS (SELECT 1 FROM checked WHERE checked.period && activities.period) " +
+		"UNION ALL " +
+		"SELECT activities.*, occurrence.occurs_at FROM activities " + recurringOccurrences + " " +
+		"WHERE activities.owner_id = @owner AND activities.id <> @event AND activities.deleted_at IS NULL " +
+		"AND activities.recurrence_frequency IS NOT NULL AND activities.period IS NOT NULL " +
+		"AND EXISTS (SELECT 1 FROM checked WHERE checked.period && " + occurrencePeriod + ") " +
+		"ORDER BY occurs_at, id"
+
+	busyQuery = "SELECT lower(activities.period) AS start_at, upper(activities.period) AS end_at FROM activities " +
+		"WHERE activities.owner_id = @owner AND activities.deleted_at IS NULL " +
+		"AND activities.recurrence_frequency IS NULL AND activities.period && tstzrange(@from, @to) " +
+		"UNION ALL " +
+		"SELECT occurrence.occurs_at, occurrence.occurs_at + " + eventDuration + " FROM activities " + recurringOccurrences + " " +
+		"WHERE activities.owner_id = @owner AND activities.deleted_at IS NULL " +
+		"AND activities.recurrence_frequency IS NOT NULL AND activities.period IS NOT NULL " +
+		"AND " + occurrencePeriod + " && tstzrange(@from, @to) " +
+		"ORDER BY start_at"
+)
+
+func (repository *activityRepositoryImpl) FindConflicts(event entities.Activity, to time.Time) ([]entities.ActivityOccurrence, error) {
+	// The expansion of the other recurring events stops just after the
+	// last checked occurrence ends; the overlap test drops what lies
+	// beyond it.
+	var duration time.Duration
+	if event.EndAt != nil && event.EndAt.After(*event.StartAt) {
+		duration = event.EndAt.Sub(*event.StartAt)
+	}
+
+	var conflicts []entities.ActivityOccurrence
+	err := repository.DB.Raw(conflictsQuery, map[string]any{
+		"owner":   event.OwnerId,
+		"event":   event.Id,
+		"from":    *event.StartAt,
+		"horizon": to,
+		"to":      to.Add(duration + time.Microsecond),
+	}).Scan(&conflicts).Error
+	if err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+func (repository *activityRepositoryImpl) FindBusy(ownerId int, from time.Time, to time.Time) ([]entities.BusyPeriod, error) {
+	var periods []entities.BusyPeriod
+	err := repository.DB.Raw(busyQuery, map[string]any{
+		"owner": ownerId,
+		"from":  from,
+		"to":    to,
+	}).Scan(&periods).Error
+	if err != nil {
+		return nil, err
+	}
+	return periods, nil
+}
+
+func (repository *activityRepositoryImpl) CountOpenBlockers(id int) (int64, error) {
+	var count int64
+	err := repository.DB.Model(&entities.Activity{}).
+		Joins("JOIN activity_dependencies ON activity_dependencies.blocker_id = activities.id").
+		Where("activity_dependencies.activity_id = ? AND activities.status IN ?", id, entities.OpenStatuses).
+		Count(&count).Error
+	return count, err
+}
+
+func (repository *activityRepositoryImpl) LockSchedule(ownerId int) error {
+	return repository.DB.Exec("SELECT pg_advisory_xact_lock(hashtext('activity_schedule'), ?)", ownerId).Error
+}
+
+func (repository *activityRepositoryImpl) LockBoard(projectId int) error {
+	return repository.DB.Exec("SELECT pg_advisory_xact_lock(hashtext('project_board'), ?)", projectId).Error
+}
+
+// boardColumn limits a query to the ranked activities of a board column.
+func boardColumn(projectId int, status string, excludeId int) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Model(&entities.Activity{}).
+			Where("project_id = ? AND status = ? AND rank IS NOT NULL AND id <> ?", projectId, status, excludeId)
+	}
+}
+
+func (repository *activityRepositoryImpl) FindLastRank(projectId int, status string, excludeId int) (string, error) {
+	var ranks []string
+	err := repository.DB.Scopes(boardColumn(projectId, status, excludeId)).
+		Order("rank DESC").Limit(1).Pluck("rank", &ranks).Error
+	return firstRank(ranks), err
+}
+
+func (repository *activityRepositoryImpl) FindRankAfter(projectId int, status string, rank string, excludeId int) (string, error) {
+	var ranks []string
+	err := repository.DB.Scopes(boardColumn(projectId, status, excludeId)).
+		Where("rank > ?", rank).Order("rank").Limit(1).Pluck("rank", &ranks).Error
+	return firstRank(ranks), err
+}
+
+func (repository *activityRepositoryImpl) FindRankBefore(projectId int, status string, rank string, excludeId int) (string, error) {
+	var ranks []string
+	err := repository.DB.Scopes(boardColumn(projectId, status, excludeId)).
+		Where("rank < ?", rank).Order("rank DESC").Limit(1).Pluck("rank", &ranks).Error
+	return firstRank(ranks), err
+}
+
+func (repository *activityRepositoryImpl) FindColumn(projectId int, status string, excludeId int) ([]int, error) {
+	var ids []int
+	err := repository.DB.Scopes(boardColumn(projectId, status, excludeId)).
+		Order("rank").Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (repository *activityRepositoryImpl) SetRank(id int, rank string) error {
+	return repository.DB.Model(&entities.Activity{}).Where("id = ?", id).Update("rank", rank).Error
+}
+
+func firstRank(ranks []string) string {
+	if len(ranks) == 0 {
+		return ""
+	}
+	return ranks[0]
+}
+
+func (repository *activityRepositoryImpl) FindByIdWithDeleted(userId int, id int) (entities.Activity, error) {
+	var activity entities.Activity
+	if err := repository.DB.Unscoped().Scopes(visibleTo(userId), withTotals).First(&activity, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.Activity{}, ErrActivityNotFound
+		}
+		return entities.Activity{}, err
+	}
+	return activity, nil
+}
+
+func (repository *activityRepositoryImpl) Lock(userId int, id int) (entities.Activity, error) {
+	var activity entities.Activity
+	err := repository.DB.Unscoped().Scopes(visibleTo(userId)).
+		Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "activities"}}).
+		First(&activity, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.Activity{}, ErrActivityNotFound
+		}
+		return entities.Activity{}, err
+	}
+	return activity, nil
+}
+
 func (repository *activityRepositoryImpl) Save(activity entities.Activity) (entities.Activity, error) {
 	if err := repository.DB.Create(&activity).Error; err != nil {
 		return entities.Activity{}, err
@@ -30,13 +377,24 @@ func (repository *activityRepositoryImpl) Save(activity entities.Activity) (enti
 	return activity, nil
 }
 
-func (repository *activityRepositoryImpl) Update(id int, activity entities.Activity) (entities.Activity, error) {
-	result := repository.DB.Model(&entities.Activity{}).Where("id = ?", id).Updates(map[string]any{
-		"title":         activity.Title,
-		"category":      activity.Category,
-		"description":   activity.Description,
-		"activity_date": activity.ActivityDate,
-		"status":        activity.Status,
+func (repository *activityRepositoryImpl) Update(userId int, id int, activity entities.Activity) (entities.Activity, error) {
+	result := repository.DB.Model(&entities.Activity{}).Scopes(visibleTo(userId)).Where("id = ?", id).Updates(map[string]any{
+		"title":                activity.Title,
+		"category":             activity.Category,
+		"description":          activity.Description,
+		"activity_date":        activity.ActivityDate,
+		"status":               activity.Status,
+		"priority":             activity.Priority,
+		"start_at":             activity.StartAt,
+		"end_at":               activity.EndAt,
+		"due_at":               activity.DueAt,
+		"timezone":             activity.Timezone,
+		"all_day":              activity.AllDay,
+		"local_date":           activity.LocalDate,
+		"recurrence_frequency": activity.RecurrenceFrequency,
+		"recurrence_interval":  activity.RecurrenceInterval,
+		"recurrence_until":     activity.RecurrenceUntil,
+		"tags":                 activity.Tags,
 	})
 	if result.Error != nil {
 		return entities.Activity{}, result.Error
@@ -45,19 +403,58 @@ func (repository *activityRepositoryImpl) Update(id int, activity entities.Activ
 		return entities.Activity{}, ErrActivityNotFound
 	}
 
-	var updated entities.Activity
-	if err := repository.DB.First(&updated, id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return entities.Activity{}, ErrActivityNotFound
-		}
-		return entities.Activity{}, err
+	return repository.FindById(userId, id)
+}
+
+func (repository *activityRepositoryImpl) Reschedule(userId int, id int, date time.Time) (entities.Activity, error) {
+	result := repository.DB.Model(&entities.Activity{}).Scopes(visibleTo(userId)).Where("id = ?", id).Updates(map[string]any{
+		"original_activity_date": gorm.Expr("COALESCE(original_activity_date, activity_date)"),
+		"activity_date":          date,
+		"start_at":               gorm.Expr("start_at + (?::timestamptz - activity_date)", date),
+		"end_at":                 gorm.Expr("end_at + (?::timestamptz - activity_date)", date),
+		"due_at":                 gorm.Expr("due_at + (?::timestamptz - activity_date)", date),
+		"local_date":             gorm.Expr("CASE WHEN all_day THEN (?::timestamptz AT TIME ZONE COALESCE(timezone, 'UTC'))::date END", date),
+		"reschedule_count":       gorm.Expr("reschedule_count + 1"),
+	})
+	if result.Error != nil {
+		return entities.Activity{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.Activity{}, ErrActivityNotFound
 	}
 
-	return activity, nil
+	return repository.FindById(userId, id)
 }
 
-func (repository *activityRepositoryImpl) Delete(id int) error {
-	result := repository.DB.Delete(&entities.Activity{}, id)
+func (repository *activityRepositoryImpl) Move(userId int, id int, status string, projectId int, rank string) (entities.Activity, error) {
+	result := repository.DB.Model(&entities.Activity{}).Scopes(visibleTo(userId)).Where("id = ?", id).Updates(map[string]any{
+		"status":     status,
+		"project_id": projectId,
+		"rank":       rank,
+	})
+	if result.Error != nil {
+		return entities.Activity{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.Activity{}, ErrActivityNotFound
+	}
+
+	return repository.FindById(userId, id)
+}
+
+func (repository *activityRepositoryImpl) Delete(userId int, id int) error {
+	result := repository.DB.Scopes(visibleTo(userId)).Delete(&entities.Activity{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrActivityNotFound
+	}
+	return nil
+}
+
+func (repository *activityRepositoryImpl) Purge(userId int, id int) error {
+	result := repository.DB.Unscoped().Scopes(visibleTo(userId)).Delete(&entities.Activity{}, id)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -66,3 +463,80 @@ func (repository *activityRepositoryImpl) Delete(id int) error {
 	}
 	return nil
 }
+
+func (repository *activityRepositoryImpl) Archive(userId int, id int, archived bool) (entities.Activity, error) {
+	return repository.setFlag(userId, id, "archived", archived)
+}
+
+func (repository *activityRepositoryImpl) Pin(userId int, id int, pinned bool) (entities.Activity, error) {
+	return repository.setFlag(userId, id, "pinned", pinned)
+}
+
+func (repository *activityRepositoryImpl) setFlag(userId int, id int, column string, value bool) (entities.Activity, error) {
+	result := repository.DB.Model(&entities.Activity{}).Scopes(visibleTo(userId)).Where("id = ?", id).Update(column, value)
+	if result.Error != nil {
+		return entities.Activity{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.Activity{}, ErrActivityNotFound
+	}
+
+	return repository.FindById(userId, id)
+}
+
+func (repository *activityRepositoryImpl) Restore(userId int, id int, activity entities.Activity) (entities.Activity, error) {
+	return repository.overwrite(userId, id, restoredColumns(activity))
+}
+
+func (repository *activityRepositoryImpl) Revert(userId int, id int, activity entities.Activity) (entities.Activity, error) {
+	columns := restoredColumns(activity)
+	columns["project_id"] = activity.ProjectId
+	columns["rank"] = activity.Rank
+	columns["original_activity_date"] = activity.OriginalActivityDate
+	columns["reschedule_count"] = activity.RescheduleCount
+	return repository.overwrite(userId, id, columns)
+}
+
+// restoredColumns are the columns a revision of the activity brings back,
+// undeleting it.
+func restoredColumns(activity entities.Activity) map[string]any {
+	return map[string]any{
+		"title":                activity.Title,
+		"category":             activity.Category,
+		"description":          activity.Description,
+		"activity_date":        activity.ActivityDate,
+		"status":               activity.Status,
+		"priority":             activity.Priority,
+		"start_at":             activity.StartAt,
+		"end_at":               activity.EndAt,
+		"due_at":               activity.DueAt,
+		"timezone":             activity.Timezone,
+		"all_day":              activity.AllDay,
+		"local_date":           activity.LocalDate,
+		"recurrence_frequency": activity.RecurrenceFrequency,
+		"recurrence_interval":  activity.RecurrenceInterval,
+		"recurrence_until":     activity.RecurrenceUntil,
+		"tags":                 activity.Tags,
+		"archived":             activity.Archived,
+		"pinned":               activity.Pinned,
+		"deleted_at":           nil,
+	}
+}
+
+func (repository *activityRepositoryImpl) overwrite(userId int, id int, columns map[string]any) (entities.Activity, error) {
+	result := repository.DB.Unscoped().Model(&entities.Activity{}).Scopes(visibleTo(userId)).Where("id = ?", id).Updates(columns)
+	if result.Error != nil {
+		return entities.Activity{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.Activity{}, ErrActivityNotFound
+	}
+
+	return repository.FindById(userId, id)
+}
+
+func (repository *activityRepositoryImpl) Transaction(fn func(activities ActivityRepository, history ActivityHistoryRepository) error) error {
+	return repository.DB.Transaction(func(tx *gorm.DB) error {
+		return fn(&activityRepositoryImpl{DB: tx}, &activityHistoryRepositoryImpl{DB: tx})
+	})
+}
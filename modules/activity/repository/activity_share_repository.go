@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"errors"
+	"todolist-v1/modules/activity/entities"
+)
+
+var (
+	ErrShareNotFound     = errors.New("share not found")
+	ErrShareLinkNotFound = errors.New("share link not found")
+)
+
+type ActivityShareRepository interface {
+	FindShares(activityId int) ([]entities.ActivityShare, error)
+	FindShare(activityId int, userId int) (entities.ActivityShare, error)
+	// SaveShare creates the share or updates the permission of an existing
+	// share for the same user.
+	SaveShare(share entities.ActivityShare) (entities.ActivityShare, error)
+	DeleteShare(activityId int, userId int) error
+
+	FindActiveLinks(activityId int) ([]entities.ActivityShareLink, error)
+	SaveLink(link entities.ActivityShareLink) (entities.ActivityShareLink, error)
+	RevokeLink(activityId int, id int) error
+	// FindActivityByLinkHash returns the activity behind an unexpired,
+	// unrevoked share link.
+	FindActivityByLinkHash(hash string) (entities.Activity, error)
+}
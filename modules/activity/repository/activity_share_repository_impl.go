@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"errors"
+	"time"
+	"todolist-v1/modules/activity/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type activityShareRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewActivityShareRepository(db *gorm.DB) ActivityShareRepository {
+	return &activityShareRepositoryImpl{DB: db}
+}
+
+func (repository *activityShareRepositoryImpl) FindShares(activityId int) ([]entities.ActivityShare, error) {
+	var shares []entities.ActivityShare
+	err := repository.DB.
+		Select("activity_shares.*, users.email, users.name").
+		Joins("JOIN users ON users.id = activity_shares.user_id").
+		Where("activity_shares.activity_id = ?", activityId).
+		Order("activity_shares.created_at").
+		Find(&shares).Error
+	if err != nil {
+		return nil, err
+	}
+	return shares, nil
+}
+
+func (repository *activityShareRepositoryImpl) FindShare(activityId int, userId int) (entities.ActivityShare, error) {
+	var share entities.ActivityShare
+	err := repository.DB.
+		Where("activity_id = ? AND user_id = ?", activityId, userId).
+		First(&share).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.ActivityShare{}, ErrShareNotFound
+		}
+		return entities.ActivityShare{}, err
+	}
+	return share, nil
+}
+
+func (repository *activityShareRepositoryImpl) SaveShare(share entities.ActivityShare) (entities.ActivityShare, error) {
+	err := repository.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "activity_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"permission"}),
+	}).Create(&share).Error
+	if err != nil {
+		return entities.ActivityShare{}, err
+	}
+	return share, nil
+}
+
+func (repository *activityShareRepositoryImpl) DeleteShare(activityId int, userId int) error {
+	result := repository.DB.
+		Where("activity_id = ? AND user_id = ?", activityId, userId).
+		Delete(&entities.ActivityShare{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrShareNotFound
+	}
+	return nil
+}
+
+func (repository *activityShareRepositoryImpl) FindActiveLinks(activityId int) ([]entities.ActivityShareLink, error) {
+	var links []entities.ActivityShareLink
+	err := repository.DB.
+		Where("activity_id = ? AND revoked_at IS NULL AND expires_at > ?", activityId, time.Now()).
+		Order("created_at DESC").
+		Find(&links).Error
+	if err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+func (repository *activityShareRepositoryImpl) SaveLink(link entities.ActivityShareLink) (entities.ActivityShareLink, error) {
+	if err := repository.DB.Create(&link).Error; err != nil {
+		return entities.ActivityShareLink{}, err
+	}
+	return link, nil
+}
+
+func (repository *activityShareRepositoryImpl) RevokeLink(activityId int, id int) error {
+	result := repository.DB.Model(&entities.ActivityShareLink{}).
+		Where("id = ? AND activity_id = ? AND revoked_at IS NULL", id, activityId).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrShareLinkNotFound
+	}
+	return nil
+}
+
+func (repository *activityShareRepositoryImpl) FindActivityByLinkHash(hash string) (entities.Activity, error) {
+	var activity entities.Activity
+	err := repository.DB.Scopes(withTotals).
+		Joins("JOIN activity_share_links ON activity_share_links.activity_id = activities.id").
+		Where("activity_share_links.token_hash = ? AND activity_share_links.revoked_at IS NULL AND activity_share_links.expires_at > ?", hash, time.Now()).
+		First(&activity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.Activity{}, ErrShareLinkNotFound
+		}
+		return entities.Activity{}, err
+	}
+	return activity, nil
+}
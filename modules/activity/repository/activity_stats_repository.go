@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"time"
+	"todolist-v1/modules/activity/entities"
+)
+
+// ActivityStatsRepository aggregates the activities visible to a user that
+// are dated in [from, to). Deleted activities are left out.
+type ActivityStatsRepository interface {
+	// CountByDay counts the activities and the done ones by day, taken in
+	// zone except for all-day activities which keep their own.
+	CountByDay(userId int, from time.Time, to time.Time, zone string) ([]entities.DayCompletion, error)
+	CountByCategoryAndStatus(userId int, from time.Time, to time.Time) ([]entities.CategoryStatusCount, error)
+	// CountOverdue counts the open activities dated before now.
+	CountOverdue(userId int, from time.Time, to time.Time, now time.Time) (int, error)
+	// SummarizeCompletions looks at the moves to DONE recorded in [from, to),
+	// whatever the activities are dated.
+	SummarizeCompletions(userId int, from time.Time, to time.Time) (entities.CompletionSummary, error)
+	// FindViewers returns the users the activity is visible to, deleted or
+	// not: its owner, the members of its workspace and the users it is
+	// shared with.
+	FindViewers(activityId int) ([]int, error)
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"time"
+	"todolist-v1/modules/activity/entities"
+
+	"gorm.io/gorm"
+)
+
+type activityStatsRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewActivityStatsRepository(db *gorm.DB) ActivityStatsRepository {
+	return &activityStatsRepositoryImpl{DB: db}
+}
+
+// datedIn limits a query to the activities dated in [from, to).
+func datedIn(from time.Time, to time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("activities.activity_date >= ? AND activities.activity_date < ?", from, to)
+	}
+}
+
+func (repository *activityStatsRepositoryImpl) CountByDay(userId int, from time.Time, to time.Time, zone string) ([]entities.DayCompletion, error) {
+	var days []entities.DayCompletion
+	err := repository.DB.Model(&entities.Activity{}).Scopes(visibleTo(userId), datedIn(from, to)).
+		Select("COALESCE(activities.local_date, (activities.activity_date AT TIME ZONE ?)::date) AS day, "+
+			"COUNT(*) AS due, COUNT(*) FILTER (WHERE activities.status = ?) AS done", zone, entities.StatusDone).
+		Group("day").
+		Order("day").
+		Scan(&days).Error
+	if err != nil {
+		return nil, err
+	}
+	return days, nil
+}
+
+func (repository *activityStatsRepositoryImpl) CountByCategoryAndStatus(userId int, from time.Time, to time.Time) ([]entities.CategoryStatusCount, error) {
+	var counts []entities.CategoryStatusCount
+	err := repository.DB.Model(&entities.Activity{}).Scopes(visibleTo(userId), datedIn(from, to)).
+		Select("activities.category, activities.status, COUNT(*) AS count").
+		Group("activities.category, activities.status").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (repository *activityStatsRepositoryImpl) CountOverdue(userId int, from time.Time, to time.Time, now time.Time) (int, error) {
+	var count int64
+	err := repository.DB.Model(&entities.Activity{}).Scopes(visibleTo(userId), datedIn(from, to)).
+		Where("activities.activity_date < ? AND activities.status IN ?", now, entities.OpenStatuses).
+		Count(&count).Error
+	return int(count), err
+}
+
+func (repository *activityStatsRepositoryImpl) SummarizeCompletions(userId int, from time.Time, to time.Time) (entities.CompletionSummary, error) {
+	var summary entities.CompletionSummary
+	err := repository.DB.Table("activity_history AS done").
+		Select("COUNT(*) AS completed, "+
+			"AVG(EXTRACT(EPOCH FROM done.created_at - created.created_at))::bigint AS average_seconds").
+		Joins("JOIN activities ON activities.id = done.activity_id AND activities.deleted_at IS NULL").
+		Joins("JOIN activity_history AS created ON created.activity_id = done.activity_id AND created.action = ?", entities.HistoryActionCreate).
+		Scopes(visibleTo(userId)).
+		Where("done.changes -> 'status' ->> 'new' = ? AND done.created_at >= ? AND done.created_at < ?", entities.StatusDone, from, to).
+		Scan(&summary).Error
+	return summary, err
+}
+
+// viewersQuery lists the users visibleTo lets see the activity.
+const viewersQuery = "SELECT activities.owner_id AS user_id FROM activities " +
+	"WHERE activities.id = @activity AND activities.workspace_id IS NULL " +
+	"UNION SELECT workspace_members.user_id FROM workspace_members " +
+	"JOIN activities ON activities.workspace_id = workspace_members.workspace_id WHERE activities.id = @activity " +
+	"UNION SELECT activity_shares.user_id FROM activity_shares WHERE activity_shares.activity_id = @activity"
+
+func (repository *activityStatsRepositoryImpl) FindViewers(activityId int) ([]int, error) {
+	var userIds []int
+	if err := repository.DB.Raw(viewersQuery, map[string]any{"activity": activityId}).Scan(&userIds).Error; err != nil {
+		return nil, err
+	}
+	return userIds, nil
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"errors"
+	"todolist-v1/modules/activity/entities"
+)
+
+var ErrSavedFilterNotFound = errors.New("saved filter not found")
+
+// SavedFilterRepository finds saved filters for a user: their personal
+// filters and those shared in the workspaces they are a member of.
+type SavedFilterRepository interface {
+	FindAllForUser(userId int) ([]entities.SavedFilter, error)
+	FindByIdForUser(userId int, id int) (entities.SavedFilter, error)
+	Save(filter entities.SavedFilter) (entities.SavedFilter, error)
+	// Update saves the name and the filter document.
+	Update(id int, filter entities.SavedFilter) (entities.SavedFilter, error)
+	Delete(id int) error
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"errors"
+	"todolist-v1/modules/activity/entities"
+
+	"gorm.io/gorm"
+)
+
+type savedFilterRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewSavedFilterRepository(db *gorm.DB) SavedFilterRepository {
+	return &savedFilterRepositoryImpl{DB: db}
+}
+
+func (repository *savedFilterRepositoryImpl) visibleTo(userId int) *gorm.DB {
+	return repository.DB.Where(
+		"(saved_filters.workspace_id IS NULL AND saved_filters.owner_id = ?) OR "+
+			"saved_filters.workspace_id IN (SELECT workspace_id FROM workspace_members WHERE user_id = ?)",
+		userId, userId,
+	)
+}
+
+func (repository *savedFilterRepositoryImpl) FindAllForUser(userId int) ([]entities.SavedFilter, error) {
+	var filters []entities.SavedFilter
+	if err := repository.visibleTo(userId).Order("saved_filters.name, saved_filters.id").Find(&filters).Error; err != nil {
+		return nil, err
+	}
+	return filters, nil
+}
+
+func (repository *savedFilterRepositoryImpl) FindByIdForUser(userId int, id int) (entities.SavedFilter, error) {
+	var filter entities.SavedFilter
+	if err := repository.visibleTo(userId).Where("saved_filters.id = ?", id).First(&filter).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.SavedFilter{}, ErrSavedFilterNotFound
+		}
+		return entities.SavedFilter{}, err
+	}
+	return filter, nil
+}
+
+func (repository *savedFilterRepositoryImpl) Save(filter entities.SavedFilter) (entities.SavedFilter, error) {
+	if err := repository.DB.Create(&filter).Error; err != nil {
+		return entities.SavedFilter{}, err
+	}
+	return filter, nil
+}
+
+func (repository *savedFilterRepositoryImpl) Update(id int, filter entities.SavedFilter) (entities.SavedFilter, error) {
+	result := repository.DB.Model(&entities.SavedFilter{Id: id}).Select("name", "filter").Updates(&filter)
+	if result.Error != nil {
+		return entities.SavedFilter{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.SavedFilter{}, ErrSavedFilterNotFound
+	}
+
+	var updated entities.SavedFilter
+	if err := repository.DB.First(&updated, id).Error; err != nil {
+		return entities.SavedFilter{}, err
+	}
+	return updated, nil
+}
+
+func (repository *savedFilterRepositoryImpl) Delete(id int) error {
+	result := repository.DB.Delete(&entities.SavedFilter{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrSavedFilterNotFound
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"errors"
+	"time"
+	"todolist-v1/modules/activity/entities"
+)
+
+var (
+	ErrTimeEntryNotFound = errors.New("time entry not found")
+	ErrNoRunningTimer    = errors.New("no timer is running")
+)
+
+type TimeEntryRepository interface {
+	FindAll(activityId int) ([]entities.TimeEntry, error)
+	FindById(activityId int, id int) (entities.TimeEntry, error)
+	FindRunning(userId int) (entities.TimeEntry, error)
+	// CountOverlapping counts the user's entries overlapping [start, end). A
+	// running timer lasts until it is stopped.
+	CountOverlapping(userId int, start time.Time, end time.Time) (int64, error)
+	Save(entry entities.TimeEntry) (entities.TimeEntry, error)
+	Stop(id int, endedAt time.Time) (entities.TimeEntry, error)
+	Delete(id int) error
+	// Summarize sums the user's finished entries started in [from, to) by
+	// the given dimensions, with days taken in zone. Entries of deleted
+	// activities still count.
+	Summarize(userId int, from time.Time, to time.Time, zone string, groupBy []string) ([]entities.TimesheetRow, error)
+	// Total sums the user's finished entries started in [from, to).
+	Total(userId int, from time.Time, to time.Time) (int64, error)
+	// Transaction runs fn with a repository bound to a single database
+	// transaction in which no other transaction changes the user's entries.
+	Transaction(userId int, fn func(entries TimeEntryRepository) error) error
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"todolist-v1/modules/activity/entities"
+
+	"gorm.io/gorm"
+)
+
+type timeEntryRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewTimeEntryRepository(db *gorm.DB) TimeEntryRepository {
+	return &timeEntryRepositoryImpl{DB: db}
+}
+
+// timesheetColumns maps the timesheet dimensions to the expressions they
+// group by.
+var timesheetColumns = map[string]string{
+	entities.TimesheetByDay:      "(time_entries.started_at AT TIME ZONE @zone)::date",
+	entities.TimesheetByCategory: "activities.category::text",
+	entities.TimesheetByTag:      "tags.tag",
+}
+
+const trackedSeconds = "COALESCE(SUM(EXTRACT(EPOCH FROM time_entries.ended_at - time_entries.started_at)), 0)::bigint"
+
+func (repository *timeEntryRepositoryImpl) FindAll(activityId int) ([]entities.TimeEntry, error) {
+	var entries []entities.TimeEntry
+	err := repository.DB.Where("activity_id = ?", activityId).Order("started_at, id").Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (repository *timeEntryRepositoryImpl) FindById(activityId int, id int) (entities.TimeEntry, error) {
+	var entry entities.TimeEntry
+	if err := repository.DB.Where("activity_id = ?", activityId).First(&entry, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.TimeEntry{}, ErrTimeEntryNotFound
+		}
+		return entities.TimeEntry{}, err
+	}
+	return entry, nil
+}
+
+func (repository *timeEntryRepositoryImpl) FindRunning(userId int) (entities.TimeEntry, error) {
+	var entry entities.TimeEntry
+	if err := repository.DB.Where("user_id = ? AND ended_at IS NULL", userId).First(&entry).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.TimeEntry{}, ErrNoRunningTimer
+		}
+		return entities.TimeEntry{}, err
+	}
+	return entry, nil
+}
+
+func (repository *timeEntryRepositoryImpl) CountOverlapping(userId int, start time.Time, end time.Time) (int64, error) {
+	var count int64
+	err := repository.DB.Model(&entities.TimeEntry{}).
+		Where("user_id = ? AND started_at < ? AND (ended_at IS NULL OR ended_at > ?)", userId, end, start).
+		Count(&count).Error
+	return count, err
+}
+
+func (repository *timeEntryRepositoryImpl) Save(entry entities.TimeEntry) (entities.TimeEntry, error) {
+	if err := repository.DB.Create(&entry).Error; err != nil {
+		return entities.TimeEntry{}, err
+	}
+	return entry, nil
+}
+
+func (repository *timeEntryRepositoryImpl) Stop(id int, endedAt time.Time) (entities.TimeEntry, error) {
+	result := repository.DB.Model(&entities.TimeEntry{}).
+		Where("id = ? AND ended_at IS NULL", id).
+		Update("ended_at", endedAt)
+	if result.Error != nil {
+		return entities.TimeEntry{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.TimeEntry{}, ErrNoRunningTimer
+	}
+
+	var entry entities.TimeEntry
+	if err := repository.DB.First(&entry, id).Error; err != nil {
+		return entities.TimeEntry{}, err
+	}
+	return entry, nil
+}
+
+func (repository *timeEntryRepositoryImpl) Delete(id int) error {
+	result := repository.DB.Delete(&entities.TimeEntry{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTimeEntryNotFound
+	}
+	return nil
+}
+
+func (repository *timeEntryRepositoryImpl) Summarize(userId int, from time.Time, to time.Time, zone string, groupBy []string) ([]entities.TimesheetRow, error) {
+	selects := []string{trackedSeconds + " AS seconds", "COUNT(*) AS entries"}
+	var groups []string
+	joins := ""
+	for _, dimension := range groupBy {
+		column := timesheetColumns[dimension]
+		selects = append(selects, column+" AS "+dimension)
+		groups = append(groups, column)
+		if dimension == entities.TimesheetByTag {
+			joins = " LEFT JOIN LATERAL unnest(activities.tags) AS tags(tag) ON true"
+		}
+	}
+	query := "SELECT " + strings.Join(selects, ", ") +
+		" FROM time_entries JOIN activities ON activities.id = time_entries.activity_id" + joins +
+		" WHERE time_entries.user_id = @user AND time_entries.ended_at IS NOT NULL" +
+		" AND time_entries.started_at >= @from AND time_entries.started_at < @to"
+	if len(groups) > 0 {
+		query += " GROUP BY " + strings.Join(groups, ", ") +
+			" ORDER BY " + strings.Join(groups, " NULLS LAST, ") + " NULLS LAST"
+	}
+
+	var rows []entities.TimesheetRow
+	err := repository.DB.Raw(query, map[string]any{
+		"user": userId,
+		"from": from,
+		"to":   to,
+		"zone": zone,
+	}).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (repository *timeEntryRepositoryImpl) Total(userId int, from time.Time, to time.Time) (int64, error) {
+	var total int64
+	err := repository.DB.Model(&entities.TimeEntry{}).
+		Select(trackedSeconds).
+		Where("user_id = ? AND ended_at IS NOT NULL AND started_at >= ? AND started_at < ?", userId, from, to).
+		Scan(&total).Error
+	return total, err
+}
+
+func (repository *timeEntryRepositoryImpl) Transaction(userId int, fn func(entries TimeEntryRepository) error) error {
+	return repository.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext('time_entries'), ?)", userId).Error; err != nil {
+			return err
+		}
+		return fn(&timeEntryRepositoryImpl{DB: tx})
+	})
+}
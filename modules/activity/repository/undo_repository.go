@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"errors"
+	"time"
+	"todolist-v1/modules/activity/entities"
+)
+
+var ErrUndoCommandNotFound = errors.New("undo command not found")
+
+// UndoRepository keeps, for each user, the stack of changes they may still
+// undo.
+type UndoRepository interface {
+	Push(command entities.UndoCommand) error
+	// FindLast returns the user's latest command pushed after since.
+	FindLast(userId int, since time.Time) (entities.UndoCommand, error)
+	Delete(userId int, id int) error
+	// Prune deletes the commands of every user pushed before since, and
+	// those of the user past the keep latest.
+	Prune(userId int, keep int, since time.Time) error
+}
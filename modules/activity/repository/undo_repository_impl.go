@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"errors"
+	"time"
+	"todolist-v1/modules/activity/entities"
+
+	"gorm.io/gorm"
+)
+
+type undoRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewUndoRepository(db *gorm.DB) UndoRepository {
+	return &undoRepositoryImpl{DB: db}
+}
+
+func (repository *undoRepositoryImpl) Push(command entities.UndoCommand) error {
+	return repository.DB.Create(&command).Error
+}
+
+func (repository *undoRepositoryImpl) FindLast(userId int, since time.Time) (entities.UndoCommand, error) {
+	var command entities.UndoCommand
+	err := repository.DB.
+		Where("user_id = ? AND pushed_at > ?", userId, since).
+		Order("id DESC").
+		First(&command).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.UndoCommand{}, ErrUndoCommandNotFound
+		}
+		return entities.UndoCommand{}, err
+	}
+	return command, nil
+}
+
+func (repository *undoRepositoryImpl) Delete(userId int, id int) error {
+	return repository.DB.Where("user_id = ?", userId).Delete(&entities.UndoCommand{}, id).Error
+}
+
+func (repository *undoRepositoryImpl) Prune(userId int, keep int, since time.Time) error {
+	latest := repository.DB.Model(&entities.UndoCommand{}).
+		Select("id").
+		Where("user_id = ?", userId).
+		Order("id DESC").
+		Limit(keep)
+	return repository.DB.
+		Where("pushed_at <= ?", since).
+		Or("user_id = ? AND id NOT IN (?)", userId, latest).
+		Delete(&entities.UndoCommand{}).Error
+}
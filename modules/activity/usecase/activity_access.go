@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"errors"
+	"todolist-v1/modules/activity/entities"
+	"todolist-v1/modules/activity/repository"
+	workspaceEntities "todolist-v1/modules/workspace/entities"
+	workspaceRepo "todolist-v1/modules/workspace/repository"
+)
+
+type accessLevel int
+
+const (
+	accessNone accessLevel = iota
+	// accessRead allows viewing the activity.
+	accessRead
+	// accessEdit allows changing the activity's fields.
+	accessEdit
+	// accessManage additionally allows deleting and sharing the activity.
+	accessManage
+)
+
+// activityAccess combines ownership, workspace roles and explicit shares
+// into the access a user has to an activity.
+type activityAccess struct {
+	activityRepository  repository.ActivityRepository
+	shareRepository     repository.ActivityShareRepository
+	workspaceRepository workspaceRepo.WorkspaceRepository
+}
+
+// load returns the activity if userId holds at least the required access.
+// Activities the user cannot see at all are reported as not found.
+func (access activityAccess) load(userId int, id int, required accessLevel) (entities.Activity, error) {
+	activity, err := access.activityRepository.FindById(userId, id)
+	if err != nil {
+		return entities.Activity{}, err
+	}
+	if err := access.check(userId, activity, required); err != nil {
+		return entities.Activity{}, err
+	}
+	return activity, nil
+}
+
+func (access activityAccess) check(userId int, activity entities.Activity, required accessLevel) error {
+	level, err := access.level(userId, activity)
+	if err != nil {
+		return err
+	}
+	if level == accessNone {
+		return repository.ErrActivityNotFound
+	}
+	if level < required {
+		return ErrForbidden
+	}
+	return nil
+}
+
+func (access activityAccess) level(userId int, activity entities.Activity) (accessLevel, error) {
+	level := accessNone
+
+	if activity.WorkspaceId == nil {
+		if activity.OwnerId == userId {
+			return accessManage, nil
+		}
+	} else {
+		member, err := access.workspaceRepository.FindMember(*activity.WorkspaceId, userId)
+		switch {
+		case err == nil && member.CanWriteActivities():
+			return accessManage, nil
+		case err == nil:
+			level = accessRead
+		case !errors.Is(err, workspaceRepo.ErrMemberNotFound):
+			return accessNone, err
+		}
+	}
+
+	share, err := access.shareRepository.FindShare(activity.Id, userId)
+	switch {
+	case err == nil && share.Permission == entities.SharePermissionEdit:
+		level = max(level, accessEdit)
+	case err == nil:
+		level = max(level, accessRead)
+	case !errors.Is(err, repository.ErrShareNotFound):
+		return accessNone, err
+	}
+
+	return level, nil
+}
+
+// workspaceMember returns the membership of userId, reporting non-members as
+// ErrWorkspaceNotFound.
+func (access activityAccess) workspaceMember(userId int, workspaceId int) (workspaceEntities.WorkspaceMember, error) {
+	member, err := access.workspaceRepository.FindMember(workspaceId, userId)
+	if errors.Is(err, workspaceRepo.ErrMemberNotFound) {
+		return workspaceEntities.WorkspaceMember{}, ErrWorkspaceNotFound
+	}
+	return member, err
+}
@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"errors"
+	"io"
+	"todolist-v1/modules/activity/entities"
+)
+
+var (
+	ErrAttachmentTooLarge  = errors.New("file exceeds the maximum attachment size")
+	ErrUnsupportedFileType = errors.New("file type is not allowed")
+)
+
+// ActivityAttachmentUsecase stores files attached to activities in the blob
+// store. Anyone who can see an activity may download its attachments;
+// uploading and deleting them needs edit access. The type of an upload is
+// detected from its content, not from the name or the declared type.
+type ActivityAttachmentUsecase interface {
+	GetAll(userId int, activityId int) ([]entities.ActivityAttachment, error)
+	Upload(userId int, activityId int, fileName string, content io.ReadSeeker, size int64) (entities.ActivityAttachment, error)
+	// Download returns the attachment with its content, which the caller
+	// must close.
+	Download(userId int, activityId int, id int) (entities.ActivityAttachment, io.ReadCloser, error)
+	Delete(userId int, activityId int, id int) error
+	// RemoveOrphans deletes the attachments left behind by purged activities.
+	RemoveOrphans() error
+}
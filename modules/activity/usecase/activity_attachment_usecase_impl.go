@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"todolist-v1/config"
+	"todolist-v1/modules/activity/entities"
+	"todolist-v1/modules/activity/repository"
+	workspaceRepo "todolist-v1/modules/workspace/repository"
+	"todolist-v1/pkg/storage"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+type activityAttachmentUsecaseImpl struct {
+	attachmentRepository repository.ActivityAttachmentRepository
+	store                storage.BlobStore
+	access               activityAccess
+	maxFileSize          int64
+	allowedTypes         []string
+}
+
+func NewActivityAttachmentUsecase(activityRepository repository.ActivityRepository, attachmentRepository repository.ActivityAttachmentRepository, shareRepository repository.ActivityShareRepository, workspaceRepository workspaceRepo.WorkspaceRepository, store storage.BlobStore, cfg *config.Config) ActivityAttachmentUsecase {
+	return &activityAttachmentUsecaseImpl{
+		attachmentRepository: attachmentRepository,
+		store:                store,
+		access: activityAccess{
+			activityRepository:  activityRepository,
+			shareRepository:     shareRepository,
+			workspaceRepository: workspaceRepository,
+		},
+		maxFileSize:  cfg.Storage.MaxFileSize,
+		allowedTypes: cfg.Storage.AllowedTypes,
+	}
+}
+
+func (usecase *activityAttachmentUsecaseImpl) GetAll(userId int, activityId int) ([]entities.ActivityAttachment, error) {
+	if _, err := usecase.access.load(userId, activityId, accessRead); err != nil {
+		return nil, err
+	}
+	return usecase.attachmentRepository.FindAll(activityId)
+}
+
+func (usecase *activityAttachmentUsecaseImpl) Upload(userId int, activityId int, fileName string, content io.ReadSeeker, size int64) (entities.ActivityAttachment, error) {
+	if _, err := usecase.access.load(userId, activityId, accessEdit); err != nil {
+		return entities.ActivityAttachment{}, err
+	}
+	if size > usecase.maxFileSize {
+		return entities.ActivityAttachment{}, ErrAttachmentTooLarge
+	}
+
+	detected, err := mimetype.DetectReader(content)
+	if err != nil {
+		return entities.ActivityAttachment{}, err
+	}
+	if !usecase.allowed(detected) {
+		return entities.ActivityAttachment{}, ErrUnsupportedFileType
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return entities.ActivityAttachment{}, err
+	}
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return entities.ActivityAttachment{}, err
+	}
+	key := fmt.Sprintf("activities/%d/%s", activityId, hex.EncodeToString(buf))
+
+	checksum := sha256.New()
+	if err := usecase.store.Put(key, io.TeeReader(content, checksum), size, detected.String()); err != nil {
+		return entities.ActivityAttachment{}, err
+	}
+
+	attachment, err := usecase.attachmentRepository.Save(entities.ActivityAttachment{
+		ActivityId:     &activityId,
+		UploadedBy:     &userId,
+		FileName:       cleanFileName(fileName),
+		ContentType:    detected.String(),
+		Size:           size,
+		ChecksumSha256: hex.EncodeToString(checksum.Sum(nil)),
+		StorageKey:     key,
+	})
+	if err != nil {
+		return entities.ActivityAttachment{}, errors.Join(err, usecase.store.Delete(key))
+	}
+	return attachment, nil
+}
+
+func (usecase *activityAttachmentUsecaseImpl) Download(userId int, activityId int, id int) (entities.ActivityAttachment, io.ReadCloser, error) {
+	if _, err := usecase.access.load(userId, activityId, accessRead); err != nil {
+		return entities.ActivityAttachment{}, nil, err
+	}
+
+	attachment, err := usecase.attachmentRepository.FindById(activityId, id)
+	if err != nil {
+		return entities.ActivityAttachment{}, nil, err
+	}
+	content, err := usecase.store.Get(attachment.StorageKey)
+	if err != nil {
+		return entities.ActivityAttachment{}, nil, err
+	}
+	return attachment, content, nil
+}
+
+func (usecase *activityAttachmentUsecaseImpl) Delete(userId int, activityId int, id int) error {
+	if _, err := usecase.access.load(userId, activityId, accessEdit); err != nil {
+		return err
+	}
+
+	attachment, err := usecase.attachmentRepository.Delete(activityId, id)
+	if err != nil {
+		return err
+	}
+	return usecase.store.Delete(attachment.StorageKey)
+}
+
+func (usecase *activityAttachmentUsecaseImpl) RemoveOrphans() error {
+	orphans, err := usecase.attachmentRepository.FindOrphans()
+	if err != nil {
+		return err
+	}
+
+	// The record is only removed once its blob is gone, so that a failed
+	// attempt is retried the next time.
+	for _, orphan := range orphans {
+		if err := usecase.store.Delete(orphan.StorageKey); err != nil {
+			return err
+		}
+		if err := usecase.attachmentRepository.DeleteOrphan(orphan.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (usecase *activityAttachmentUsecaseImpl) allowed(detected *mimetype.MIME) bool {
+	for _, allowedType := range usecase.allowedTypes {
+		if detected.Is(allowedType) {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanFileName strips any directory from a client-supplied file name and
+// limits its length to what the database accepts.
+func cleanFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == "/" {
+		name = "attachment"
+	}
+	if runes := []rune(name); len(runes) > 255 {
+		name = string(runes[:255])
+	}
+	return name
+}
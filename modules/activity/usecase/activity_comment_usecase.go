@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"errors"
+	"todolist-v1/modules/activity/entities"
+)
+
+var ErrNotCommentAuthor = errors.New("only the author can edit a comment")
+
+// ActivityCommentUsecase manages the comment thread of an activity. Anyone
+// who can see an activity may read and add comments; comments can be edited
+// by their author and deleted by their author or by whoever manages the
+// activity. Users mentioned as @email are resolved when they can see the
+// activity and ignored otherwise.
+type ActivityCommentUsecase interface {
+	GetAll(userId int, activityId int) ([]entities.ActivityComment, error)
+	Create(userId int, activityId int, body string) (entities.ActivityComment, error)
+	Update(userId int, activityId int, id int, body string) (entities.ActivityComment, error)
+	Delete(userId int, activityId int, id int) error
+}
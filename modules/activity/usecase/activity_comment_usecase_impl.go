@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"errors"
+	"todolist-v1/modules/activity/entities"
+	"todolist-v1/modules/activity/repository"
+	authRepo "todolist-v1/modules/auth/repository"
+	workspaceRepo "todolist-v1/modules/workspace/repository"
+	"todolist-v1/pkg/events"
+)
+
+type activityCommentUsecaseImpl struct {
+	commentRepository repository.ActivityCommentRepository
+	userRepository    authRepo.UserRepository
+	access            activityAccess
+	bus               events.Bus
+}
+
+func NewActivityCommentUsecase(activityRepository repository.ActivityRepository, commentRepository repository.ActivityCommentRepository, shareRepository repository.ActivityShareRepository, workspaceRepository workspaceRepo.WorkspaceRepository, userRepository authRepo.UserRepository, bus events.Bus) ActivityCommentUsecase {
+	return &activityCommentUsecaseImpl{
+		commentRepository: commentRepository,
+		userRepository:    userRepository,
+		access: activityAccess{
+			activityRepository:  activityRepository,
+			shareRepository:     shareRepository,
+			workspaceRepository: workspaceRepository,
+		},
+		bus: bus,
+	}
+}
+
+func (usecase *activityCommentUsecaseImpl) GetAll(userId int, activityId int) ([]entities.ActivityComment, error) {
+	if _, err := usecase.access.load(userId, activityId, accessRead); err != nil {
+		return nil, err
+	}
+	return usecase.commentRepository.FindAll(activityId)
+}
+
+func (usecase *activityCommentUsecaseImpl) Create(userId int, activityId int, body string) (entities.ActivityComment, error) {
+	activity, err := usecase.access.load(userId, activityId, accessRead)
+	if err != nil {
+		return entities.ActivityComment{}, err
+	}
+
+	mentions, err := usecase.resolveMentions(activity, body)
+	if err != nil {
+		return entities.ActivityComment{}, err
+	}
+
+	comment, err := usecase.commentRepository.Save(entities.ActivityComment{
+		ActivityId: activityId,
+		AuthorId:   userId,
+		Body:       body,
+		Mentions:   mentions,
+	})
+	if err != nil {
+		return entities.ActivityComment{}, err
+	}
+
+	usecase.publish(entities.EventCommentCreated, userId, comment)
+	return comment, nil
+}
+
+func (usecase *activityCommentUsecaseImpl) Update(userId int, activityId int, id int, body string) (entities.ActivityComment, error) {
+	activity, err := usecase.access.load(userId, activityId, accessRead)
+	if err != nil {
+		return entities.ActivityComment{}, err
+	}
+
+	comment, err := usecase.commentRepository.FindById(activityId, id)
+	if err != nil {
+		return entities.ActivityComment{}, err
+	}
+	if comment.AuthorId != userId {
+		return entities.ActivityComment{}, ErrNotCommentAuthor
+	}
+
+	if comment.Mentions, err = usecase.resolveMentions(activity, body); err != nil {
+		return entities.ActivityComment{}, err
+	}
+	comment.Body = body
+
+	updated, err := usecase.commentRepository.Update(comment)
+	if err != nil {
+		return entities.ActivityComment{}, err
+	}
+
+	usecase.publish(entities.EventCommentUpdated, userId, updated)
+	return updated, nil
+}
+
+func (usecase *activityCommentUsecaseImpl) Delete(userId int, activityId int, id int) error {
+	activity, err := usecase.access.load(userId, activityId, accessRead)
+	if err != nil {
+		return err
+	}
+
+	comment, err := usecase.commentRepository.FindById(activityId, id)
+	if err != nil {
+		return err
+	}
+	if comment.AuthorId != userId {
+		if err := usecase.access.check(userId, activity, accessManage); err != nil {
+			return err
+		}
+	}
+
+	if err := usecase.commentRepository.Delete(activityId, id); err != nil {
+		return err
+	}
+
+	usecase.publish(entities.EventCommentDeleted, userId, comment)
+	return nil
+}
+
+// resolveMentions returns the users mentioned in body who can see the
+// activity. Unknown addresses and users without access are skipped.
+func (usecase *activityCommentUsecaseImpl) resolveMentions(activity entities.Activity, body string) ([]entities.CommentMention, error) {
+	var mentions []entities.CommentMention
+	for _, email := range parseMentions(body) {
+		user, err := usecase.userRepository.FindByEmail(email)
+		if errors.Is(err, authRepo.ErrUserNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		level, err := usecase.access.level(user.Id, activity)
+		if err != nil {
+			return nil, err
+		}
+		if level == accessNone {
+			continue
+		}
+
+		mentions = append(mentions, entities.CommentMention{UserId: user.Id, Email: user.Email, Name: user.Name})
+	}
+	return mentions, nil
+}
+
+func (usecase *activityCommentUsecaseImpl) publish(name string, actorId int, comment entities.ActivityComment) {
+	usecase.bus.Publish(events.Event{
+		Name:       name,
+		ActorId:    actorId,
+		ActivityId: comment.ActivityId,
+		Payload:    comment,
+	})
+}
@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"errors"
+	"todolist-v1/modules/activity/entities"
+)
+
+var (
+	ErrBlockSelf       = errors.New("an activity cannot block itself")
+	ErrDependencyCycle = errors.New("the blocker already waits for this activity")
+)
+
+// ActivityDependencyUsecase manages which activities block which. Blocking
+// an activity needs edit access to it and read access to its blocker.
+// Dependencies never form a cycle.
+type ActivityDependencyUsecase interface {
+	GetBlockers(userId int, activityId int) ([]entities.Activity, error)
+	GetDependents(userId int, activityId int) ([]entities.Activity, error)
+	AddBlocker(userId int, activityId int, blockerId int) (entities.ActivityDependency, error)
+	RemoveBlocker(userId int, activityId int, blockerId int) error
+	// Next lists the user's open activities in an order they can be worked
+	// through: those that can start now first, then those only waiting for
+	// them, and so on, each group by priority.
+	Next(userId int) ([]entities.NextActivity, error)
+}
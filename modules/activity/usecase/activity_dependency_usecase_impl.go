@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"sort"
+	"todolist-v1/modules/activity/entities"
+	"todolist-v1/modules/activity/repository"
+	workspaceRepo "todolist-v1/modules/workspace/repository"
+)
+
+type activityDependencyUsecaseImpl struct {
+	activityRepository   repository.ActivityRepository
+	dependencyRepository repository.ActivityDependencyRepository
+	access               activityAccess
+}
+
+func NewActivityDependencyUsecase(activityRepository repository.ActivityRepository, dependencyRepository repository.ActivityDependencyRepository, shareRepository repository.ActivityShareRepository, workspaceRepository workspaceRepo.WorkspaceRepository) ActivityDependencyUsecase {
+	return &activityDependencyUsecaseImpl{
+		activityRepository:   activityRepository,
+		dependencyRepository: dependencyRepository,
+		access: activityAccess{
+			activityRepository:  activityRepository,
+			shareRepository:     shareRepository,
+			workspaceRepository: workspaceRepository,
+		},
+	}
+}
+
+func (usecase *activityDependencyUsecaseImpl) GetBlockers(userId int, activityId int) ([]entities.Activity, error) {
+	if _, err := usecase.access.load(userId, activityId, accessRead); err != nil {
+		return nil, err
+	}
+	return usecase.dependencyRepository.FindBlockers(userId, activityId)
+}
+
+func (usecase *activityDependencyUsecaseImpl) GetDependents(userId int, activityId int) ([]entities.Activity, error) {
+	if _, err := usecase.access.load(userId, activityId, accessRead); err != nil {
+		return nil, err
+	}
+	return usecase.dependencyRepository.FindDependents(userId, activityId)
+}
+
+func (usecase *activityDependencyUsecaseImpl) AddBlocker(userId int, activityId int, blockerId int) (entities.ActivityDependency, error) {
+	if activityId == blockerId {
+		return entities.ActivityDependency{}, ErrBlockSelf
+	}
+	if _, err := usecase.access.load(userId, activityId, accessEdit); err != nil {
+		return entities.ActivityDependency{}, err
+	}
+	if _, err := usecase.access.load(userId, blockerId, accessRead); err != nil {
+		return entities.ActivityDependency{}, err
+	}
+
+	var saved entities.ActivityDependency
+	err := usecase.dependencyRepository.Transaction(func(dependencies repository.ActivityDependencyRepository) error {
+		cycle, err := waitsFor(dependencies, blockerId, activityId)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return ErrDependencyCycle
+		}
+		saved, err = dependencies.Save(entities.ActivityDependency{
+			ActivityId: activityId,
+			BlockerId:  blockerId,
+			CreatedBy:  userId,
+		})
+		return err
+	})
+	if err != nil {
+		return entities.ActivityDependency{}, err
+	}
+	return saved, nil
+}
+
+func (usecase *activityDependencyUsecaseImpl) RemoveBlocker(userId int, activityId int, blockerId int) error {
+	if _, err := usecase.access.load(userId, activityId, accessEdit); err != nil {
+		return err
+	}
+	return usecase.dependencyRepository.Delete(activityId, blockerId)
+}
+
+func (usecase *activityDependencyUsecaseImpl) Next(userId int) ([]entities.NextActivity, error) {
+	activities, err := usecase.activityRepository.FindAll(userId, entities.ActivityFilter{
+		Statuses: entities.OpenStatuses,
+		Sort:     entities.SortPriority,
+	})
+	if err != nil {
+		return nil, err
+	}
+	graph, err := usecase.dependencyRepository.FindOpenGraph(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	blockers := make(map[int][]int)
+	for _, dependency := range graph {
+		blockers[dependency.ActivityId] = append(blockers[dependency.ActivityId], dependency.BlockerId)
+	}
+
+	// depth is computed depth-first with memoization. Dependencies are
+	// acyclic, but an activity met again on the current path is taken as
+	// unblocked rather than recursed into.
+	depths := make(map[int]int)
+	onPath := make(map[int]bool)
+	var depth func(id int) int
+	depth = func(id int) int {
+		if d, ok := depths[id]; ok {
+			return d
+		}
+		if onPath[id] {
+			return 0
+		}
+		onPath[id] = true
+		d := 0
+		for _, blocker := range blockers[id] {
+			d = max(d, depth(blocker)+1)
+		}
+		onPath[id] = false
+		depths[id] = d
+		return d
+	}
+
+	next := make([]entities.NextActivity, 0, len(activities))
+	for _, activity := range activities {
+		next = append(next, entities.NextActivity{
+			Activity:     activity,
+			Depth:        depth(activity.Id),
+			OpenBlockers: len(blockers[activity.Id]),
+		})
+	}
+	sort.SliceStable(next, func(i, j int) bool { return next[i].Depth < next[j].Depth })
+	return next, nil
+}
+
+// waitsFor reports whether from is blocked by to, directly or through other
+// activities, following the dependencies breadth first.
+func waitsFor(dependencies repository.ActivityDependencyRepository, from int, to int) (bool, error) {
+	seen := map[int]bool{from: true}
+	frontier := []int{from}
+	for len(frontier) > 0 {
+		found, err := dependencies.FindBlockedBy(frontier)
+		if err != nil {
+			return false, err
+		}
+		frontier = nil
+		for _, dependency := range found {
+			if dependency.BlockerId == to {
+				return true, nil
+			}
+			if !seen[dependency.BlockerId] {
+				seen[dependency.BlockerId] = true
+				frontier = append(frontier, dependency.BlockerId)
+			}
+		}
+	}
+	return false, nil
+}
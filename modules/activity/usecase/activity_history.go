@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"slices"
+	"time"
+	"todolist-v1/modules/activity/entities"
+	"todolist-v1/modules/activity/repository"
+	"todolist-v1/pkg/events"
+)
+
+var historyEvents = map[string]string{
+	entities.HistoryActionCreate:  entities.EventActivityCreated,
+	entities.HistoryActionUpdate:  entities.EventActivityUpdated,
+	entities.HistoryActionDelete:  entities.EventActivityDeleted,
+	entities.HistoryActionRestore: entities.EventActivityRestored,
+}
+
+// record appends a revision for a change made by actorId. before is nil
+// when the activity was just created.
+func record(history repository.ActivityHistoryRepository, actorId int, action string, before *entities.Activity, after entities.Activity) (entities.ActivityHistory, error) {
+	return history.Append(entities.ActivityHistory{
+		ActivityId: after.Id,
+		ActorId:    &actorId,
+		Action:     action,
+		Changes:    diffActivities(before, after),
+		Snapshot:   after,
+	})
+}
+
+// historyEvent is the event announcing a recorded revision.
+func historyEvent(entry entities.ActivityHistory) events.Event {
+	event := events.Event{
+		Name:       historyEvents[entry.Action],
+		ActivityId: entry.ActivityId,
+		Payload:    entry,
+		OccurredAt: entry.CreatedAt,
+	}
+	if entry.ActorId != nil {
+		event.ActorId = *entry.ActorId
+	}
+	return event
+}
+
+// diffActivities returns the user-editable fields that differ between
+// before and after, keyed by their JSON name. Every field counts as changed
+// when before is nil.
+func diffActivities(before *entities.Activity, after entities.Activity) map[string]entities.FieldChange {
+	changes := make(map[string]entities.FieldChange)
+	compare := func(field string, oldValue any, newValue any, equal bool) {
+		switch {
+		case before == nil:
+			changes[field] = entities.FieldChange{New: newValue}
+		case !equal:
+			changes[field] = entities.FieldChange{Old: oldValue, New: newValue}
+		}
+	}
+
+	var previous entities.Activity
+	if before != nil {
+		previous = *before
+	}
+	compare("title", previous.Title, after.Title, previous.Title == after.Title)
+	compare("category", previous.Category, after.Category, previous.Category == after.Category)
+	compare("description", previous.Description, after.Description, previous.Description == after.Description)
+	compare("activity_date", previous.ActivityDate, after.ActivityDate, previous.ActivityDate.Equal(after.ActivityDate))
+	compare("status", previous.Status, after.Status, previous.Status == after.Status)
+	compare("priority", previous.Priority, after.Priority, previous.Priority == after.Priority)
+	compare("start_at", previous.StartAt, after.StartAt, sameTime(previous.StartAt, after.StartAt))
+	compare("end_at", previous.EndAt, after.EndAt, sameTime(previous.EndAt, after.EndAt))
+	compare("due_at", previous.DueAt, after.DueAt, sameTime(previous.DueAt, after.DueAt))
+	compare("timezone", previous.Timezone, after.Timezone, sameValue(previous.Timezone, after.Timezone))
+	compare("all_day", previous.AllDay, after.AllDay, previous.AllDay == after.AllDay)
+	compare("local_date", previous.LocalDate, after.LocalDate, sameValue(previous.LocalDate, after.LocalDate))
+	compare("recurrence_frequency", previous.RecurrenceFrequency, after.RecurrenceFrequency, sameValue(previous.RecurrenceFrequency, after.RecurrenceFrequency))
+	compare("recurrence_interval", previous.RecurrenceInterval, after.RecurrenceInterval, previous.RecurrenceInterval == after.RecurrenceInterval)
+	compare("recurrence_until", previous.RecurrenceUntil, after.RecurrenceUntil, sameTime(previous.RecurrenceUntil, after.RecurrenceUntil))
+	compare("project_id", previous.ProjectId, after.ProjectId, sameValue(previous.ProjectId, after.ProjectId))
+	compare("tags", previous.Tags, after.Tags, slices.Equal(previous.Tags, after.Tags))
+	compare("archived", previous.Archived, after.Archived, previous.Archived == after.Archived)
+	compare("pinned", previous.Pinned, after.Pinned, previous.Pinned == after.Pinned)
+	return changes
+}
+
+// sameValue reports whether two optional values are both unset or equal.
+func sameValue[T comparable](a *T, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// sameTime reports whether two optional times are both unset or equal.
+func sameTime(a *time.Time, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+	"todolist-v1/modules/activity/entities"
+)
+
+var ErrShareWithSelf = errors.New("cannot share an activity with yourself")
+
+// ActivityShareUsecase manages per-user shares and public read-only links.
+// Only users who may delete an activity may share it.
+type ActivityShareUsecase interface {
+	GetShares(userId int, activityId int) ([]entities.ActivityShare, error)
+	Share(userId int, activityId int, email string, permission string) (entities.ActivityShare, error)
+	Unshare(userId int, activityId int, targetUserId int) error
+
+	GetLinks(userId int, activityId int) ([]entities.ActivityShareLink, error)
+	// CreateLink returns the stored link and the plain-text token that
+	// grants access to it; only a hash of the token is persisted.
+	CreateLink(userId int, activityId int, ttl time.Duration) (entities.ActivityShareLink, string, error)
+	RevokeLink(userId int, activityId int, linkId int) error
+	// GetByLinkToken resolves a public link without authentication.
+	GetByLinkToken(token string) (entities.Activity, error)
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+	"todolist-v1/modules/activity/entities"
+	"todolist-v1/modules/activity/repository"
+	authRepo "todolist-v1/modules/auth/repository"
+	workspaceRepo "todolist-v1/modules/workspace/repository"
+)
+
+type activityShareUsecaseImpl struct {
+	shareRepository repository.ActivityShareRepository
+	userRepository  authRepo.UserRepository
+	access          activityAccess
+}
+
+func NewActivityShareUsecase(activityRepository repository.ActivityRepository, shareRepository repository.ActivityShareRepository, workspaceRepository workspaceRepo.WorkspaceRepository, userRepository authRepo.UserRepository) ActivityShareUsecase {
+	return &activityShareUsecaseImpl{
+		shareRepository: shareRepository,
+		userRepository:  userRepository,
+		access: activityAccess{
+			activityRepository:  activityRepository,
+			shareRepository:     shareRepository,
+			workspaceRepository: workspaceRepository,
+		},
+	}
+}
+
+func (usecase *activityShareUsecaseImpl) GetShares(userId int, activityId int) ([]entities.ActivityShare, error) {
+	if _, err := usecase.access.load(userId, activityId, accessManage); err != nil {
+		return nil, err
+	}
+	return usecase.shareRepository.FindShares(activityId)
+}
+
+func (usecase *activityShareUsecaseImpl) Share(userId int, activityId int, email string, permission string) (entities.ActivityShare, error) {
+	if _, err := usecase.access.load(userId, activityId, accessManage); err != nil {
+		return entities.ActivityShare{}, err
+	}
+
+	target, err := usecase.userRepository.FindByEmail(email)
+	if err != nil {
+		return entities.ActivityShare{}, err
+	}
+	if target.Id == userId {
+		return entities.ActivityShare{}, ErrShareWithSelf
+	}
+
+	share, err := usecase.shareRepository.SaveShare(entities.ActivityShare{
+		ActivityId: activityId,
+		UserId:     target.Id,
+		Permission: permission,
+		CreatedBy:  userId,
+	})
+	if err != nil {
+		return entities.ActivityShare{}, err
+	}
+	share.Email = target.Email
+	share.Name = target.Name
+	return share, nil
+}
+
+func (usecase *activityShareUsecaseImpl) Unshare(userId int, activityId int, targetUserId int) error {
+	// Recipients may always drop a share that was given to them.
+	if targetUserId != userId {
+		if _, err := usecase.access.load(userId, activityId, accessManage); err != nil {
+			return err
+		}
+	}
+	return usecase.shareRepository.DeleteShare(activityId, targetUserId)
+}
+
+func (usecase *activityShareUsecaseImpl) GetLinks(userId int, activityId int) ([]entities.ActivityShareLink, error) {
+	if _, err := usecase.access.load(userId, activityId, accessManage); err != nil {
+		return nil, err
+	}
+	return usecase.shareRepository.FindActiveLinks(activityId)
+}
+
+func (usecase *activityShareUsecaseImpl) CreateLink(userId int, activityId int, ttl time.Duration) (entities.ActivityShareLink, string, error) {
+	if _, err := usecase.access.load(userId, activityId, accessManage); err != nil {
+		return entities.ActivityShareLink{}, "", err
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return entities.ActivityShareLink{}, "", err
+	}
+	token := hex.EncodeToString(buf)
+
+	link, err := usecase.shareRepository.SaveLink(entities.ActivityShareLink{
+		ActivityId: activityId,
+		TokenHash:  hashShareToken(token),
+		ExpiresAt:  time.Now().Add(ttl),
+		CreatedBy:  userId,
+	})
+	if err != nil {
+		return entities.ActivityShareLink{}, "", err
+	}
+	return link, token, nil
+}
+
+func (usecase *activityShareUsecaseImpl) RevokeLink(userId int, activityId int, linkId int) error {
+	if _, err := usecase.access.load(userId, activityId, accessManage); err != nil {
+		return err
+	}
+	return usecase.shareRepository.RevokeLink(activityId, linkId)
+}
+
+func (usecase *activityShareUsecaseImpl) GetByLinkToken(token string) (entities.Activity, error) {
+	return usecase.shareRepository.FindActivityByLinkHash(hashShareToken(token))
+}
+
+func hashShareToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
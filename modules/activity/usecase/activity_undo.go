@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+	"todolist-v1/modules/activity/entities"
+	"todolist-v1/modules/activity/repository"
+)
+
+var (
+	ErrNothingToUndo = errors.New("nothing to undo")
+	ErrModifiedSince = errors.New("the activity changed since, the change can no longer be undone")
+)
+
+// maxUndoCommands bounds the commands kept for each user; older ones are
+// dropped first.
+const maxUndoCommands = 20
+
+// commit announces the revisions recorded by one usecase call and keeps
+// them as a command the user may undo.
+func (usecase *activityUsecaseImpl) commit(userId int, entries ...entities.ActivityHistory) {
+	if len(entries) == 0 {
+		return
+	}
+	command := entities.UndoCommand{UserId: userId, PushedAt: time.Now()}
+	for _, entry := range entries {
+		usecase.bus.Publish(historyEvent(entry))
+		command.Revisions = append(command.Revisions, entities.RevisionRef{ActivityId: entry.ActivityId, Revision: entry.Revision})
+	}
+	// The change is made either way; failing to keep the command only
+	// means it cannot be undone.
+	if err := usecase.undoRepository.Push(command); err == nil {
+		usecase.undoRepository.Prune(userId, maxUndoCommands, command.PushedAt.Add(-usecase.undoWindow))
+	}
+}
+
+func (usecase *activityUsecaseImpl) Undo(userId int) ([]entities.Activity, map[int][]entities.ActivityOccurrence, error) {
+	command, err := usecase.undoRepository.FindLast(userId, time.Now().Add(-usecase.undoWindow))
+	if err != nil {
+		if errors.Is(err, repository.ErrUndoCommandNotFound) {
+			return nil, nil, ErrNothingToUndo
+		}
+		return nil, nil, err
+	}
+
+	var reverted []entities.Activity
+	conflicts := make(map[int][]entities.ActivityOccurrence)
+	var entries []entities.ActivityHistory
+	err = usecase.activityRepository.Transaction(func(activities repository.ActivityRepository, history repository.ActivityHistoryRepository) error {
+		for i := len(command.Revisions) - 1; i >= 0; i-- {
+			activity, entry, err := usecase.revert(activities, history, userId, command.Revisions[i])
+			if err != nil {
+				return err
+			}
+			entries = append(entries, entry)
+			if activity == nil {
+				continue
+			}
+			found, err := checkConflicts(activities, *activity, entities.ConflictsWarn)
+			if err != nil {
+				return err
+			}
+			if len(found) > 0 {
+				conflicts[activity.Id] = found
+			}
+			reverted = append(reverted, *activity)
+		}
+		return nil
+	})
+	// A command that cannot be undone now never will be. One left behind
+	// is refused as modified since by the next undo, and dropped then.
+	if err == nil || errors.Is(err, ErrModifiedSince) {
+		usecase.undoRepository.Delete(userId, command.Id)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Undoing is not itself a command that can be undone.
+	for _, entry := range entries {
+		usecase.bus.Publish(historyEvent(entry))
+	}
+	return reverted, conflicts, nil
+}
+
+// revert brings the activity back to the revision before ref, as long as
+// ref is still its latest revision. It returns the activity, or nil when it
+// ends up deleted.
+func (usecase *activityUsecaseImpl) revert(activities repository.ActivityRepository, history repository.ActivityHistoryRepository, userId int, ref entities.RevisionRef) (*entities.Activity, entities.ActivityHistory, error) {
+	current, err := activities.Lock(userId, ref.ActivityId)
+	if err != nil {
+		return nil, entities.ActivityHistory{}, err
+	}
+	latest, err := history.FindLatest(ref.ActivityId)
+	if err != nil {
+		return nil, entities.ActivityHistory{}, err
+	}
+	if latest.Revision != ref.Revision {
+		// Later revisions, such as those of undoing later commands, are
+		// fine as long as they left the activity as the command did.
+		undone, err := history.FindRevision(ref.ActivityId, ref.Revision)
+		if err != nil {
+			return nil, entities.ActivityHistory{}, err
+		}
+		if changedSince(undone, current) {
+			return nil, entities.ActivityHistory{}, ErrModifiedSince
+		}
+	}
+
+	// Undoing a creation deletes the activity.
+	if ref.Revision == 1 {
+		if err := usecase.access.check(userId, current, accessManage); err != nil {
+			return nil, entities.ActivityHistory{}, err
+		}
+		if err := activities.Delete(userId, ref.ActivityId); err != nil {
+			return nil, entities.ActivityHistory{}, err
+		}
+		entry, err := record(history, userId, entities.HistoryActionDelete, &current, current)
+		return nil, entry, err
+	}
+
+	previous, err := history.FindRevision(ref.ActivityId, ref.Revision-1)
+	if err != nil {
+		return nil, entities.ActivityHistory{}, err
+	}
+	// Deleting or undeleting needs the access that deleting does.
+	deleted := previous.Action == entities.HistoryActionDelete
+	required := accessEdit
+	if deleted || current.DeletedAt.Valid {
+		required = accessManage
+	}
+	if err := usecase.access.check(userId, current, required); err != nil {
+		return nil, entities.ActivityHistory{}, err
+	}
+
+	snapshot := previous.Snapshot
+	if err := normalizeSchedule(&snapshot); err != nil {
+		return nil, entities.ActivityHistory{}, err
+	}
+	if snapshot.Tags, err = normalizeTags(snapshot.Tags); err != nil {
+		return nil, entities.ActivityHistory{}, err
+	}
+	if err := checkStart(activities, current, snapshot.Status); err != nil {
+		return nil, entities.ActivityHistory{}, err
+	}
+	reverted, err := activities.Revert(userId, ref.ActivityId, snapshot)
+	if err != nil {
+		return nil, entities.ActivityHistory{}, err
+	}
+	if deleted {
+		if err := activities.Delete(userId, ref.ActivityId); err != nil {
+			return nil, entities.ActivityHistory{}, err
+		}
+		entry, err := record(history, userId, entities.HistoryActionDelete, &current, reverted)
+		return nil, entry, err
+	}
+	entry, err := record(history, userId, entities.HistoryActionRestore, &current, reverted)
+	return &reverted, entry, err
+}
+
+// changedSince reports whether the activity differs from the revision.
+func changedSince(entry entities.ActivityHistory, current entities.Activity) bool {
+	if current.DeletedAt.Valid != (entry.Action == entities.HistoryActionDelete) {
+		return true
+	}
+	return len(diffActivities(&entry.Snapshot, current)) > 0
+}
@@ -1,10 +1,64 @@
 package usecase
 
-import "todolist-v1/modules/activity/entities"
+import (
+	"errors"
+	"todolist-v1/modules/activity/entities"
+)
 
+var (
+	ErrWorkspaceNotFound = errors.New("workspace not found")
+	ErrForbidden         = errors.New("insufficient permission for this activity")
+)
+
+// ActivityUsecase operates on behalf of the user identified by userId. It
+// sees the user's personal activities, those of their workspaces and those
+// shared with them, and checks the user's access before any change: editing
+// needs a writing workspace role or an edit share, deleting needs ownership
+// or a writing workspace role. Every change is recorded as a revision in the
+// activity's history and published as an event once committed, and the
+// user who made it may undo it for a short while. Dates are
+// checked against the activity's category: events span StartAt to EndAt,
+// tasks are due at DueAt. Timed events are checked for overlaps with the
+// other events of their owner, which are returned or, depending on the
+// policy, fail the write with a ConflictError. Changes that move events
+// without a policy, such as snoozing or undoing, only return them; bulk
+// changes return them by activity id.
 type ActivityUsecase interface {
-	GetAll() ([]entities.Activity, error)
-	Create(activity entities.Activity) (entities.Activity, error)
-	Update(id int, activity entities.Activity) (entities.Activity, error)
-	Delete(id int) error
+	GetAll(userId int, filter entities.ActivityFilter) ([]entities.Activity, error)
+	Create(userId int, activity entities.Activity, policy entities.ConflictPolicy) (entities.Activity, []entities.ActivityOccurrence, error)
+	// CreateWithChildren creates an activity and child activities pointing to
+	// it, all or none of them. Children share the workspace of their parent
+	// but are left out of its project. Only the parent is checked for
+	// conflicts.
+	CreateWithChildren(userId int, activity entities.Activity, children []entities.Activity, policy entities.ConflictPolicy) (entities.Activity, []entities.Activity, []entities.ActivityOccurrence, error)
+	Update(userId int, id int, update entities.ActivityUpdate, policy entities.ConflictPolicy) (entities.Activity, []entities.ActivityOccurrence, error)
+	Delete(userId int, id int) error
+	GetHistory(userId int, id int) ([]entities.ActivityHistory, error)
+	// RestoreRevision resets the activity to its state at the given revision,
+	// undeleting it if needed.
+	RestoreRevision(userId int, id int, revision int) (entities.Activity, []entities.ActivityOccurrence, error)
+	// Snooze moves an activity to a preset time or by a duration from now.
+	Snooze(userId int, id int, snooze entities.Snooze) (entities.Activity, []entities.ActivityOccurrence, error)
+	// RescheduleOverdue shifts every overdue activity the user may edit by
+	// the given number of days, limited to one workspace when workspaceId is
+	// set, and returns the moved activities.
+	RescheduleOverdue(userId int, days int, workspaceId *int) ([]entities.Activity, map[int][]entities.ActivityOccurrence, error)
+	// Move puts the activity in a column of a project's board, ranked between
+	// the given neighbours, changing its status and position at once. An
+	// activity created in a project starts at the bottom of its NEW column.
+	Move(userId int, id int, move entities.Move) (entities.Activity, error)
+	// Archive archives or unarchives the activity and Pin pins or unpins
+	// it. Both need the access editing it does.
+	Archive(userId int, id int, archived bool) (entities.Activity, error)
+	Pin(userId int, id int, pinned bool) (entities.Activity, error)
+	// ArchiveMatching archives every unarchived activity matching the filter
+	// that the user may edit, and returns them.
+	ArchiveMatching(userId int, filter entities.ActivityFilter) ([]entities.Activity, error)
+	// Undo reverts the user's latest change that can still be undone, in
+	// one transaction, and returns the activities it brought back. It fails
+	// with ErrModifiedSince when an activity changed after the change.
+	Undo(userId int) ([]entities.Activity, map[int][]entities.ActivityOccurrence, error)
+	// Purge removes an activity and its history for good. It needs the same
+	// access as deleting it.
+	Purge(userId int, id int) error
 }
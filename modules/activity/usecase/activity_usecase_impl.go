@@ -1,31 +1,637 @@
 package usecase
 
 import (
+	"errors"
+	"time"
+	"todolist-v1/config"
 	"todolist-v1/modules/activity/entities"
 	"todolist-v1/modules/activity/repository"
+	projectRepo "todolist-v1/modules/project/repository"
+	workspaceRepo "todolist-v1/modules/workspace/repository"
+	"todolist-v1/pkg/date"
+	"todolist-v1/pkg/events"
 )
 
 type activityUsecaseImpl struct {
 	activityRepository repository.ActivityRepository
+	historyRepository  repository.ActivityHistoryRepository
+	projectRepository  projectRepo.ProjectRepository
+	access             activityAccess
+	bus                events.Bus
+	undoRepository     repository.UndoRepository
+	undoWindow         time.Duration
 }
 
-func NewActivityUsecase(activityRepository repository.ActivityRepository) ActivityUsecase {
-	return &activityUsecaseImpl{activityRepository}
+func NewActivityUsecase(activityRepository repository.ActivityRepository, historyRepository repository.ActivityHistoryRepository, undoRepository repository.UndoRepository, shareRepository repository.ActivityShareRepository, workspaceRepository workspaceRepo.WorkspaceRepository, projectRepository projectRepo.ProjectRepository, bus events.Bus, cfg *config.Config) ActivityUsecase {
+	return &activityUsecaseImpl{
+		activityRepository: activityRepository,
+		historyRepository:  historyRepository,
+		projectRepository:  projectRepository,
+		access: activityAccess{
+			activityRepository:  activityRepository,
+			shareRepository:     shareRepository,
+			workspaceRepository: workspaceRepository,
+		},
+		bus:            bus,
+		undoRepository: undoRepository,
+		undoWindow:     cfg.Undo.Window,
+	}
 }
 
-func (usecase *activityUsecaseImpl) GetAll() ([]entities.Activity, error) {
-	return usecase.activityRepository.FindAll()
+func (usecase *activityUsecaseImpl) GetAll(userId int, filter entities.ActivityFilter) ([]entities.Activity, error) {
+	if filter.WorkspaceId != nil {
+		if _, err := usecase.access.workspaceMember(userId, *filter.WorkspaceId); err != nil {
+			return nil, err
+		}
+	}
+	return usecase.activityRepository.FindAll(userId, filter)
 }
 
-func (usecase *activityUsecaseImpl) Create(activity entities.Activity) (entities.Activity, error) {
-	activity.Status = "NEW"
-	return usecase.activityRepository.Save(activity)
+func (usecase *activityUsecaseImpl) Create(userId int, activity entities.Activity, policy entities.ConflictPolicy) (entities.Activity, []entities.ActivityOccurrence, error) {
+	if err := usecase.prepare(userId, &activity); err != nil {
+		return entities.Activity{}, nil, err
+	}
+
+	var created entities.Activity
+	var conflicts []entities.ActivityOccurrence
+	var entry entities.ActivityHistory
+	err := usecase.activityRepository.Transaction(func(activities repository.ActivityRepository, history repository.ActivityHistoryRepository) error {
+		var err error
+		if created, entry, err = insert(activities, history, userId, activity); err != nil {
+			return err
+		}
+		conflicts, err = checkConflicts(activities, created, policy)
+		return err
+	})
+	if err != nil {
+		return entities.Activity{}, nil, err
+	}
+
+	usecase.commit(userId, entry)
+	return created, conflicts, nil
+}
+
+func (usecase *activityUsecaseImpl) CreateWithChildren(userId int, activity entities.Activity, children []entities.Activity, policy entities.ConflictPolicy) (entities.Activity, []entities.Activity, []entities.ActivityOccurrence, error) {
+	if err := usecase.prepare(userId, &activity); err != nil {
+		return entities.Activity{}, nil, nil, err
+	}
+	for i := range children {
+		children[i].WorkspaceId = activity.WorkspaceId
+		children[i].ProjectId = nil
+		if err := usecase.prepare(userId, &children[i]); err != nil {
+			return entities.Activity{}, nil, nil, err
+		}
+	}
+
+	var created entities.Activity
+	var createdChildren []entities.Activity
+	var conflicts []entities.ActivityOccurrence
+	var entries []entities.ActivityHistory
+	err := usecase.activityRepository.Transaction(func(activities repository.ActivityRepository, history repository.ActivityHistoryRepository) error {
+		parent, entry, err := insert(activities, history, userId, activity)
+		if err != nil {
+			return err
+		}
+		created = parent
+		entries = append(entries, entry)
+		if conflicts, err = checkConflicts(activities, created, policy); err != nil {
+			return err
+		}
+		for _, child := range children {
+			child.ParentId = &created.Id
+			createdChild, entry, err := insert(activities, history, userId, child)
+			if err != nil {
+				return err
+			}
+			createdChildren = append(createdChildren, createdChild)
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return entities.Activity{}, nil, nil, err
+	}
+
+	usecase.commit(userId, entries...)
+	return created, createdChildren, conflicts, nil
+}
+
+// prepare checks a new activity and fills in what the user does not choose:
+// its owner, status and board position.
+func (usecase *activityUsecaseImpl) prepare(userId int, activity *entities.Activity) error {
+	if err := normalizeSchedule(activity); err != nil {
+		return err
+	}
+	tags, err := normalizeTags(activity.Tags)
+	if err != nil {
+		return err
+	}
+	activity.Tags = tags
+	if activity.WorkspaceId != nil {
+		member, err := usecase.access.workspaceMember(userId, *activity.WorkspaceId)
+		if err != nil {
+			return err
+		}
+		if !member.CanWriteActivities() {
+			return ErrForbidden
+		}
+	}
+
+	activity.OwnerId = userId
+	activity.Status = entities.StatusNew
+	activity.Rank = nil
+	if activity.ProjectId != nil {
+		project, err := usecase.projectRepository.FindByIdForUser(userId, *activity.ProjectId)
+		if err != nil {
+			return err
+		}
+		if err := checkProject(project, *activity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insert saves a prepared activity, at the bottom of the NEW column of its
+// project if it has one, and records its creation.
+func insert(activities repository.ActivityRepository, history repository.ActivityHistoryRepository, userId int, activity entities.Activity) (entities.Activity, entities.ActivityHistory, error) {
+	if activity.ProjectId != nil {
+		if err := activities.LockBoard(*activity.ProjectId); err != nil {
+			return entities.Activity{}, entities.ActivityHistory{}, err
+		}
+		rank, err := positionRank(activities, userId, 0, *activity.ProjectId, activity.Status, nil, nil)
+		if err != nil {
+			return entities.Activity{}, entities.ActivityHistory{}, err
+		}
+		activity.Rank = &rank
+	}
+
+	created, err := activities.Save(activity)
+	if err != nil {
+		return entities.Activity{}, entities.ActivityHistory{}, err
+	}
+	entry, err := record(history, userId, entities.HistoryActionCreate, nil, created)
+	if err != nil {
+		return entities.Activity{}, entities.ActivityHistory{}, err
+	}
+	return created, entry, nil
+}
+
+func (usecase *activityUsecaseImpl) Update(userId int, id int, update entities.ActivityUpdate, policy entities.ConflictPolicy) (entities.Activity, []entities.ActivityOccurrence, error) {
+	current, err := usecase.access.load(userId, id, accessEdit)
+	if err != nil {
+		return entities.Activity{}, nil, err
+	}
+	// Clients unaware of priorities, tags or schedules keep the current
+	// ones.
+	activity := update.Activity
+	if activity.Priority == "" {
+		activity.Priority = current.Priority
+	}
+	if activity.Tags == nil {
+		activity.Tags = current.Tags
+	}
+	if activity.Timezone == nil {
+		activity.Timezone = current.Timezone
+	}
+	activity.AllDay = current.AllDay
+	if update.AllDay != nil {
+		activity.AllDay = *update.AllDay
+	}
+	if activity.AllDay && activity.Timezone == nil && update.Location != nil {
+		zone := update.Location.String()
+		activity.Timezone = &zone
+	}
+	if err := normalizeSchedule(&activity); err != nil {
+		return entities.Activity{}, nil, err
+	}
+	if activity.Tags, err = normalizeTags(activity.Tags); err != nil {
+		return entities.Activity{}, nil, err
+	}
+
+	var updated entities.Activity
+	var conflicts []entities.ActivityOccurrence
+	var entry *entities.ActivityHistory
+	err = usecase.activityRepository.Transaction(func(activities repository.ActivityRepository, history repository.ActivityHistoryRepository) error {
+		before, err := activities.Lock(userId, id)
+		if err != nil {
+			return err
+		}
+		if err := checkStart(activities, before, activity.Status); err != nil {
+			return err
+		}
+		if updated, err = activities.Update(userId, id, activity); err != nil {
+			return err
+		}
+		if conflicts, err = checkConflicts(activities, updated, policy); err != nil {
+			return err
+		}
+		// Saving an unchanged activity does not add a revision.
+		if len(diffActivities(&before, updated)) == 0 {
+			return nil
+		}
+		recorded, err := record(history, userId, entities.HistoryActionUpdate, &before, updated)
+		entry = &recorded
+		return err
+	})
+	if err != nil {
+		return entities.Activity{}, nil, err
+	}
+
+	if entry != nil {
+		usecase.commit(userId, *entry)
+	}
+	return updated, conflicts, nil
+}
+
+func (usecase *activityUsecaseImpl) Delete(userId int, id int) error {
+	if _, err := usecase.access.load(userId, id, accessManage); err != nil {
+		return err
+	}
+
+	var entry entities.ActivityHistory
+	err := usecase.activityRepository.Transaction(func(activities repository.ActivityRepository, history repository.ActivityHistoryRepository) error {
+		before, err := activities.Lock(userId, id)
+		if err != nil {
+			return err
+		}
+		if err := activities.Delete(userId, id); err != nil {
+			return err
+		}
+		entry, err = record(history, userId, entities.HistoryActionDelete, &before, before)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	usecase.commit(userId, entry)
+	return nil
+}
+
+func (usecase *activityUsecaseImpl) GetHistory(userId int, id int) ([]entities.ActivityHistory, error) {
+	activity, err := usecase.activityRepository.FindByIdWithDeleted(userId, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := usecase.access.check(userId, activity, accessRead); err != nil {
+		return nil, err
+	}
+	return usecase.historyRepository.FindAll(id)
 }
 
-func (usecase *activityUsecaseImpl) Update(id int, activity entities.Activity) (entities.Activity, error) {
-	return usecase.activityRepository.Update(id, activity)
+func (usecase *activityUsecaseImpl) RestoreRevision(userId int, id int, revision int) (entities.Activity, []entities.ActivityOccurrence, error) {
+	activity, err := usecase.activityRepository.FindByIdWithDeleted(userId, id)
+	if err != nil {
+		return entities.Activity{}, nil, err
+	}
+	// Bringing back a deleted activity needs the access that deleting it did.
+	required := accessEdit
+	if activity.DeletedAt.Valid {
+		required = accessManage
+	}
+	if err := usecase.access.check(userId, activity, required); err != nil {
+		return entities.Activity{}, nil, err
+	}
+
+	entry, err := usecase.historyRepository.FindRevision(id, revision)
+	if err != nil {
+		return entities.Activity{}, nil, err
+	}
+	// Revisions from before priorities, schedules and tags existed get the
+	// defaults.
+	snapshot := entry.Snapshot
+	if err := normalizeSchedule(&snapshot); err != nil {
+		return entities.Activity{}, nil, err
+	}
+	if snapshot.Tags, err = normalizeTags(snapshot.Tags); err != nil {
+		return entities.Activity{}, nil, err
+	}
+
+	var restored entities.Activity
+	var conflicts []entities.ActivityOccurrence
+	var restoredEntry entities.ActivityHistory
+	err = usecase.activityRepository.Transaction(func(activities repository.ActivityRepository, history repository.ActivityHistoryRepository) error {
+		before, err := activities.Lock(userId, id)
+		if err != nil {
+			return err
+		}
+		if err := checkStart(activities, before, snapshot.Status); err != nil {
+			return err
+		}
+		if restored, err = activities.Restore(userId, id, snapshot); err != nil {
+			return err
+		}
+		if conflicts, err = checkConflicts(activities, restored, entities.ConflictsWarn); err != nil {
+			return err
+		}
+		restoredEntry, err = record(history, userId, entities.HistoryActionRestore, &before, restored)
+		return err
+	})
+	if err != nil {
+		return entities.Activity{}, nil, err
+	}
+
+	usecase.commit(userId, restoredEntry)
+	return restored, conflicts, nil
 }
 
-func (usecase *activityUsecaseImpl) Delete(id int) error {
-	return usecase.activityRepository.Delete(id)
+func (usecase *activityUsecaseImpl) Snooze(userId int, id int, snooze entities.Snooze) (entities.Activity, []entities.ActivityOccurrence, error) {
+	location := snooze.Location
+	if location == nil {
+		location = time.UTC
+	}
+	until, err := snoozeUntil(time.Now().In(location), snooze)
+	if err != nil {
+		return entities.Activity{}, nil, err
+	}
+	if _, err := usecase.access.load(userId, id, accessEdit); err != nil {
+		return entities.Activity{}, nil, err
+	}
+
+	var snoozed entities.Activity
+	var conflicts []entities.ActivityOccurrence
+	var entry entities.ActivityHistory
+	err = usecase.activityRepository.Transaction(func(activities repository.ActivityRepository, history repository.ActivityHistoryRepository) error {
+		var err error
+		if snoozed, entry, err = reschedule(activities, history, userId, id, func(entities.Activity) time.Time { return until }); err != nil {
+			return err
+		}
+		conflicts, err = checkConflicts(activities, snoozed, entities.ConflictsWarn)
+		return err
+	})
+	if err != nil {
+		return entities.Activity{}, nil, err
+	}
+
+	usecase.commit(userId, entry)
+	return snoozed, conflicts, nil
+}
+
+func (usecase *activityUsecaseImpl) RescheduleOverdue(userId int, days int, workspaceId *int) ([]entities.Activity, map[int][]entities.ActivityOccurrence, error) {
+	if workspaceId != nil {
+		if _, err := usecase.access.workspaceMember(userId, *workspaceId); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	overdue, err := usecase.activityRepository.FindOverdue(userId, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	var ids []int
+	for _, activity := range overdue {
+		if workspaceId != nil && (activity.WorkspaceId == nil || *activity.WorkspaceId != *workspaceId) {
+			continue
+		}
+		// Activities the user may only read are left where they are.
+		if err := usecase.access.check(userId, activity, accessEdit); err != nil {
+			if errors.Is(err, ErrForbidden) {
+				continue
+			}
+			return nil, nil, err
+		}
+		ids = append(ids, activity.Id)
+	}
+
+	rescheduled := make([]entities.Activity, 0, len(ids))
+	conflicts := make(map[int][]entities.ActivityOccurrence)
+	entries := make([]entities.ActivityHistory, 0, len(ids))
+	err = usecase.activityRepository.Transaction(func(activities repository.ActivityRepository, history repository.ActivityHistoryRepository) error {
+		for _, id := range ids {
+			moved, entry, err := reschedule(activities, history, userId, id, func(before entities.Activity) time.Time {
+				return before.ActivityDate.AddDate(0, 0, days)
+			})
+			if err != nil {
+				return err
+			}
+			found, err := checkConflicts(activities, moved, entities.ConflictsWarn)
+			if err != nil {
+				return err
+			}
+			if len(found) > 0 {
+				conflicts[moved.Id] = found
+			}
+			rescheduled = append(rescheduled, moved)
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	usecase.commit(userId, entries...)
+	return rescheduled, conflicts, nil
+}
+
+// reschedule moves a locked activity to the date computed from its current
+// state and records the change.
+func reschedule(activities repository.ActivityRepository, history repository.ActivityHistoryRepository, userId int, id int, moveTo func(before entities.Activity) time.Time) (entities.Activity, entities.ActivityHistory, error) {
+	before, err := activities.Lock(userId, id)
+	if err != nil {
+		return entities.Activity{}, entities.ActivityHistory{}, err
+	}
+	target := moveTo(before)
+	// All-day activities stay at the start of a day.
+	if before.AllDay {
+		loc := location(before)
+		target = date.Of(target.In(loc)).In(loc)
+	}
+	moved, err := activities.Reschedule(userId, id, target)
+	if err != nil {
+		return entities.Activity{}, entities.ActivityHistory{}, err
+	}
+	entry, err := record(history, userId, entities.HistoryActionUpdate, &before, moved)
+	if err != nil {
+		return entities.Activity{}, entities.ActivityHistory{}, err
+	}
+	return moved, entry, nil
+}
+
+func (usecase *activityUsecaseImpl) Move(userId int, id int, move entities.Move) (entities.Activity, error) {
+	current, err := usecase.access.load(userId, id, accessEdit)
+	if err != nil {
+		return entities.Activity{}, err
+	}
+	projectId := move.ProjectId
+	if projectId == nil {
+		projectId = current.ProjectId
+	}
+	if projectId == nil {
+		return entities.Activity{}, ErrNoProject
+	}
+	project, err := usecase.projectRepository.FindByIdForUser(userId, *projectId)
+	if err != nil {
+		return entities.Activity{}, err
+	}
+	if err := checkProject(project, current); err != nil {
+		return entities.Activity{}, err
+	}
+
+	var moved entities.Activity
+	var entry *entities.ActivityHistory
+	err = usecase.activityRepository.Transaction(func(activities repository.ActivityRepository, history repository.ActivityHistoryRepository) error {
+		if err := activities.LockBoard(project.Id); err != nil {
+			return err
+		}
+		before, err := activities.Lock(userId, id)
+		if err != nil {
+			return err
+		}
+		if err := checkStart(activities, before, move.Status); err != nil {
+			return err
+		}
+		rank, err := positionRank(activities, userId, id, project.Id, move.Status, move.AfterId, move.BeforeId)
+		if err != nil {
+			return err
+		}
+		if moved, err = activities.Move(userId, id, move.Status, project.Id, rank); err != nil {
+			return err
+		}
+		// Reordering within a column does not add a revision.
+		if len(diffActivities(&before, moved)) == 0 {
+			return nil
+		}
+		recorded, err := record(history, userId, entities.HistoryActionUpdate, &before, moved)
+		entry = &recorded
+		return err
+	})
+	if err != nil {
+		return entities.Activity{}, err
+	}
+
+	if entry != nil {
+		usecase.commit(userId, *entry)
+	}
+	return moved, nil
+}
+
+func (usecase *activityUsecaseImpl) Archive(userId int, id int, archived bool) (entities.Activity, error) {
+	return usecase.setFlag(userId, id, func(activities repository.ActivityRepository) (entities.Activity, error) {
+		return activities.Archive(userId, id, archived)
+	})
+}
+
+func (usecase *activityUsecaseImpl) Pin(userId int, id int, pinned bool) (entities.Activity, error) {
+	return usecase.setFlag(userId, id, func(activities repository.ActivityRepository) (entities.Activity, error) {
+		return activities.Pin(userId, id, pinned)
+	})
+}
+
+// setFlag applies a change of the archived or pinned flag. Setting a flag
+// the activity already has does not add a revision.
+func (usecase *activityUsecaseImpl) setFlag(userId int, id int, apply func(activities repository.ActivityRepository) (entities.Activity, error)) (entities.Activity, error) {
+	if _, err := usecase.access.load(userId, id, accessEdit); err != nil {
+		return entities.Activity{}, err
+	}
+
+	var updated entities.Activity
+	var entry *entities.ActivityHistory
+	err := usecase.activityRepository.Transaction(func(activities repository.ActivityRepository, history repository.ActivityHistoryRepository) error {
+		before, err := activities.Lock(userId, id)
+		if err != nil {
+			return err
+		}
+		if updated, err = apply(activities); err != nil {
+			return err
+		}
+		if len(diffActivities(&before, updated)) == 0 {
+			return nil
+		}
+		recorded, err := record(history, userId, entities.HistoryActionUpdate, &before, updated)
+		entry = &recorded
+		return err
+	})
+	if err != nil {
+		return entities.Activity{}, err
+	}
+
+	if entry != nil {
+		usecase.commit(userId, *entry)
+	}
+	return updated, nil
+}
+
+func (usecase *activityUsecaseImpl) ArchiveMatching(userId int, filter entities.ActivityFilter) ([]entities.Activity, error) {
+	if filter.WorkspaceId != nil {
+		if _, err := usecase.access.workspaceMember(userId, *filter.WorkspaceId); err != nil {
+			return nil, err
+		}
+	}
+
+	filter.Archived = false
+	matching, err := usecase.activityRepository.FindAll(userId, filter)
+	if err != nil {
+		return nil, err
+	}
+	var ids []int
+	for _, activity := range matching {
+		// Activities the user may only read are left as they are.
+		if err := usecase.access.check(userId, activity, accessEdit); err != nil {
+			if errors.Is(err, ErrForbidden) {
+				continue
+			}
+			return nil, err
+		}
+		ids = append(ids, activity.Id)
+	}
+
+	archived := make([]entities.Activity, 0, len(ids))
+	entries := make([]entities.ActivityHistory, 0, len(ids))
+	err = usecase.activityRepository.Transaction(func(activities repository.ActivityRepository, history repository.ActivityHistoryRepository) error {
+		for _, id := range ids {
+			// Activities purged, deleted or archived since they were
+			// listed are left as they are.
+			before, err := activities.Lock(userId, id)
+			if errors.Is(err, repository.ErrActivityNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if before.DeletedAt.Valid || before.Archived {
+				continue
+			}
+			after, err := activities.Archive(userId, id, true)
+			if err != nil {
+				return err
+			}
+			entry, err := record(history, userId, entities.HistoryActionUpdate, &before, after)
+			if err != nil {
+				return err
+			}
+			archived = append(archived, after)
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	usecase.commit(userId, entries...)
+	return archived, nil
+}
+
+func (usecase *activityUsecaseImpl) Purge(userId int, id int) error {
+	activity, err := usecase.activityRepository.FindByIdWithDeleted(userId, id)
+	if err != nil {
+		return err
+	}
+	if err := usecase.access.check(userId, activity, accessManage); err != nil {
+		return err
+	}
+
+	if err := usecase.activityRepository.Purge(userId, id); err != nil {
+		return err
+	}
+
+	usecase.bus.Publish(events.Event{
+		Name:       entities.EventActivityPurged,
+		ActorId:    userId,
+		ActivityId: id,
+		Payload:    activity,
+	})
+	return nil
 }
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"errors"
+	"todolist-v1/modules/activity/entities"
+	"todolist-v1/modules/activity/repository"
+	projectEntities "todolist-v1/modules/project/entities"
+	"todolist-v1/pkg/rank"
+)
+
+var (
+	ErrProjectMismatch = errors.New("the project belongs to another workspace than the activity")
+	ErrNoProject       = errors.New("the activity is not in a project")
+	ErrInvalidPosition = errors.New("the neighbours must be distinct activities of the target column, in order")
+)
+
+// checkProject makes sure the activity may join the project: a workspace
+// project holds the workspace's activities, a personal project its owner's.
+func checkProject(project projectEntities.Project, activity entities.Activity) error {
+	if !sameValue(project.WorkspaceId, activity.WorkspaceId) {
+		return ErrProjectMismatch
+	}
+	if project.WorkspaceId == nil && project.OwnerId != activity.OwnerId {
+		return ErrProjectMismatch
+	}
+	return nil
+}
+
+// positionRank computes the rank for activity id placed between the given
+// neighbours of a board column. The board must be locked.
+func positionRank(activities repository.ActivityRepository, userId int, id int, projectId int, status string, afterId *int, beforeId *int) (string, error) {
+	neighbour := func(neighbourId int) (string, error) {
+		if neighbourId == id {
+			return "", ErrInvalidPosition
+		}
+		activity, err := activities.FindById(userId, neighbourId)
+		if errors.Is(err, repository.ErrActivityNotFound) {
+			return "", ErrInvalidPosition
+		}
+		if err != nil {
+			return "", err
+		}
+		if activity.ProjectId == nil || *activity.ProjectId != projectId || activity.Status != status || activity.Rank == nil {
+			return "", ErrInvalidPosition
+		}
+		return *activity.Rank, nil
+	}
+
+	var lower, upper string
+	var err error
+	switch {
+	case afterId != nil && beforeId != nil:
+		if lower, err = neighbour(*afterId); err != nil {
+			return "", err
+		}
+		if upper, err = neighbour(*beforeId); err != nil {
+			return "", err
+		}
+	case afterId != nil:
+		if lower, err = neighbour(*afterId); err != nil {
+			return "", err
+		}
+		if upper, err = activities.FindRankAfter(projectId, status, lower, id); err != nil {
+			return "", err
+		}
+	case beforeId != nil:
+		if upper, err = neighbour(*beforeId); err != nil {
+			return "", err
+		}
+		if lower, err = activities.FindRankBefore(projectId, status, upper, id); err != nil {
+			return "", err
+		}
+	default:
+		if lower, err = activities.FindLastRank(projectId, status, id); err != nil {
+			return "", err
+		}
+	}
+
+	between, err := rank.Between(lower, upper)
+	if errors.Is(err, rank.ErrOutOfOrder) {
+		return "", ErrInvalidPosition
+	}
+	if err == nil && len(between) > rank.MaxLength {
+		// Renumbering leaves short ranks with room between them, so the
+		// second attempt does not renumber again.
+		if err := renumberColumn(activities, projectId, status, id); err != nil {
+			return "", err
+		}
+		return positionRank(activities, userId, id, projectId, status, afterId, beforeId)
+	}
+	return between, err
+}
+
+// renumberColumn spreads the ranks of a board column evenly, keeping its
+// order. The board must be locked.
+func renumberColumn(activities repository.ActivityRepository, projectId int, status string, excludeId int) error {
+	ids, err := activities.FindColumn(projectId, status, excludeId)
+	if err != nil {
+		return err
+	}
+	for i, spread := range rank.Spread(len(ids)) {
+		if err := activities.SetRank(ids[i], spread); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"time"
+	"todolist-v1/modules/activity/entities"
+	"todolist-v1/modules/activity/repository"
+)
+
+// conflictHorizon is how far past its start a recurring event is checked
+// for overlaps.
+const conflictHorizon = 366 * 24 * time.Hour
+
+// ConflictError refuses an event that overlaps other events of its owner.
+type ConflictError struct {
+	Conflicts []entities.ActivityOccurrence
+}
+
+func (err *ConflictError) Error() string {
+	return "the event overlaps other events of its owner"
+}
+
+// checkConflicts returns the events of the owner that overlap the timed
+// event just written, or a ConflictError when the policy rejects overlaps.
+// The owner's schedule is locked first, so that of two overlapping events
+// written at once the second sees the first. The occurrences of a recurring
+// event are checked up to conflictHorizon after its start.
+func checkConflicts(activities repository.ActivityRepository, event entities.Activity, policy entities.ConflictPolicy) ([]entities.ActivityOccurrence, error) {
+	if event.Category != entities.CategoryEvent || event.AllDay || event.StartAt == nil {
+		return nil, nil
+	}
+	if err := activities.LockSchedule(event.OwnerId); err != nil {
+		return nil, err
+	}
+
+	conflicts, err := activities.FindConflicts(event, event.StartAt.Add(conflictHorizon))
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) > 0 && policy == entities.ConflictsReject {
+		return nil, &ConflictError{Conflicts: conflicts}
+	}
+	return conflicts, nil
+}
@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"errors"
+	"todolist-v1/modules/activity/entities"
+	"todolist-v1/modules/activity/repository"
+)
+
+var ErrBlocked = errors.New("the activity cannot start while activities blocking it are open")
+
+// checkStart refuses to move an activity to ON PROGRESS while any of its
+// blockers is open. Activities already in progress may stay there.
+func checkStart(activities repository.ActivityRepository, before entities.Activity, status string) error {
+	if status != entities.StatusInProgress || before.Status == entities.StatusInProgress {
+		return nil
+	}
+	open, err := activities.CountOpenBlockers(before.Id)
+	if err != nil {
+		return err
+	}
+	if open > 0 {
+		return ErrBlocked
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// codePattern matches fenced code blocks and inline code spans, which
+	// may contain @ signs that are not mentions.
+	codePattern    = regexp.MustCompile("(?s)```.*?```|`[^`\n]*`")
+	mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([\w.%+-]+@[\w-]+(?:\.[\w-]+)+)`)
+)
+
+// parseMentions returns the distinct email addresses mentioned as
+// @user@example.com in a Markdown body, lower-cased and in order of
+// appearance.
+func parseMentions(body string) []string {
+	body = codePattern.ReplaceAllString(body, " ")
+
+	var emails []string
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		email := strings.ToLower(strings.TrimRight(match[1], "."))
+		if !seen[email] {
+			seen[email] = true
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
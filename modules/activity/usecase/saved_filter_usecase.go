@@ -0,0 +1,18 @@
+package usecase
+
+import "todolist-v1/modules/activity/entities"
+
+// SavedFilterUsecase manages named filters. Every member of a workspace
+// sees and runs its filters; changing them needs the right to write
+// activities in the workspace. Personal filters are only visible to their
+// owner.
+type SavedFilterUsecase interface {
+	GetAll(userId int) ([]entities.SavedFilter, error)
+	Get(userId int, id int) (entities.SavedFilter, error)
+	Create(userId int, filter entities.SavedFilter) (entities.SavedFilter, error)
+	Update(userId int, id int, filter entities.SavedFilter) (entities.SavedFilter, error)
+	Delete(userId int, id int) error
+	// Activities lists the activities matching the filter among those
+	// visible to userId, exactly as the activity list would.
+	Activities(userId int, id int) ([]entities.Activity, error)
+}
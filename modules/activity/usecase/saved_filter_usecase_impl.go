@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"todolist-v1/modules/activity/entities"
+	"todolist-v1/modules/activity/repository"
+	workspaceRepo "todolist-v1/modules/workspace/repository"
+)
+
+type savedFilterUsecaseImpl struct {
+	activityRepository    repository.ActivityRepository
+	savedFilterRepository repository.SavedFilterRepository
+	access                activityAccess
+}
+
+func NewSavedFilterUsecase(activityRepository repository.ActivityRepository, savedFilterRepository repository.SavedFilterRepository, shareRepository repository.ActivityShareRepository, workspaceRepository workspaceRepo.WorkspaceRepository) SavedFilterUsecase {
+	return &savedFilterUsecaseImpl{
+		activityRepository:    activityRepository,
+		savedFilterRepository: savedFilterRepository,
+		access: activityAccess{
+			activityRepository:  activityRepository,
+			shareRepository:     shareRepository,
+			workspaceRepository: workspaceRepository,
+		},
+	}
+}
+
+func (usecase *savedFilterUsecaseImpl) GetAll(userId int) ([]entities.SavedFilter, error) {
+	return usecase.savedFilterRepository.FindAllForUser(userId)
+}
+
+func (usecase *savedFilterUsecaseImpl) Get(userId int, id int) (entities.SavedFilter, error) {
+	return usecase.savedFilterRepository.FindByIdForUser(userId, id)
+}
+
+func (usecase *savedFilterUsecaseImpl) Create(userId int, filter entities.SavedFilter) (entities.SavedFilter, error) {
+	filter.OwnerId = userId
+	if err := usecase.checkWrite(userId, filter); err != nil {
+		return entities.SavedFilter{}, err
+	}
+	if err := normalizeFilter(&filter.Filter); err != nil {
+		return entities.SavedFilter{}, err
+	}
+	return usecase.savedFilterRepository.Save(filter)
+}
+
+func (usecase *savedFilterUsecaseImpl) Update(userId int, id int, filter entities.SavedFilter) (entities.SavedFilter, error) {
+	current, err := usecase.savedFilterRepository.FindByIdForUser(userId, id)
+	if err != nil {
+		return entities.SavedFilter{}, err
+	}
+	if err := usecase.checkWrite(userId, current); err != nil {
+		return entities.SavedFilter{}, err
+	}
+	if err := normalizeFilter(&filter.Filter); err != nil {
+		return entities.SavedFilter{}, err
+	}
+	return usecase.savedFilterRepository.Update(id, filter)
+}
+
+func (usecase *savedFilterUsecaseImpl) Delete(userId int, id int) error {
+	current, err := usecase.savedFilterRepository.FindByIdForUser(userId, id)
+	if err != nil {
+		return err
+	}
+	if err := usecase.checkWrite(userId, current); err != nil {
+		return err
+	}
+	return usecase.savedFilterRepository.Delete(id)
+}
+
+func (usecase *savedFilterUsecaseImpl) Activities(userId int, id int) ([]entities.Activity, error) {
+	saved, err := usecase.savedFilterRepository.FindByIdForUser(userId, id)
+	if err != nil {
+		return nil, err
+	}
+	filter := saved.Filter.ActivityFilter()
+	if filter.WorkspaceId != nil {
+		if _, err := usecase.access.workspaceMember(userId, *filter.WorkspaceId); err != nil {
+			return nil, err
+		}
+	}
+	return usecase.activityRepository.FindAll(userId, filter)
+}
+
+// checkWrite allows the owner of a personal filter and members who may
+// write activities in the workspace of a shared filter.
+func (usecase *savedFilterUsecaseImpl) checkWrite(userId int, filter entities.SavedFilter) error {
+	if filter.WorkspaceId == nil {
+		if filter.OwnerId != userId {
+			return ErrForbidden
+		}
+		return nil
+	}
+
+	member, err := usecase.access.workspaceMember(userId, *filter.WorkspaceId)
+	if err != nil {
+		return err
+	}
+	if !member.CanWriteActivities() {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// normalizeFilter stores tags the way activities carry them.
+func normalizeFilter(document *entities.FilterDocument) error {
+	if len(document.Tags) == 0 {
+		return nil
+	}
+	tags, err := normalizeTags(document.Tags)
+	if err != nil {
+		return err
+	}
+	document.Tags = tags
+	return nil
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+	"todolist-v1/modules/activity/entities"
+	"todolist-v1/pkg/date"
+)
+
+var (
+	ErrMissingDate     = errors.New("an activity needs a date: start_at for events, due_at for tasks or activity_date")
+	ErrTaskTimeRange   = errors.New("tasks have a due date, not a start and end")
+	ErrEventDueDate    = errors.New("events have a start and end, not a due date")
+	ErrEndBeforeStart  = errors.New("end_at must not be before start_at")
+	ErrInvalidTimezone = errors.New("unknown timezone")
+	ErrRecurrenceUntil = errors.New("recurrence_until must not be before the activity date")
+)
+
+// normalizeSchedule checks the dates that apply to the activity's category
+// and keeps ActivityDate in step with them: it is the start of an event and
+// the deadline of a task. When only ActivityDate is given it is taken as the
+// start or deadline. All-day activities are moved to the start of their day
+// in the activity's timezone, the end of an event to the start of its last
+// day. It also fills in the default priority and recurrence interval.
+func normalizeSchedule(activity *entities.Activity) error {
+	if activity.Priority == "" {
+		activity.Priority = entities.PriorityDefault
+	}
+	if activity.RecurrenceInterval < 1 || activity.RecurrenceFrequency == nil {
+		activity.RecurrenceInterval = 1
+	}
+	if activity.RecurrenceFrequency == nil {
+		activity.RecurrenceUntil = nil
+	}
+
+	switch activity.Category {
+	case entities.CategoryTask:
+		if activity.StartAt != nil || activity.EndAt != nil {
+			return ErrTaskTimeRange
+		}
+		if activity.DueAt == nil {
+			if activity.ActivityDate.IsZero() {
+				return ErrMissingDate
+			}
+			due := activity.ActivityDate
+			activity.DueAt = &due
+		}
+		activity.ActivityDate = *activity.DueAt
+	case entities.CategoryEvent:
+		if activity.DueAt != nil {
+			return ErrEventDueDate
+		}
+		if activity.StartAt == nil {
+			if activity.ActivityDate.IsZero() {
+				return ErrMissingDate
+			}
+			start := activity.ActivityDate
+			activity.StartAt = &start
+		}
+		if activity.EndAt != nil && activity.EndAt.Before(*activity.StartAt) {
+			return ErrEndBeforeStart
+		}
+		activity.ActivityDate = *activity.StartAt
+	}
+	if activity.RecurrenceUntil != nil && activity.RecurrenceUntil.Before(activity.ActivityDate) {
+		return ErrRecurrenceUntil
+	}
+
+	location := time.UTC
+	if activity.Timezone != nil {
+		var err error
+		if location, err = date.LoadLocation(*activity.Timezone); err != nil {
+			return ErrInvalidTimezone
+		}
+	}
+	if !activity.AllDay {
+		activity.LocalDate = nil
+		return nil
+	}
+
+	day := date.Of(activity.ActivityDate.In(location))
+	start := day.In(location)
+	activity.LocalDate = &day
+	activity.ActivityDate = start
+	switch activity.Category {
+	case entities.CategoryTask:
+		activity.DueAt = &start
+	case entities.CategoryEvent:
+		activity.StartAt = &start
+		if activity.EndAt != nil {
+			end := date.Of(activity.EndAt.In(location)).In(location)
+			activity.EndAt = &end
+		}
+	}
+	return nil
+}
+
+// location returns the timezone an activity was planned in, UTC when it has
+// none.
+func location(activity entities.Activity) *time.Location {
+	if activity.Timezone != nil {
+		if loc, err := date.LoadLocation(*activity.Timezone); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
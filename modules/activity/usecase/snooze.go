@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+	"todolist-v1/modules/activity/entities"
+)
+
+var ErrInvalidSnooze = errors.New("snooze needs either a known preset or a positive duration")
+
+const (
+	// laterToday is how far "later today" pushes an activity.
+	laterToday = 3 * time.Hour
+	// morningHour is the local hour that morning presets move activities to.
+	morningHour = 9
+)
+
+// snoozeUntil resolves a snooze relative to now, which is in the caller's
+// timezone.
+func snoozeUntil(now time.Time, snooze entities.Snooze) (time.Time, error) {
+	if snooze.Preset == "" {
+		if snooze.Duration <= 0 {
+			return time.Time{}, ErrInvalidSnooze
+		}
+		return now.Add(snooze.Duration), nil
+	}
+
+	morning := func(days int) time.Time {
+		return time.Date(now.Year(), now.Month(), now.Day()+days, morningHour, 0, 0, 0, now.Location())
+	}
+	switch snooze.Preset {
+	case entities.SnoozeLaterToday:
+		return now.Add(laterToday), nil
+	case entities.SnoozeTomorrowMorning:
+		return morning(1), nil
+	case entities.SnoozeNextWeek:
+		// Next Monday, a full week ahead when today is Monday.
+		days := (8 - int(now.Weekday())) % 7
+		if days == 0 {
+			days = 7
+		}
+		return morning(days), nil
+	}
+	return time.Time{}, ErrInvalidSnooze
+}
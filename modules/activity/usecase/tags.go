@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// maxTags is the number of tags an activity may carry.
+const maxTags = 20
+
+var ErrInvalidTag = errors.New("tags are up to 50 letters, digits, - and _, at most 20 per activity")
+
+var tagPattern = regexp.MustCompile(`^[\p{Ll}\p{N}][\p{Ll}\p{N}_-]{0,49}$`)
+
+// normalizeTags lowercases the tags, strips a leading # and drops
+// duplicates, keeping the order they were given in. No tags are stored as an
+// empty list.
+func normalizeTags(tags []string) (pq.StringArray, error) {
+	normalized := make(pq.StringArray, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+		if !tagPattern.MatchString(tag) {
+			return nil, ErrInvalidTag
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > maxTags {
+		return nil, ErrInvalidTag
+	}
+	return normalized, nil
+}
@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"errors"
+	"todolist-v1/modules/activity/entities"
+)
+
+var (
+	ErrTimerRunning      = errors.New("a timer is already running, stop it first")
+	ErrTimeOverlap       = errors.New("the time entry overlaps another of your time entries")
+	ErrInvalidTimeEntry  = errors.New("ended_at must be after started_at and not in the future")
+	ErrNotEntryOwner     = errors.New("only the user who logged a time entry can delete it")
+	ErrInvalidTimesheet  = errors.New("the timesheet must span 1 to 366 days")
+	ErrInvalidDimensions = errors.New("group_by takes day, category and tag")
+)
+
+// TimeEntryUsecase tracks the time users spend on activities. Anyone who can
+// edit an activity may track time on it, and anyone who can see it may see
+// its entries. A user runs at most one timer at a time, and their entries
+// never overlap.
+type TimeEntryUsecase interface {
+	GetAll(userId int, activityId int) ([]entities.TimeEntry, error)
+	// Log adds a finished entry by hand.
+	Log(userId int, activityId int, entry entities.TimeEntry) (entities.TimeEntry, error)
+	Delete(userId int, activityId int, id int) error
+	Start(userId int, activityId int, note string) (entities.TimeEntry, error)
+	// Stop ends the user's running timer, whichever activity it is on.
+	Stop(userId int) (entities.TimeEntry, error)
+	Running(userId int) (entities.TimeEntry, error)
+	// Timesheet sums the user's finished entries by the query's dimensions.
+	Timesheet(userId int, query entities.TimesheetQuery) (entities.Timesheet, error)
+}
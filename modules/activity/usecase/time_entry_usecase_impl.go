@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"errors"
+	"slices"
+	"time"
+	"todolist-v1/modules/activity/entities"
+	"todolist-v1/modules/activity/repository"
+	workspaceRepo "todolist-v1/modules/workspace/repository"
+)
+
+// maxTimesheetDays is the longest period a timesheet covers.
+const maxTimesheetDays = 366
+
+var timesheetDimensions = []string{
+	entities.TimesheetByDay,
+	entities.TimesheetByCategory,
+	entities.TimesheetByTag,
+}
+
+type timeEntryUsecaseImpl struct {
+	timeEntryRepository repository.TimeEntryRepository
+	access              activityAccess
+}
+
+func NewTimeEntryUsecase(activityRepository repository.ActivityRepository, timeEntryRepository repository.TimeEntryRepository, shareRepository repository.ActivityShareRepository, workspaceRepository workspaceRepo.WorkspaceRepository) TimeEntryUsecase {
+	return &timeEntryUsecaseImpl{
+		timeEntryRepository: timeEntryRepository,
+		access: activityAccess{
+			activityRepository:  activityRepository,
+			shareRepository:     shareRepository,
+			workspaceRepository: workspaceRepository,
+		},
+	}
+}
+
+func (usecase *timeEntryUsecaseImpl) GetAll(userId int, activityId int) ([]entities.TimeEntry, error) {
+	if _, err := usecase.access.load(userId, activityId, accessRead); err != nil {
+		return nil, err
+	}
+	return usecase.timeEntryRepository.FindAll(activityId)
+}
+
+func (usecase *timeEntryUsecaseImpl) Log(userId int, activityId int, entry entities.TimeEntry) (entities.TimeEntry, error) {
+	if entry.EndedAt == nil || !entry.EndedAt.After(entry.StartedAt) || entry.EndedAt.After(time.Now()) {
+		return entities.TimeEntry{}, ErrInvalidTimeEntry
+	}
+	if _, err := usecase.access.load(userId, activityId, accessEdit); err != nil {
+		return entities.TimeEntry{}, err
+	}
+
+	entry.ActivityId = activityId
+	entry.UserId = userId
+	var logged entities.TimeEntry
+	err := usecase.timeEntryRepository.Transaction(userId, func(entries repository.TimeEntryRepository) error {
+		overlapping, err := entries.CountOverlapping(userId, entry.StartedAt, *entry.EndedAt)
+		if err != nil {
+			return err
+		}
+		if overlapping > 0 {
+			return ErrTimeOverlap
+		}
+		logged, err = entries.Save(entry)
+		return err
+	})
+	if err != nil {
+		return entities.TimeEntry{}, err
+	}
+	return logged, nil
+}
+
+func (usecase *timeEntryUsecaseImpl) Delete(userId int, activityId int, id int) error {
+	if _, err := usecase.access.load(userId, activityId, accessRead); err != nil {
+		return err
+	}
+	entry, err := usecase.timeEntryRepository.FindById(activityId, id)
+	if err != nil {
+		return err
+	}
+	if entry.UserId != userId {
+		return ErrNotEntryOwner
+	}
+	return usecase.timeEntryRepository.Delete(id)
+}
+
+func (usecase *timeEntryUsecaseImpl) Start(userId int, activityId int, note string) (entities.TimeEntry, error) {
+	if _, err := usecase.access.load(userId, activityId, accessEdit); err != nil {
+		return entities.TimeEntry{}, err
+	}
+
+	// Entries logged by hand end in the past, so a timer starting now cannot
+	// overlap them.
+	var started entities.TimeEntry
+	err := usecase.timeEntryRepository.Transaction(userId, func(entries repository.TimeEntryRepository) error {
+		_, err := entries.FindRunning(userId)
+		if err == nil {
+			return ErrTimerRunning
+		}
+		if !errors.Is(err, repository.ErrNoRunningTimer) {
+			return err
+		}
+		started, err = entries.Save(entities.TimeEntry{
+			ActivityId: activityId,
+			UserId:     userId,
+			StartedAt:  time.Now(),
+			Note:       note,
+		})
+		return err
+	})
+	if err != nil {
+		return entities.TimeEntry{}, err
+	}
+	return started, nil
+}
+
+func (usecase *timeEntryUsecaseImpl) Stop(userId int) (entities.TimeEntry, error) {
+	var stopped entities.TimeEntry
+	err := usecase.timeEntryRepository.Transaction(userId, func(entries repository.TimeEntryRepository) error {
+		running, err := entries.FindRunning(userId)
+		if err != nil {
+			return err
+		}
+		stopped, err = entries.Stop(running.Id, time.Now())
+		return err
+	})
+	if err != nil {
+		return entities.TimeEntry{}, err
+	}
+	return stopped, nil
+}
+
+func (usecase *timeEntryUsecaseImpl) Running(userId int) (entities.TimeEntry, error) {
+	return usecase.timeEntryRepository.FindRunning(userId)
+}
+
+func (usecase *timeEntryUsecaseImpl) Timesheet(userId int, query entities.TimesheetQuery) (entities.Timesheet, error) {
+	if query.To < query.From || query.From.AddDays(maxTimesheetDays-1) < query.To {
+		return entities.Timesheet{}, ErrInvalidTimesheet
+	}
+	if len(query.GroupBy) == 0 {
+		query.GroupBy = []string{entities.TimesheetByDay}
+	}
+	for i, dimension := range query.GroupBy {
+		if !slices.Contains(timesheetDimensions, dimension) || slices.Contains(query.GroupBy[:i], dimension) {
+			return entities.Timesheet{}, ErrInvalidDimensions
+		}
+	}
+	if query.Location == nil {
+		query.Location = time.UTC
+	}
+
+	from := query.From.In(query.Location)
+	to := query.To.AddDays(1).In(query.Location)
+	rows, err := usecase.timeEntryRepository.Summarize(userId, from, to, query.Location.String(), query.GroupBy)
+	if err != nil {
+		return entities.Timesheet{}, err
+	}
+	total, err := usecase.timeEntryRepository.Total(userId, from, to)
+	if err != nil {
+		return entities.Timesheet{}, err
+	}
+	return entities.Timesheet{TimesheetQuery: query, TotalSeconds: total, Rows: rows}, nil
+}
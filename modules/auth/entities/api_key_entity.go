@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	ScopeActivitiesRead  = "activities:read"
+	ScopeActivitiesWrite = "activities:write"
+	ScopeWorkspacesRead  = "workspaces:read"
+	ScopeWorkspacesWrite = "workspaces:write"
+)
+
+type ApiKey struct {
+	Id         int            `json:"id"           gorm:"column:id;primaryKey;autoIncrement"`
+	UserId     int            `json:"user_id"      gorm:"column:user_id;not null"`
+	Name       string         `json:"name"         gorm:"column:name;size:100;not null"`
+	Prefix     string         `json:"prefix"       gorm:"column:prefix;size:16;not null"`
+	KeyHash    string         `json:"-"            gorm:"column:key_hash;size:64;not null;unique"`
+	Scopes     pq.StringArray `json:"scopes"       gorm:"column:scopes;type:text[];not null"`
+	LastUsedAt *time.Time     `json:"last_used_at" gorm:"column:last_used_at"`
+	CreatedAt  time.Time      `json:"created_at"   gorm:"column:created_at;autoCreateTime"`
+	RevokedAt  *time.Time     `json:"revoked_at"   gorm:"column:revoked_at"`
+}
+
+func (ApiKey) TableName() string { return "api_keys" }
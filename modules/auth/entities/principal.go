@@ -0,0 +1,27 @@
+package entities
+
+import "slices"
+
+// Principal is the authenticated caller of a request. It is stored in
+// fiber.Ctx.Locals by the auth middleware.
+type Principal struct {
+	UserId int
+	Email  string
+	// ApiKeyId is set when the caller authenticated with a personal API key
+	// instead of an access token.
+	ApiKeyId int
+	Scopes   []string
+	// Timezone is the IANA zone from the user's profile, empty when unset.
+	// Access tokens carry the zone the profile had when they were issued.
+	Timezone string
+}
+
+// HasScope reports whether the caller may perform actions guarded by scope.
+// Access tokens carry the full rights of the user; API keys only the scopes
+// they were created with.
+func (principal Principal) HasScope(scope string) bool {
+	if principal.ApiKeyId == 0 {
+		return true
+	}
+	return slices.Contains(principal.Scopes, scope)
+}
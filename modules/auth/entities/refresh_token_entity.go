@@ -0,0 +1,14 @@
+package entities
+
+import "time"
+
+type RefreshToken struct {
+	Id         string     `json:"id"          gorm:"column:id;primaryKey;size:64"`
+	UserId     int        `json:"user_id"     gorm:"column:user_id;not null"`
+	ExpiresAt  time.Time  `json:"expires_at"  gorm:"column:expires_at;not null"`
+	RevokedAt  *time.Time `json:"revoked_at"  gorm:"column:revoked_at"`
+	ReplacedBy *string    `json:"replaced_by" gorm:"column:replaced_by;size:64"`
+	CreatedAt  time.Time  `json:"created_at"  gorm:"column:created_at;autoCreateTime"`
+}
+
+func (RefreshToken) TableName() string { return "refresh_tokens" }
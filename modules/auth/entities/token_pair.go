@@ -0,0 +1,10 @@
+package entities
+
+import "time"
+
+type TokenPair struct {
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
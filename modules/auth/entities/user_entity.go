@@ -0,0 +1,14 @@
+package entities
+
+import "time"
+
+type User struct {
+	Id           int       `json:"id"         gorm:"column:id;primaryKey;autoIncrement"`
+	Email        string    `json:"email"      gorm:"column:email;size:254;not null;unique"`
+	Name         string    `json:"name"       gorm:"column:name;size:100;not null"`
+	PasswordHash string    `json:"-"          gorm:"column:password_hash;not null"`
+	Timezone     *string   `json:"timezone"   gorm:"column:timezone;size:64"`
+	CreatedAt    time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+}
+
+func (User) TableName() string { return "users" }
@@ -0,0 +1,10 @@
+package handler
+
+import "github.com/gofiber/fiber/v2"
+
+type ApiKeyHandler interface {
+	GetAll(ctx *fiber.Ctx) error
+	Create(ctx *fiber.Ctx) error
+	Revoke(ctx *fiber.Ctx) error
+	RegisterRoutes()
+}
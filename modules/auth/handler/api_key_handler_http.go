@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+	"todolist-v1/modules/auth/entities"
+	"todolist-v1/modules/auth/middleware"
+	"todolist-v1/modules/auth/models"
+	"todolist-v1/modules/auth/repository"
+	"todolist-v1/modules/auth/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type apiKeyHandlerHttp struct {
+	app            *fiber.App
+	usecase        usecase.ApiKeyUsecase
+	authMiddleware fiber.Handler
+	validate       *validator.Validate
+}
+
+func NewApiKeyHttpHandler(app *fiber.App, usecase usecase.ApiKeyUsecase, authMiddleware fiber.Handler) ApiKeyHandler {
+	return &apiKeyHandlerHttp{
+		app:            app,
+		usecase:        usecase,
+		authMiddleware: authMiddleware,
+		validate:       validator.New(),
+	}
+}
+
+func (handler *apiKeyHandlerHttp) GetAll(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	apiKeys, err := handler.usecase.GetAll(principal.UserId)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"data":        nil,
+			"status_code": fiber.StatusInternalServerError,
+			"message":     err.Error(),
+		})
+	}
+
+	var apiKeyResponses []models.ApiKeyResponse
+	for _, apiKey := range apiKeys {
+		apiKeyResponses = append(apiKeyResponses, toApiKeyResponse(apiKey))
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        apiKeyResponses,
+		"status_code": fiber.StatusOK,
+		"message":     "API keys retrieved successfully",
+	})
+}
+
+func (handler *apiKeyHandlerHttp) Create(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	var request models.ApiKeyCreateRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"data":        nil,
+			"status_code": fiber.StatusBadRequest,
+			"message":     "Cannot parse JSON",
+		})
+	}
+
+	if err := handler.validate.Struct(request); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"data":        nil,
+			"status_code": fiber.StatusBadRequest,
+			"message":     err.Error(),
+		})
+	}
+
+	apiKey, rawKey, err := handler.usecase.Create(principal.UserId, request.Name, request.Scopes)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"data":        nil,
+			"status_code": fiber.StatusInternalServerError,
+			"message":     err.Error(),
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data": models.ApiKeyCreateResponse{
+			ApiKeyResponse: toApiKeyResponse(apiKey),
+			Key:            rawKey,
+		},
+		"status_code": fiber.StatusCreated,
+		"message":     "API key created successfully",
+	})
+}
+
+func (handler *apiKeyHandlerHttp) Revoke(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"data":        nil,
+			"status_code": fiber.StatusBadRequest,
+			"message":     "Invalid ID",
+		})
+	}
+
+	if err := handler.usecase.Revoke(principal.UserId, id); err != nil {
+		if errors.Is(err, repository.ErrApiKeyNotFound) {
+			return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"data":        nil,
+				"status_code": fiber.StatusNotFound,
+				"message":     err.Error(),
+			})
+		}
+
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"data":        nil,
+			"status_code": fiber.StatusInternalServerError,
+			"message":     err.Error(),
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusOK,
+		"message":     "API key revoked successfully",
+	})
+}
+
+func (handler *apiKeyHandlerHttp) RegisterRoutes() {
+	apiKeys := handler.app.Group("/api/auth/api-keys", handler.authMiddleware, middleware.RequireSession())
+	apiKeys.Get("/", handler.GetAll)
+	apiKeys.Post("/", handler.Create)
+	apiKeys.Delete("/:id", handler.Revoke)
+}
+
+func toApiKeyResponse(apiKey entities.ApiKey) models.ApiKeyResponse {
+	return models.ApiKeyResponse{
+		Id:         apiKey.Id,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Scopes:     apiKey.Scopes,
+		LastUsedAt: apiKey.LastUsedAt,
+		CreatedAt:  apiKey.CreatedAt,
+	}
+}
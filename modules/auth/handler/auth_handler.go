@@ -0,0 +1,13 @@
+package handler
+
+import "github.com/gofiber/fiber/v2"
+
+type AuthHandler interface {
+	Register(ctx *fiber.Ctx) error
+	Login(ctx *fiber.Ctx) error
+	Refresh(ctx *fiber.Ctx) error
+	Logout(ctx *fiber.Ctx) error
+	Me(ctx *fiber.Ctx) error
+	UpdateProfile(ctx *fiber.Ctx) error
+	RegisterRoutes()
+}
@@ -0,0 +1,299 @@
+package handler
+
+import (
+	"errors"
+	"todolist-v1/modules/auth/entities"
+	"todolist-v1/modules/auth/middleware"
+	"todolist-v1/modules/auth/models"
+	"todolist-v1/modules/auth/repository"
+	"todolist-v1/modules/auth/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type authHandlerHttp struct {
+	app            *fiber.App
+	usecase        usecase.AuthUsecase
+	authMiddleware fiber.Handler
+	validate       *validator.Validate
+}
+
+func NewAuthHttpHandler(app *fiber.App, usecase usecase.AuthUsecase, authMiddleware fiber.Handler) AuthHandler {
+	return &authHandlerHttp{
+		app:            app,
+		usecase:        usecase,
+		authMiddleware: authMiddleware,
+		validate:       validator.New(),
+	}
+}
+
+func (handler *authHandlerHttp) Register(ctx *fiber.Ctx) error {
+	var request models.RegisterRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"data":        nil,
+			"status_code": fiber.StatusBadRequest,
+			"message":     "Cannot parse JSON",
+		})
+	}
+
+	if err := handler.validate.Struct(request); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"data":        nil,
+			"status_code": fiber.StatusBadRequest,
+			"message":     err.Error(),
+		})
+	}
+
+	user, err := handler.usecase.Register(entities.User{
+		Email: request.Email,
+		Name:  request.Name,
+	}, request.Password)
+	if err != nil {
+		if errors.Is(err, usecase.ErrEmailAlreadyRegistered) {
+			return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"data":        nil,
+				"status_code": fiber.StatusConflict,
+				"message":     err.Error(),
+			})
+		}
+
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"data":        nil,
+			"status_code": fiber.StatusInternalServerError,
+			"message":     err.Error(),
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data":        toUserResponse(user),
+		"status_code": fiber.StatusCreated,
+		"message":     "User registered successfully",
+	})
+}
+
+func (handler *authHandlerHttp) Login(ctx *fiber.Ctx) error {
+	var request models.LoginRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"data":        nil,
+			"status_code": fiber.StatusBadRequest,
+			"message":     "Cannot parse JSON",
+		})
+	}
+
+	if err := handler.validate.Struct(request); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"data":        nil,
+			"status_code": fiber.StatusBadRequest,
+			"message":     err.Error(),
+		})
+	}
+
+	pair, err := handler.usecase.Login(request.Email, request.Password)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidCredentials) {
+			return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"data":        nil,
+				"status_code": fiber.StatusUnauthorized,
+				"message":     err.Error(),
+			})
+		}
+
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"data":        nil,
+			"status_code": fiber.StatusInternalServerError,
+			"message":     err.Error(),
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":        toTokenResponse(pair),
+		"status_code": fiber.StatusOK,
+		"message":     "Logged in successfully",
+	})
+}
+
+func (handler *authHandlerHttp) Refresh(ctx *fiber.Ctx) error {
+	var request models.RefreshRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"data":        nil,
+			"status_code": fiber.StatusBadRequest,
+			"message":     "Cannot parse JSON",
+		})
+	}
+
+	if err := handler.validate.Struct(request); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"data":        nil,
+			"status_code": fiber.StatusBadRequest,
+			"message":     err.Error(),
+		})
+	}
+
+	pair, err := handler.usecase.Refresh(request.RefreshToken)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidToken) {
+			return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"data":        nil,
+				"status_code": fiber.StatusUnauthorized,
+				"message":     err.Error(),
+			})
+		}
+
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"data":        nil,
+			"status_code": fiber.StatusInternalServerError,
+			"message":     err.Error(),
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":        toTokenResponse(pair),
+		"status_code": fiber.StatusOK,
+		"message":     "Token refreshed successfully",
+	})
+}
+
+func (handler *authHandlerHttp) Logout(ctx *fiber.Ctx) error {
+	var request models.RefreshRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"data":        nil,
+			"status_code": fiber.StatusBadRequest,
+			"message":     "Cannot parse JSON",
+		})
+	}
+
+	if err := handler.validate.Struct(request); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"data":        nil,
+			"status_code": fiber.StatusBadRequest,
+			"message":     err.Error(),
+		})
+	}
+
+	if err := handler.usecase.Logout(request.RefreshToken); err != nil {
+		if errors.Is(err, usecase.ErrInvalidToken) {
+			return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"data":        nil,
+				"status_code": fiber.StatusUnauthorized,
+				"message":     err.Error(),
+			})
+		}
+
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"data":        nil,
+			"status_code": fiber.StatusInternalServerError,
+			"message":     err.Error(),
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusOK,
+		"message":     "Logged out successfully",
+	})
+}
+
+func (handler *authHandlerHttp) Me(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	user, err := handler.usecase.GetUser(principal.UserId)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"data":        nil,
+				"status_code": fiber.StatusNotFound,
+				"message":     err.Error(),
+			})
+		}
+
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"data":        nil,
+			"status_code": fiber.StatusInternalServerError,
+			"message":     err.Error(),
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":        toUserResponse(user),
+		"status_code": fiber.StatusOK,
+		"message":     "User retrieved successfully",
+	})
+}
+
+func (handler *authHandlerHttp) UpdateProfile(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	var request models.ProfileUpdateRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"data":        nil,
+			"status_code": fiber.StatusBadRequest,
+			"message":     "Cannot parse JSON",
+		})
+	}
+
+	if err := handler.validate.Struct(request); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"data":        nil,
+			"status_code": fiber.StatusBadRequest,
+			"message":     err.Error(),
+		})
+	}
+
+	user, err := handler.usecase.UpdateProfile(principal.UserId, request.Name, request.Timezone)
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		switch {
+		case errors.Is(err, usecase.ErrInvalidTimezone):
+			status = fiber.StatusBadRequest
+		case errors.Is(err, repository.ErrUserNotFound):
+			status = fiber.StatusNotFound
+		}
+
+		return ctx.Status(status).JSON(fiber.Map{
+			"data":        nil,
+			"status_code": status,
+			"message":     err.Error(),
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":        toUserResponse(user),
+		"status_code": fiber.StatusOK,
+		"message":     "Profile updated successfully",
+	})
+}
+
+func (handler *authHandlerHttp) RegisterRoutes() {
+	handler.app.Post("/api/auth/register", handler.Register)
+	handler.app.Post("/api/auth/login", handler.Login)
+	handler.app.Post("/api/auth/refresh", handler.Refresh)
+	handler.app.Post("/api/auth/logout", handler.Logout)
+	handler.app.Get("/api/auth/me", handler.authMiddleware, handler.Me)
+	handler.app.Put("/api/auth/me", handler.authMiddleware, middleware.RequireSession(), handler.UpdateProfile)
+}
+
+func toUserResponse(user entities.User) models.UserResponse {
+	return models.UserResponse{
+		Id:        user.Id,
+		Email:     user.Email,
+		Name:      user.Name,
+		Timezone:  user.Timezone,
+		CreatedAt: user.CreatedAt,
+	}
+}
+
+func toTokenResponse(pair entities.TokenPair) models.TokenResponse {
+	return models.TokenResponse{
+		AccessToken:      pair.AccessToken,
+		AccessExpiresAt:  pair.AccessExpiresAt,
+		RefreshToken:     pair.RefreshToken,
+		RefreshExpiresAt: pair.RefreshExpiresAt,
+		TokenType:        "Bearer",
+	}
+}
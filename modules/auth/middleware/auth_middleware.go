@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"strings"
+	"time"
+	"todolist-v1/modules/auth/entities"
+	"todolist-v1/modules/auth/usecase"
+	"todolist-v1/pkg/date"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	principalKey   = "principal"
+	apiKeyHeader   = "X-API-Key"
+	timezoneHeader = "X-Timezone"
+)
+
+// NewAuthMiddleware rejects requests without valid credentials and stores
+// the authenticated entities.Principal in the request locals. Callers
+// authenticate with a JWT access token in the Authorization header, or with
+// a personal API key in either the Authorization or the X-API-Key header.
+func NewAuthMiddleware(authUsecase usecase.AuthUsecase, apiKeyUsecase usecase.ApiKeyUsecase) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			principal entities.Principal
+			err       error
+		)
+
+		if apiKey := strings.TrimSpace(ctx.Get(apiKeyHeader)); apiKey != "" {
+			principal, err = apiKeyUsecase.Authenticate(apiKey)
+		} else if token, ok := bearerToken(ctx); !ok {
+			return unauthorized(ctx, "Missing bearer token or API key")
+		} else if strings.HasPrefix(token, usecase.ApiKeyPrefix) {
+			principal, err = apiKeyUsecase.Authenticate(token)
+		} else {
+			principal, err = authUsecase.Authenticate(token)
+		}
+		if err != nil {
+			return unauthorized(ctx, err.Error())
+		}
+
+		ctx.Locals(principalKey, principal)
+		return ctx.Next()
+	}
+}
+
+// RequireScope rejects callers whose credentials do not grant scope. It must
+// run after the auth middleware.
+func RequireScope(scope string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		principal, ok := CurrentPrincipal(ctx)
+		if !ok || !principal.HasScope(scope) {
+			return forbidden(ctx, "API key is missing the "+scope+" scope")
+		}
+		return ctx.Next()
+	}
+}
+
+// RequireSession rejects callers that authenticated with an API key, for
+// routes such as key management that must not be reachable by automation.
+func RequireSession() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		principal, ok := CurrentPrincipal(ctx)
+		if !ok || principal.ApiKeyId != 0 {
+			return forbidden(ctx, "This endpoint requires an access token")
+		}
+		return ctx.Next()
+	}
+}
+
+// CurrentPrincipal returns the caller stored by the auth middleware.
+func CurrentPrincipal(ctx *fiber.Ctx) (entities.Principal, bool) {
+	principal, ok := ctx.Locals(principalKey).(entities.Principal)
+	return principal, ok
+}
+
+// PreferredLocation returns the timezone dates should be rendered in for the
+// caller: the X-Timezone header, else the timezone of the caller's profile,
+// else UTC. An unknown zone in the header is an error.
+func PreferredLocation(ctx *fiber.Ctx) (*time.Location, error) {
+	if name := strings.TrimSpace(ctx.Get(timezoneHeader)); name != "" {
+		return date.LoadLocation(name)
+	}
+	if principal, ok := CurrentPrincipal(ctx); ok && principal.Timezone != "" {
+		if location, err := date.LoadLocation(principal.Timezone); err == nil {
+			return location, nil
+		}
+	}
+	return time.UTC, nil
+}
+
+func bearerToken(ctx *fiber.Ctx) (string, bool) {
+	header := ctx.Get(fiber.HeaderAuthorization)
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func unauthorized(ctx *fiber.Ctx, message string) error {
+	return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusUnauthorized,
+		"message":     message,
+	})
+}
+
+func forbidden(ctx *fiber.Ctx, message string) error {
+	return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusForbidden,
+		"message":     message,
+	})
+}
@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+type RegisterRequest struct {
+	Email    string `json:"email" validate:"required,email,max=254"`
+	Name     string `json:"name" validate:"required,max=100"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type TokenResponse struct {
+	AccessToken      string    `json:"access_token"`
+	AccessExpiresAt  time.Time `json:"access_expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+	TokenType        string    `json:"token_type"`
+}
+
+// ProfileUpdateRequest replaces the user's name and timezone. Timezone is an
+// IANA name such as Europe/Berlin; null clears it.
+type ProfileUpdateRequest struct {
+	Name     string  `json:"name" validate:"required,max=100"`
+	Timezone *string `json:"timezone" validate:"omitempty,max=64"`
+}
+
+type UserResponse struct {
+	Id        int       `json:"id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	Timezone  *string   `json:"timezone"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ApiKeyCreateRequest struct {
+	Name   string   `json:"name" validate:"required,max=100"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,oneof=activities:read activities:write workspaces:read workspaces:write"`
+}
+
+type ApiKeyResponse struct {
+	Id         int        `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type ApiKeyCreateResponse struct {
+	ApiKeyResponse
+	Key string `json:"key"`
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"errors"
+	"time"
+	"todolist-v1/modules/auth/entities"
+)
+
+var ErrApiKeyNotFound = errors.New("api key not found")
+
+type ApiKeyRepository interface {
+	FindAllByUser(userId int) ([]entities.ApiKey, error)
+	// FindActiveByHash returns the non-revoked key whose hash matches.
+	FindActiveByHash(hash string) (entities.ApiKey, error)
+	Save(apiKey entities.ApiKey) (entities.ApiKey, error)
+	Revoke(userId int, id int) error
+	TouchLastUsed(id int, at time.Time) error
+}
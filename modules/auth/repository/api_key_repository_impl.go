@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"errors"
+	"time"
+	"todolist-v1/modules/auth/entities"
+
+	"gorm.io/gorm"
+)
+
+type apiKeyRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewApiKeyRepository(db *gorm.DB) ApiKeyRepository {
+	return &apiKeyRepositoryImpl{DB: db}
+}
+
+func (repository *apiKeyRepositoryImpl) FindAllByUser(userId int) ([]entities.ApiKey, error) {
+	var apiKeys []entities.ApiKey
+	err := repository.DB.
+		Where("user_id = ? AND revoked_at IS NULL", userId).
+		Order("created_at DESC").
+		Find(&apiKeys).Error
+	if err != nil {
+		return nil, err
+	}
+	return apiKeys, nil
+}
+
+func (repository *apiKeyRepositoryImpl) FindActiveByHash(hash string) (entities.ApiKey, error) {
+	var apiKey entities.ApiKey
+	if err := repository.DB.Where("key_hash = ? AND revoked_at IS NULL", hash).First(&apiKey).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.ApiKey{}, ErrApiKeyNotFound
+		}
+		return entities.ApiKey{}, err
+	}
+	return apiKey, nil
+}
+
+func (repository *apiKeyRepositoryImpl) Save(apiKey entities.ApiKey) (entities.ApiKey, error) {
+	if err := repository.DB.Create(&apiKey).Error; err != nil {
+		return entities.ApiKey{}, err
+	}
+	return apiKey, nil
+}
+
+func (repository *apiKeyRepositoryImpl) Revoke(userId int, id int) error {
+	result := repository.DB.Model(&entities.ApiKey{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userId).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrApiKeyNotFound
+	}
+	return nil
+}
+
+func (repository *apiKeyRepositoryImpl) TouchLastUsed(id int, at time.Time) error {
+	return repository.DB.Model(&entities.ApiKey{}).
+		Where("id = ?", id).
+		Update("last_used_at", at).Error
+}
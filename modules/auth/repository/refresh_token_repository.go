@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"errors"
+	"todolist-v1/modules/auth/entities"
+)
+
+var (
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	ErrRefreshTokenRevoked  = errors.New("refresh token revoked")
+)
+
+type RefreshTokenRepository interface {
+	FindById(id string) (entities.RefreshToken, error)
+	Save(token entities.RefreshToken) (entities.RefreshToken, error)
+	// Rotate revokes the token identified by oldId and stores next in its
+	// place. It fails with ErrRefreshTokenRevoked if oldId was already revoked,
+	// so two concurrent refreshes with the same token cannot both succeed.
+	Rotate(oldId string, next entities.RefreshToken) error
+	Revoke(id string) error
+	RevokeAllForUser(userId int) error
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"errors"
+	"time"
+	"todolist-v1/modules/auth/entities"
+
+	"gorm.io/gorm"
+)
+
+type refreshTokenRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) RefreshTokenRepository {
+	return &refreshTokenRepositoryImpl{DB: db}
+}
+
+func (repository *refreshTokenRepositoryImpl) FindById(id string) (entities.RefreshToken, error) {
+	var token entities.RefreshToken
+	if err := repository.DB.Where("id = ?", id).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.RefreshToken{}, ErrRefreshTokenNotFound
+		}
+		return entities.RefreshToken{}, err
+	}
+	return token, nil
+}
+
+func (repository *refreshTokenRepositoryImpl) Save(token entities.RefreshToken) (entities.RefreshToken, error) {
+	if err := repository.DB.Create(&token).Error; err != nil {
+		return entities.RefreshToken{}, err
+	}
+	return token, nil
+}
+
+func (repository *refreshTokenRepositoryImpl) Rotate(oldId string, next entities.RefreshToken) error {
+	return repository.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", oldId).
+			Updates(map[string]any{
+				"revoked_at":  time.Now(),
+				"replaced_by": next.Id,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRefreshTokenRevoked
+		}
+		return tx.Create(&next).Error
+	})
+}
+
+func (repository *refreshTokenRepositoryImpl) Revoke(id string) error {
+	result := repository.DB.Model(&entities.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrRefreshTokenNotFound
+	}
+	return nil
+}
+
+func (repository *refreshTokenRepositoryImpl) RevokeAllForUser(userId int) error {
+	return repository.DB.Model(&entities.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userId).
+		Update("revoked_at", time.Now()).Error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"errors"
+	"todolist-v1/modules/auth/entities"
+)
+
+var ErrUserNotFound = errors.New("user not found")
+
+type UserRepository interface {
+	FindById(id int) (entities.User, error)
+	FindByEmail(email string) (entities.User, error)
+	Save(user entities.User) (entities.User, error)
+	// Update saves the user's name and timezone.
+	Update(user entities.User) (entities.User, error)
+}
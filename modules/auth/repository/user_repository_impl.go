@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"errors"
+	"todolist-v1/modules/auth/entities"
+
+	"gorm.io/gorm"
+)
+
+type userRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) UserRepository {
+	return &userRepositoryImpl{DB: db}
+}
+
+func (repository *userRepositoryImpl) FindById(id int) (entities.User, error) {
+	var user entities.User
+	if err := repository.DB.First(&user, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.User{}, ErrUserNotFound
+		}
+		return entities.User{}, err
+	}
+	return user, nil
+}
+
+func (repository *userRepositoryImpl) FindByEmail(email string) (entities.User, error) {
+	var user entities.User
+	if err := repository.DB.Where("LOWER(email) = LOWER(?)", email).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.User{}, ErrUserNotFound
+		}
+		return entities.User{}, err
+	}
+	return user, nil
+}
+
+func (repository *userRepositoryImpl) Save(user entities.User) (entities.User, error) {
+	if err := repository.DB.Create(&user).Error; err != nil {
+		return entities.User{}, err
+	}
+	return user, nil
+}
+
+func (repository *userRepositoryImpl) Update(user entities.User) (entities.User, error) {
+	result := repository.DB.Model(&entities.User{}).Where("id = ?", user.Id).Updates(map[string]any{
+		"name":     user.Name,
+		"timezone": user.Timezone,
+	})
+	if result.Error != nil {
+		return entities.User{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.User{}, ErrUserNotFound
+	}
+	return repository.FindById(user.Id)
+}
@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"errors"
+	"todolist-v1/modules/auth/entities"
+)
+
+// ApiKeyPrefix marks a bearer credential as a personal API key rather than
+// a JWT access token.
+const ApiKeyPrefix = "tdl_"
+
+var ErrInvalidApiKey = errors.New("invalid or revoked api key")
+
+type ApiKeyUsecase interface {
+	// Create returns the stored key together with the plain-text key, which
+	// is not persisted and cannot be retrieved again.
+	Create(userId int, name string, scopes []string) (entities.ApiKey, string, error)
+	GetAll(userId int) ([]entities.ApiKey, error)
+	Revoke(userId int, id int) error
+	Authenticate(rawKey string) (entities.Principal, error)
+}
@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"errors"
+	"todolist-v1/modules/auth/entities"
+)
+
+var (
+	ErrEmailAlreadyRegistered = errors.New("email already registered")
+	ErrInvalidCredentials     = errors.New("invalid email or password")
+	ErrInvalidToken           = errors.New("invalid or expired token")
+	ErrInvalidTimezone        = errors.New("unknown timezone")
+)
+
+type AuthUsecase interface {
+	Register(user entities.User, password string) (entities.User, error)
+	Login(email string, password string) (entities.TokenPair, error)
+	Refresh(refreshToken string) (entities.TokenPair, error)
+	Logout(refreshToken string) error
+	Authenticate(accessToken string) (entities.Principal, error)
+	GetUser(id int) (entities.User, error)
+	// UpdateProfile sets the user's name and preferred timezone; a nil
+	// timezone clears it. Access tokens pick up the new timezone when they
+	// are next refreshed.
+	UpdateProfile(id int, name string, timezone *string) (entities.User, error)
+}
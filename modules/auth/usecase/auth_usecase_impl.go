@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"todolist-v1/config"
+	"todolist-v1/modules/auth/entities"
+	"todolist-v1/modules/auth/repository"
+	"todolist-v1/pkg/date"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type authUsecaseImpl struct {
+	userRepository         repository.UserRepository
+	refreshTokenRepository repository.RefreshTokenRepository
+	tokens                 *tokenIssuer
+}
+
+func NewAuthUsecase(userRepository repository.UserRepository, refreshTokenRepository repository.RefreshTokenRepository, cfg *config.Config) AuthUsecase {
+	return &authUsecaseImpl{
+		userRepository:         userRepository,
+		refreshTokenRepository: refreshTokenRepository,
+		tokens:                 newTokenIssuer(cfg),
+	}
+}
+
+func (usecase *authUsecaseImpl) Register(user entities.User, password string) (entities.User, error) {
+	user.Email = strings.TrimSpace(user.Email)
+
+	_, err := usecase.userRepository.FindByEmail(user.Email)
+	if err == nil {
+		return entities.User{}, ErrEmailAlreadyRegistered
+	}
+	if !errors.Is(err, repository.ErrUserNotFound) {
+		return entities.User{}, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return entities.User{}, err
+	}
+	user.PasswordHash = string(hash)
+
+	return usecase.userRepository.Save(user)
+}
+
+func (usecase *authUsecaseImpl) Login(email string, password string) (entities.TokenPair, error) {
+	user, err := usecase.userRepository.FindByEmail(strings.TrimSpace(email))
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return entities.TokenPair{}, ErrInvalidCredentials
+		}
+		return entities.TokenPair{}, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return entities.TokenPair{}, ErrInvalidCredentials
+	}
+
+	return usecase.issue(user, "")
+}
+
+func (usecase *authUsecaseImpl) Refresh(refreshToken string) (entities.TokenPair, error) {
+	claims, err := usecase.tokens.parseRefresh(refreshToken)
+	if err != nil {
+		return entities.TokenPair{}, ErrInvalidToken
+	}
+
+	stored, err := usecase.refreshTokenRepository.FindById(claims.ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenNotFound) {
+			return entities.TokenPair{}, ErrInvalidToken
+		}
+		return entities.TokenPair{}, err
+	}
+
+	// A revoked token being presented again means it leaked or was replayed,
+	// so every session of the user is terminated.
+	if stored.RevokedAt != nil {
+		if err := usecase.refreshTokenRepository.RevokeAllForUser(stored.UserId); err != nil {
+			return entities.TokenPair{}, err
+		}
+		return entities.TokenPair{}, ErrInvalidToken
+	}
+
+	user, err := usecase.userRepository.FindById(stored.UserId)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return entities.TokenPair{}, ErrInvalidToken
+		}
+		return entities.TokenPair{}, err
+	}
+
+	pair, err := usecase.issue(user, stored.Id)
+	if errors.Is(err, repository.ErrRefreshTokenRevoked) {
+		return entities.TokenPair{}, ErrInvalidToken
+	}
+	return pair, err
+}
+
+func (usecase *authUsecaseImpl) Logout(refreshToken string) error {
+	claims, err := usecase.tokens.parseRefresh(refreshToken)
+	if err != nil {
+		return ErrInvalidToken
+	}
+
+	if err := usecase.refreshTokenRepository.Revoke(claims.ID); err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenNotFound) {
+			return ErrInvalidToken
+		}
+		return err
+	}
+	return nil
+}
+
+func (usecase *authUsecaseImpl) Authenticate(accessToken string) (entities.Principal, error) {
+	claims, err := usecase.tokens.parseAccess(accessToken)
+	if err != nil {
+		return entities.Principal{}, ErrInvalidToken
+	}
+
+	return entities.Principal{
+		UserId:   claims.UserId,
+		Email:    claims.Email,
+		Timezone: claims.Timezone,
+	}, nil
+}
+
+func (usecase *authUsecaseImpl) GetUser(id int) (entities.User, error) {
+	return usecase.userRepository.FindById(id)
+}
+
+func (usecase *authUsecaseImpl) UpdateProfile(id int, name string, timezone *string) (entities.User, error) {
+	if timezone != nil {
+		if _, err := date.LoadLocation(*timezone); err != nil {
+			return entities.User{}, ErrInvalidTimezone
+		}
+	}
+
+	user, err := usecase.userRepository.FindById(id)
+	if err != nil {
+		return entities.User{}, err
+	}
+	user.Name = name
+	user.Timezone = timezone
+	return usecase.userRepository.Update(user)
+}
+
+// issue signs a new token pair for user. When replacing is set, the stored
+// refresh token with that id is rotated out in the same step.
+func (usecase *authUsecaseImpl) issue(user entities.User, replacing string) (entities.TokenPair, error) {
+	accessToken, accessExpiresAt, err := usecase.tokens.signAccess(user)
+	if err != nil {
+		return entities.TokenPair{}, err
+	}
+
+	refreshToken, stored, err := usecase.tokens.signRefresh(user)
+	if err != nil {
+		return entities.TokenPair{}, err
+	}
+
+	if replacing == "" {
+		_, err = usecase.refreshTokenRepository.Save(stored)
+	} else {
+		err = usecase.refreshTokenRepository.Rotate(replacing, stored)
+	}
+	if err != nil {
+		return entities.TokenPair{}, err
+	}
+
+	return entities.TokenPair{
+		AccessToken:      accessToken,
+		AccessExpiresAt:  accessExpiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: stored.ExpiresAt,
+	}, nil
+}
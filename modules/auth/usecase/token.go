@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"time"
+	"todolist-v1/config"
+	"todolist-v1/modules/auth/entities"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	tokenTypeAccess  = "access"
+	tokenTypeRefresh = "refresh"
+)
+
+type tokenClaims struct {
+	UserId    int    `json:"-"`
+	Email     string `json:"email,omitempty"`
+	Timezone  string `json:"tz,omitempty"`
+	TokenType string `json:"typ"`
+	jwt.RegisteredClaims
+}
+
+type tokenIssuer struct {
+	issuer        string
+	accessSecret  []byte
+	refreshSecret []byte
+	accessTTL     time.Duration
+	refreshTTL    time.Duration
+}
+
+func newTokenIssuer(cfg *config.Config) *tokenIssuer {
+	return &tokenIssuer{
+		issuer:        cfg.Auth.Issuer,
+		accessSecret:  []byte(cfg.Auth.AccessTokenSecret),
+		refreshSecret: []byte(cfg.Auth.RefreshTokenSecret),
+		accessTTL:     cfg.Auth.AccessTokenTTL,
+		refreshTTL:    cfg.Auth.RefreshTokenTTL,
+	}
+}
+
+func (issuer *tokenIssuer) signAccess(user entities.User) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(issuer.accessTTL)
+
+	id, err := newTokenId()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	var timezone string
+	if user.Timezone != nil {
+		timezone = *user.Timezone
+	}
+	claims := tokenClaims{
+		Email:     user.Email,
+		Timezone:  timezone,
+		TokenType: tokenTypeAccess,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        id,
+			Issuer:    issuer.issuer,
+			Subject:   strconv.Itoa(user.Id),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(issuer.accessSecret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+func (issuer *tokenIssuer) signRefresh(user entities.User) (string, entities.RefreshToken, error) {
+	now := time.Now()
+	expiresAt := now.Add(issuer.refreshTTL)
+
+	id, err := newTokenId()
+	if err != nil {
+		return "", entities.RefreshToken{}, err
+	}
+
+	claims := tokenClaims{
+		TokenType: tokenTypeRefresh,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        id,
+			Issuer:    issuer.issuer,
+			Subject:   strconv.Itoa(user.Id),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(issuer.refreshSecret)
+	if err != nil {
+		return "", entities.RefreshToken{}, err
+	}
+
+	return signed, entities.RefreshToken{
+		Id:        id,
+		UserId:    user.Id,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (issuer *tokenIssuer) parseAccess(raw string) (*tokenClaims, error) {
+	return issuer.parse(raw, issuer.accessSecret, tokenTypeAccess)
+}
+
+func (issuer *tokenIssuer) parseRefresh(raw string) (*tokenClaims, error) {
+	return issuer.parse(raw, issuer.refreshSecret, tokenTypeRefresh)
+}
+
+func (issuer *tokenIssuer) parse(raw string, secret []byte, tokenType string) (*tokenClaims, error) {
+	claims := &tokenClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(token *jwt.Token) (any, error) {
+		return secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(issuer.issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.TokenType != tokenType {
+		return nil, errors.New("unexpected token type")
+	}
+
+	userId, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	claims.UserId = userId
+
+	return claims, nil
+}
+
+func newTokenId() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,31 @@
+package entities
+
+import (
+	activityEntities "todolist-v1/modules/activity/entities"
+	"todolist-v1/pkg/date"
+)
+
+// Views a calendar can be built for. A week starts on Monday.
+const (
+	ViewDay   = "day"
+	ViewWeek  = "week"
+	ViewMonth = "month"
+)
+
+// Calendar holds the activities taking place in [Start, End), one entry per
+// day, empty days included.
+type Calendar struct {
+	View  string
+	Start date.Date
+	End   date.Date
+	Days  []Day
+}
+
+// Day lists the occurrences on a day, along with how many there are of each
+// status.
+type Day struct {
+	Date        date.Date
+	Total       int
+	Counts      map[string]int
+	Occurrences []activityEntities.ActivityOccurrence
+}
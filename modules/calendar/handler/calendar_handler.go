@@ -0,0 +1,9 @@
+package handler
+
+import "github.com/gofiber/fiber/v2"
+
+type CalendarHandler interface {
+	Get(ctx *fiber.Ctx) error
+	FreeBusy(ctx *fiber.Ctx) error
+	RegisterRoutes()
+}
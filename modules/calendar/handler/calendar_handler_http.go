@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"errors"
+	"time"
+	authEntities "todolist-v1/modules/auth/entities"
+	"todolist-v1/modules/auth/middleware"
+	"todolist-v1/modules/calendar/entities"
+	"todolist-v1/modules/calendar/models"
+	"todolist-v1/modules/calendar/usecase"
+	"todolist-v1/pkg/date"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// defaultFreeBusyDays is how far free/busy looks ahead without a to.
+const defaultFreeBusyDays = 7
+
+type calendarHandlerHttp struct {
+	app            *fiber.App
+	usecase        usecase.CalendarUsecase
+	authMiddleware fiber.Handler
+}
+
+func NewCalendarHttpHandler(app *fiber.App, usecase usecase.CalendarUsecase, authMiddleware fiber.Handler) CalendarHandler {
+	return &calendarHandlerHttp{
+		app:            app,
+		usecase:        usecase,
+		authMiddleware: authMiddleware,
+	}
+}
+
+// Get returns the calendar of the view containing start, today by default,
+// with days taken in the caller's preferred timezone.
+func (handler *calendarHandlerHttp) Get(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return handler.badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	day := date.Of(time.Now().In(location))
+	if start := ctx.Query("start"); start != "" {
+		if day, err = date.Parse(start); err != nil {
+			return handler.badRequest(ctx, "Invalid start, expected YYYY-MM-DD")
+		}
+	}
+
+	calendar, err := handler.usecase.Get(principal.UserId, ctx.Query("view", entities.ViewWeek), day, location)
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        toCalendarResponse(calendar, location),
+		"status_code": fiber.StatusOK,
+		"message":     "Calendar retrieved successfully",
+	})
+}
+
+// FreeBusy returns when a user, the caller by default, is busy between from
+// and to, the coming week by default.
+func (handler *calendarHandlerHttp) FreeBusy(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return handler.badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	from := time.Now()
+	if value := ctx.Query("from"); value != "" {
+		if from, err = time.Parse(time.RFC3339, value); err != nil {
+			return handler.badRequest(ctx, "Invalid from, expected an RFC 3339 time")
+		}
+	}
+	to := from.AddDate(0, 0, defaultFreeBusyDays)
+	if value := ctx.Query("to"); value != "" {
+		if to, err = time.Parse(time.RFC3339, value); err != nil {
+			return handler.badRequest(ctx, "Invalid to, expected an RFC 3339 time")
+		}
+	}
+	subjectId := ctx.QueryInt("user_id", principal.UserId)
+
+	periods, err := handler.usecase.FreeBusy(principal.UserId, subjectId, from, to)
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	busy := make([]models.BusyPeriodResponse, 0, len(periods))
+	for _, period := range periods {
+		busy = append(busy, models.BusyPeriodResponse{
+			Start: period.Start.In(location),
+			End:   period.End.In(location),
+		})
+	}
+	return ctx.JSON(fiber.Map{
+		"data": models.FreeBusyResponse{
+			UserId: subjectId,
+			From:   from.In(location),
+			To:     to.In(location),
+			Busy:   busy,
+		},
+		"status_code": fiber.StatusOK,
+		"message":     "Free/busy retrieved successfully",
+	})
+}
+
+func (handler *calendarHandlerHttp) RegisterRoutes() {
+	canRead := middleware.RequireScope(authEntities.ScopeActivitiesRead)
+	handler.app.Get("/api/calendar", handler.authMiddleware, canRead, handler.Get)
+	handler.app.Get("/api/freebusy", handler.authMiddleware, canRead, handler.FreeBusy)
+}
+
+func (handler *calendarHandlerHttp) badRequest(ctx *fiber.Ctx, message string) error {
+	return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusBadRequest,
+		"message":     message,
+	})
+}
+
+func (handler *calendarHandlerHttp) fail(ctx *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, usecase.ErrInvalidView),
+		errors.Is(err, usecase.ErrInvalidRange):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, usecase.ErrForbidden):
+		status = fiber.StatusForbidden
+	}
+
+	return ctx.Status(status).JSON(fiber.Map{
+		"data":        nil,
+		"status_code": status,
+		"message":     err.Error(),
+	})
+}
+
+// toCalendarResponse renders the occurrence times in location.
+func toCalendarResponse(calendar entities.Calendar, location *time.Location) models.CalendarResponse {
+	response := models.CalendarResponse{
+		View:  calendar.View,
+		Start: calendar.Start,
+		End:   calendar.End,
+		Days:  make([]models.DayResponse, 0, len(calendar.Days)),
+	}
+	for _, day := range calendar.Days {
+		entries := make([]models.EntryResponse, 0, len(day.Occurrences))
+		for _, occurrence := range day.Occurrences {
+			entry := models.EntryResponse{
+				ActivityId: occurrence.Id,
+				Title:      occurrence.Title,
+				Category:   occurrence.Category,
+				Status:     occurrence.Status,
+				Priority:   occurrence.Priority,
+				OccursAt:   occurrence.OccursAt.In(location),
+				AllDay:     occurrence.AllDay,
+				Recurring:  occurrence.RecurrenceFrequency != nil,
+			}
+			if occurrence.EndAt != nil {
+				ends := entry.OccursAt.Add(occurrence.EndAt.Sub(occurrence.ActivityDate))
+				entry.EndsAt = &ends
+			}
+			entries = append(entries, entry)
+		}
+		response.Days = append(response.Days, models.DayResponse{
+			Date:    day.Date,
+			Total:   day.Total,
+			Counts:  day.Counts,
+			Entries: entries,
+		})
+	}
+	return response
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+	"todolist-v1/pkg/date"
+)
+
+type CalendarResponse struct {
+	View  string        `json:"view"`
+	Start date.Date     `json:"start"`
+	End   date.Date     `json:"end"`
+	Days  []DayResponse `json:"days"`
+}
+
+type DayResponse struct {
+	Date    date.Date       `json:"date"`
+	Total   int             `json:"total"`
+	Counts  map[string]int  `json:"counts"`
+	Entries []EntryResponse `json:"entries"`
+}
+
+// EntryResponse is one occurrence of an activity. OccursAt and EndsAt are
+// the times of this occurrence; a recurring event keeps its duration.
+type EntryResponse struct {
+	ActivityId int        `json:"activity_id"`
+	Title      string     `json:"title"`
+	Category   string     `json:"category"`
+	Status     string     `json:"status"`
+	Priority   string     `json:"priority"`
+	OccursAt   time.Time  `json:"occurs_at"`
+	EndsAt     *time.Time `json:"ends_at"`
+	AllDay     bool       `json:"all_day"`
+	Recurring  bool       `json:"recurring"`
+}
+
+type FreeBusyResponse struct {
+	UserId int                  `json:"user_id"`
+	From   time.Time            `json:"from"`
+	To     time.Time            `json:"to"`
+	Busy   []BusyPeriodResponse `json:"busy"`
+}
+
+type BusyPeriodResponse struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
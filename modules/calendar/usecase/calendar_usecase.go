@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"time"
+	activityEntities "todolist-v1/modules/activity/entities"
+	"todolist-v1/modules/calendar/entities"
+	"todolist-v1/pkg/date"
+)
+
+type CalendarUsecase interface {
+	// Get builds the calendar of the given view containing day, with days
+	// taken in location. Recurring activities appear on each day they take
+	// place.
+	Get(userId int, view string, day date.Date, location *time.Location) (entities.Calendar, error)
+	// FreeBusy returns the periods in [from, to) in which subjectId is busy
+	// with timed events, overlapping ones merged. Users may look up
+	// themselves and the members of their workspaces; titles are never
+	// revealed.
+	FreeBusy(userId int, subjectId int, from time.Time, to time.Time) ([]activityEntities.BusyPeriod, error)
+}
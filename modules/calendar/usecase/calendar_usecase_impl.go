@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+	activityEntities "todolist-v1/modules/activity/entities"
+	activityRepo "todolist-v1/modules/activity/repository"
+	"todolist-v1/modules/calendar/entities"
+	workspaceRepo "todolist-v1/modules/workspace/repository"
+	"todolist-v1/pkg/date"
+)
+
+var (
+	ErrInvalidView  = errors.New("view must be day, week or month")
+	ErrInvalidRange = errors.New("from must be before to, at most 92 days apart")
+	ErrForbidden    = errors.New("free/busy is only available for members of your workspaces")
+)
+
+// maxFreeBusyRange bounds the period looked up at once.
+const maxFreeBusyRange = 92 * 24 * time.Hour
+
+type calendarUsecaseImpl struct {
+	activityRepository  activityRepo.ActivityRepository
+	workspaceRepository workspaceRepo.WorkspaceRepository
+}
+
+func NewCalendarUsecase(activityRepository activityRepo.ActivityRepository, workspaceRepository workspaceRepo.WorkspaceRepository) CalendarUsecase {
+	return &calendarUsecaseImpl{
+		activityRepository:  activityRepository,
+		workspaceRepository: workspaceRepository,
+	}
+}
+
+func (usecase *calendarUsecaseImpl) Get(userId int, view string, day date.Date, location *time.Location) (entities.Calendar, error) {
+	first, last, err := bounds(view, day)
+	if err != nil {
+		return entities.Calendar{}, err
+	}
+	// All-day activities keep the day of their own timezone, which can lie
+	// up to a day away from the time they start in location. The range is
+	// widened accordingly and occurrences outside the view's days dropped.
+	from, to := first.AddDays(-1).In(location), last.AddDays(1).In(location)
+
+	occurrences, err := usecase.activityRepository.FindOccurrences(userId, from, to, location.String())
+	if err != nil {
+		return entities.Calendar{}, err
+	}
+	counts, err := usecase.activityRepository.CountOccurrences(userId, from, to, location.String())
+	if err != nil {
+		return entities.Calendar{}, err
+	}
+
+	calendar := entities.Calendar{View: view, Start: first, End: last}
+	index := make(map[date.Date]int)
+	for day := first; day < last; day = day.AddDays(1) {
+		index[day] = len(calendar.Days)
+		calendar.Days = append(calendar.Days, entities.Day{
+			Date:        day,
+			Counts:      make(map[string]int),
+			Occurrences: []activityEntities.ActivityOccurrence{},
+		})
+	}
+	for _, count := range counts {
+		if i, ok := index[count.Day]; ok {
+			calendar.Days[i].Counts[count.Status] = count.Count
+			calendar.Days[i].Total += count.Count
+		}
+	}
+	for _, occurrence := range occurrences {
+		if i, ok := index[occurrence.Day]; ok {
+			calendar.Days[i].Occurrences = append(calendar.Days[i].Occurrences, occurrence)
+		}
+	}
+	return calendar, nil
+}
+
+func (usecase *calendarUsecaseImpl) FreeBusy(userId int, subjectId int, from time.Time, to time.Time) ([]activityEntities.BusyPeriod, error) {
+	if !from.Before(to) || to.Sub(from) > maxFreeBusyRange {
+		return nil, ErrInvalidRange
+	}
+	if subjectId != userId {
+		shared, err := usecase.workspaceRepository.SharesWorkspace(userId, subjectId)
+		if err != nil {
+			return nil, err
+		}
+		if !shared {
+			return nil, ErrForbidden
+		}
+	}
+
+	periods, err := usecase.activityRepository.FindBusy(subjectId, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Periods come ordered by start; each one either extends the last
+	// merged period or starts a new one.
+	busy := []activityEntities.BusyPeriod{}
+	for _, period := range periods {
+		if last := len(busy) - 1; last >= 0 && !period.Start.After(busy[last].End) {
+			if period.End.After(busy[last].End) {
+				busy[last].End = period.End
+			}
+			continue
+		}
+		busy = append(busy, period)
+	}
+	return busy, nil
+}
+
+// bounds returns the first day of the view containing day and the day after
+// its last.
+func bounds(view string, day date.Date) (date.Date, date.Date, error) {
+	switch view {
+	case entities.ViewDay:
+		return day, day.AddDays(1), nil
+	case entities.ViewWeek:
+		sinceMonday := (int(day.In(time.UTC).Weekday()) + 6) % 7
+		first := day.AddDays(-sinceMonday)
+		return first, first.AddDays(7), nil
+	case entities.ViewMonth:
+		t := day.In(time.UTC)
+		first := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return date.Of(first), date.Of(first.AddDate(0, 1, 0)), nil
+	}
+	return "", "", ErrInvalidView
+}
@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"time"
+	activityEntities "todolist-v1/modules/activity/entities"
+)
+
+// Digest is the content of one digest email, with every date expressed in
+// the recipient's timezone.
+type Digest struct {
+	Name      string
+	Frequency string
+	Date      time.Time
+	Today     []activityEntities.Activity
+	Upcoming  []activityEntities.Activity
+	Overdue   []activityEntities.Activity
+}
+
+// Empty reports whether there is nothing to tell the recipient.
+func (digest Digest) Empty() bool {
+	return len(digest.Today) == 0 && len(digest.Upcoming) == 0 && len(digest.Overdue) == 0
+}
@@ -0,0 +1,29 @@
+package entities
+
+import "time"
+
+const (
+	FrequencyDaily  = "daily"
+	FrequencyWeekly = "weekly"
+)
+
+// DigestSubscription opts a user into a summary email of their activities.
+// It is sent at SendHour in the user's Timezone, every day or, for weekly
+// digests, on Weekday (0 = Sunday).
+type DigestSubscription struct {
+	UserId      int        `json:"user_id"      gorm:"column:user_id;primaryKey"`
+	Frequency   string     `json:"frequency"    gorm:"column:frequency;size:10;not null"`
+	Timezone    string     `json:"timezone"     gorm:"column:timezone;size:64;not null;default:UTC"`
+	SendHour    int        `json:"send_hour"    gorm:"column:send_hour;not null"`
+	Weekday     int        `json:"weekday"      gorm:"column:weekday;not null"`
+	LastSentOn  *time.Time `json:"last_sent_on" gorm:"column:last_sent_on;type:date"`
+	LockedUntil *time.Time `json:"-"            gorm:"column:locked_until"`
+	CreatedAt   time.Time  `json:"created_at"   gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time  `json:"updated_at"   gorm:"column:updated_at;autoUpdateTime"`
+
+	// Filled in from the user when due subscriptions are claimed.
+	Email string `json:"email" gorm:"column:email;->"`
+	Name  string `json:"name"  gorm:"column:name;->"`
+}
+
+func (DigestSubscription) TableName() string { return "digest_subscriptions" }
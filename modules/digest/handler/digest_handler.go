@@ -0,0 +1,10 @@
+package handler
+
+import "github.com/gofiber/fiber/v2"
+
+type DigestHandler interface {
+	Get(ctx *fiber.Ctx) error
+	Subscribe(ctx *fiber.Ctx) error
+	Unsubscribe(ctx *fiber.Ctx) error
+	RegisterRoutes()
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"errors"
+	"todolist-v1/modules/auth/middleware"
+	"todolist-v1/modules/digest/entities"
+	"todolist-v1/modules/digest/models"
+	"todolist-v1/modules/digest/repository"
+	"todolist-v1/modules/digest/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	defaultSendHour = 7
+	defaultWeekday  = 1
+)
+
+type digestHandlerHttp struct {
+	app            *fiber.App
+	usecase        usecase.DigestUsecase
+	authMiddleware fiber.Handler
+	validate       *validator.Validate
+}
+
+func NewDigestHttpHandler(app *fiber.App, usecase usecase.DigestUsecase, authMiddleware fiber.Handler) DigestHandler {
+	return &digestHandlerHttp{
+		app:            app,
+		usecase:        usecase,
+		authMiddleware: authMiddleware,
+		validate:       validator.New(),
+	}
+}
+
+func (handler *digestHandlerHttp) Get(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	subscription, err := handler.usecase.Get(principal.UserId)
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        toDigestSubscriptionResponse(subscription),
+		"status_code": fiber.StatusOK,
+		"message":     "Digest subscription retrieved successfully",
+	})
+}
+
+func (handler *digestHandlerHttp) Subscribe(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	var request models.DigestSubscriptionRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return handler.badRequest(ctx, "Cannot parse JSON")
+	}
+	if err := handler.validate.Struct(request); err != nil {
+		return handler.badRequest(ctx, err.Error())
+	}
+
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return handler.badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	subscription := entities.DigestSubscription{
+		Frequency: request.Frequency,
+		Timezone:  location.String(),
+		SendHour:  defaultSendHour,
+		Weekday:   defaultWeekday,
+	}
+	if request.Timezone != "" {
+		subscription.Timezone = request.Timezone
+	}
+	if request.SendHour != nil {
+		subscription.SendHour = *request.SendHour
+	}
+	if request.Weekday != nil {
+		subscription.Weekday = *request.Weekday
+	}
+
+	subscription, err = handler.usecase.Subscribe(principal.UserId, subscription)
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        toDigestSubscriptionResponse(subscription),
+		"status_code": fiber.StatusOK,
+		"message":     "Digest subscription saved successfully",
+	})
+}
+
+func (handler *digestHandlerHttp) Unsubscribe(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	if err := handler.usecase.Unsubscribe(principal.UserId); err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusOK,
+		"message":     "Digest subscription deleted successfully",
+	})
+}
+
+func (handler *digestHandlerHttp) RegisterRoutes() {
+	digest := handler.app.Group("/api/digest", handler.authMiddleware, middleware.RequireSession())
+	digest.Get("/", handler.Get)
+	digest.Put("/", handler.Subscribe)
+	digest.Delete("/", handler.Unsubscribe)
+}
+
+func (handler *digestHandlerHttp) badRequest(ctx *fiber.Ctx, message string) error {
+	return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusBadRequest,
+		"message":     message,
+	})
+}
+
+func (handler *digestHandlerHttp) fail(ctx *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, repository.ErrSubscriptionNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, usecase.ErrInvalidTimezone):
+		status = fiber.StatusBadRequest
+	}
+
+	return ctx.Status(status).JSON(fiber.Map{
+		"data":        nil,
+		"status_code": status,
+		"message":     err.Error(),
+	})
+}
+
+func toDigestSubscriptionResponse(subscription entities.DigestSubscription) models.DigestSubscriptionResponse {
+	return models.DigestSubscriptionResponse{
+		Frequency:  subscription.Frequency,
+		Timezone:   subscription.Timezone,
+		SendHour:   subscription.SendHour,
+		Weekday:    subscription.Weekday,
+		LastSentOn: subscription.LastSentOn,
+		CreatedAt:  subscription.CreatedAt,
+		UpdatedAt:  subscription.UpdatedAt,
+	}
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+type DigestSubscriptionRequest struct {
+	Frequency string `json:"frequency" validate:"required,oneof=daily weekly"`
+	// Timezone is an IANA name such as Europe/Berlin. It defaults to the
+	// caller's preferred timezone.
+	Timezone string `json:"timezone" validate:"omitempty,max=64"`
+	SendHour *int   `json:"send_hour" validate:"omitempty,min=0,max=23"`
+	// Weekday is the day weekly digests are sent on, 0 being Sunday. It
+	// defaults to Monday.
+	Weekday *int `json:"weekday" validate:"omitempty,min=0,max=6"`
+}
+
+type DigestSubscriptionResponse struct {
+	Frequency  string     `json:"frequency"`
+	Timezone   string     `json:"timezone"`
+	SendHour   int        `json:"send_hour"`
+	Weekday    int        `json:"weekday"`
+	LastSentOn *time.Time `json:"last_sent_on"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"errors"
+	"time"
+	"todolist-v1/modules/digest/entities"
+)
+
+var ErrSubscriptionNotFound = errors.New("digest subscription not found")
+
+type DigestSubscriptionRepository interface {
+	FindByUserId(userId int) (entities.DigestSubscription, error)
+	// Save creates or replaces the user's subscription.
+	Save(subscription entities.DigestSubscription) (entities.DigestSubscription, error)
+	Delete(userId int) error
+	// ClaimDue locks up to limit subscriptions whose digest is due in their
+	// local time for lease, so that concurrent callers never claim the same
+	// subscription twice.
+	ClaimDue(limit int, lease time.Duration) ([]entities.DigestSubscription, error)
+	// MarkSent records that the digest for the local date on was sent and
+	// releases the claim.
+	MarkSent(userId int, on time.Time) error
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"errors"
+	"time"
+	"todolist-v1/modules/digest/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// localNow is the current wall clock time in the subscriber's timezone.
+const localNow = "(NOW() AT TIME ZONE digest_subscriptions.timezone)"
+
+type digestSubscriptionRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewDigestSubscriptionRepository(db *gorm.DB) DigestSubscriptionRepository {
+	return &digestSubscriptionRepositoryImpl{DB: db}
+}
+
+func (repository *digestSubscriptionRepositoryImpl) FindByUserId(userId int) (entities.DigestSubscription, error) {
+	var subscription entities.DigestSubscription
+	if err := repository.DB.Where("user_id = ?", userId).First(&subscription).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.DigestSubscription{}, ErrSubscriptionNotFound
+		}
+		return entities.DigestSubscription{}, err
+	}
+	return subscription, nil
+}
+
+func (repository *digestSubscriptionRepositoryImpl) Save(subscription entities.DigestSubscription) (entities.DigestSubscription, error) {
+	err := repository.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"frequency", "timezone", "send_hour", "weekday", "updated_at"}),
+	}).Create(&subscription).Error
+	if err != nil {
+		return entities.DigestSubscription{}, err
+	}
+	return repository.FindByUserId(subscription.UserId)
+}
+
+func (repository *digestSubscriptionRepositoryImpl) Delete(userId int) error {
+	result := repository.DB.Where("user_id = ?", userId).Delete(&entities.DigestSubscription{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+func (repository *digestSubscriptionRepositoryImpl) ClaimDue(limit int, lease time.Duration) ([]entities.DigestSubscription, error) {
+	var userIds []int
+	err := repository.DB.Raw(`
+		UPDATE digest_subscriptions SET locked_until = NOW() + make_interval(secs => ?)
+		WHERE user_id IN (
+			SELECT user_id FROM digest_subscriptions
+			WHERE (locked_until IS NULL OR locked_until <= NOW())
+				AND EXTRACT(HOUR FROM `+localNow+`) >= send_hour
+				AND (last_sent_on IS NULL OR last_sent_on < `+localNow+`::date)
+				AND (frequency = 'daily' OR EXTRACT(DOW FROM `+localNow+`) = weekday)
+			ORDER BY user_id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING user_id`, lease.Seconds(), limit).Scan(&userIds).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(userIds) == 0 {
+		return nil, nil
+	}
+
+	var subscriptions []entities.DigestSubscription
+	err = repository.DB.
+		Select("digest_subscriptions.*, users.email, users.name").
+		Joins("JOIN users ON users.id = digest_subscriptions.user_id").
+		Where("digest_subscriptions.user_id IN ?", userIds).
+		Order("digest_subscriptions.user_id").
+		Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (repository *digestSubscriptionRepositoryImpl) MarkSent(userId int, on time.Time) error {
+	return repository.DB.Model(&entities.DigestSubscription{}).Where("user_id = ?", userId).Updates(map[string]any{
+		"last_sent_on": on.Format(time.DateOnly),
+		"locked_until": nil,
+	}).Error
+}
@@ -0,0 +1,18 @@
+package usecase
+
+import "todolist-v1/modules/digest/entities"
+
+// DigestUsecase manages each user's digest subscription and sends the digests
+// that are due.
+type DigestUsecase interface {
+	Get(userId int) (entities.DigestSubscription, error)
+	Subscribe(userId int, subscription entities.DigestSubscription) (entities.DigestSubscription, error)
+	Unsubscribe(userId int) error
+	// Build collects the digest of the given user as of now in their
+	// timezone.
+	Build(subscription entities.DigestSubscription) (entities.Digest, error)
+	// DispatchDue sends the digests that are due in their subscribers' local
+	// time and returns how many were sent. It is safe to run on several
+	// replicas at once. Digests with nothing to report are skipped.
+	DispatchDue() (int, error)
+}
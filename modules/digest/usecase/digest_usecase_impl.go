@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"todolist-v1/config"
+	activityEntities "todolist-v1/modules/activity/entities"
+	activityRepo "todolist-v1/modules/activity/repository"
+	"todolist-v1/modules/digest/entities"
+	"todolist-v1/modules/digest/repository"
+	"todolist-v1/pkg/date"
+	"todolist-v1/pkg/notifier"
+)
+
+var ErrInvalidTimezone = errors.New("unknown timezone")
+
+// claimLease is how long a claimed digest waits before another attempt when
+// its delivery failed or the replica sending it crashed.
+const claimLease = 15 * time.Minute
+
+// upcomingDays is how far past today the digest looks ahead.
+const upcomingDays = 7
+
+type digestUsecaseImpl struct {
+	subscriptionRepository repository.DigestSubscriptionRepository
+	activityRepository     activityRepo.ActivityRepository
+	notifier               notifier.Notifier
+	batchSize              int
+}
+
+func NewDigestUsecase(subscriptionRepository repository.DigestSubscriptionRepository, activityRepository activityRepo.ActivityRepository, notifier notifier.Notifier, cfg *config.Config) DigestUsecase {
+	return &digestUsecaseImpl{
+		subscriptionRepository: subscriptionRepository,
+		activityRepository:     activityRepository,
+		notifier:               notifier,
+		batchSize:              cfg.Digests.BatchSize,
+	}
+}
+
+func (usecase *digestUsecaseImpl) Get(userId int) (entities.DigestSubscription, error) {
+	return usecase.subscriptionRepository.FindByUserId(userId)
+}
+
+func (usecase *digestUsecaseImpl) Subscribe(userId int, subscription entities.DigestSubscription) (entities.DigestSubscription, error) {
+	if _, err := date.LoadLocation(subscription.Timezone); err != nil {
+		return entities.DigestSubscription{}, ErrInvalidTimezone
+	}
+
+	return usecase.subscriptionRepository.Save(entities.DigestSubscription{
+		UserId:    userId,
+		Frequency: subscription.Frequency,
+		Timezone:  subscription.Timezone,
+		SendHour:  subscription.SendHour,
+		Weekday:   subscription.Weekday,
+	})
+}
+
+func (usecase *digestUsecaseImpl) Unsubscribe(userId int) error {
+	return usecase.subscriptionRepository.Delete(userId)
+}
+
+func (usecase *digestUsecaseImpl) Build(subscription entities.DigestSubscription) (entities.Digest, error) {
+	loc, err := date.LoadLocation(subscription.Timezone)
+	if err != nil {
+		return entities.Digest{}, ErrInvalidTimezone
+	}
+
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	tomorrow := today.AddDate(0, 0, 1)
+
+	digest := entities.Digest{Name: subscription.Name, Frequency: subscription.Frequency, Date: today}
+	if digest.Today, err = usecase.activityRepository.FindBetween(subscription.UserId, today, tomorrow); err != nil {
+		return entities.Digest{}, err
+	}
+	if digest.Upcoming, err = usecase.activityRepository.FindBetween(subscription.UserId, tomorrow, tomorrow.AddDate(0, 0, upcomingDays)); err != nil {
+		return entities.Digest{}, err
+	}
+	if digest.Overdue, err = usecase.activityRepository.FindOverdue(subscription.UserId, today); err != nil {
+		return entities.Digest{}, err
+	}
+
+	for _, activities := range [][]activityEntities.Activity{digest.Today, digest.Upcoming, digest.Overdue} {
+		for i := range activities {
+			activities[i].ActivityDate = activities[i].ActivityDate.In(loc)
+		}
+	}
+	return digest, nil
+}
+
+func (usecase *digestUsecaseImpl) DispatchDue() (int, error) {
+	subscriptions, err := usecase.subscriptionRepository.ClaimDue(usecase.batchSize, claimLease)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var failures []error
+	for _, subscription := range subscriptions {
+		delivered, err := usecase.deliver(subscription)
+		if err != nil {
+			// The claim is left to expire so the digest is retried later.
+			failures = append(failures, fmt.Errorf("digest for user %d: %w", subscription.UserId, err))
+			continue
+		}
+		if delivered {
+			sent++
+		}
+	}
+	return sent, errors.Join(failures...)
+}
+
+// deliver sends the digest of one subscription unless it is empty and
+// records it as sent for the subscriber's local date.
+func (usecase *digestUsecaseImpl) deliver(subscription entities.DigestSubscription) (bool, error) {
+	digest, err := usecase.Build(subscription)
+	if err != nil {
+		return false, err
+	}
+
+	if !digest.Empty() {
+		text, html, err := render(digest)
+		if err != nil {
+			return false, err
+		}
+		err = usecase.notifier.Notify(notifier.Notification{
+			Kind:     "digest",
+			To:       subscription.Email,
+			Subject:  fmt.Sprintf("Your %s digest for %s", digest.Frequency, digest.Date.Format("Mon, 02 Jan")),
+			Body:     text,
+			HTMLBody: html,
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if err := usecase.subscriptionRepository.MarkSent(subscription.UserId, digest.Date); err != nil {
+		return false, err
+	}
+	return !digest.Empty(), nil
+}
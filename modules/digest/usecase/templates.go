@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"bytes"
+	"embed"
+	htmlTemplate "html/template"
+	textTemplate "text/template"
+	"time"
+	"todolist-v1/modules/digest/entities"
+)
+
+//go:embed templates
+var templateFiles embed.FS
+
+var templateFuncs = map[string]any{
+	"date":  func(t time.Time) string { return t.Format("Mon 02 Jan 15:04") },
+	"clock": func(t time.Time) string { return t.Format("15:04") },
+}
+
+var (
+	textDigest = textTemplate.Must(textTemplate.New("digest.txt.tmpl").Funcs(templateFuncs).
+			ParseFS(templateFiles, "templates/digest.txt.tmpl"))
+	htmlDigest = htmlTemplate.Must(htmlTemplate.New("digest.html.tmpl").Funcs(templateFuncs).
+			ParseFS(templateFiles, "templates/digest.html.tmpl"))
+)
+
+// render produces the text and HTML bodies of a digest.
+func render(digest entities.Digest) (string, string, error) {
+	var text, html bytes.Buffer
+	if err := textDigest.Execute(&text, digest); err != nil {
+		return "", "", err
+	}
+	if err := htmlDigest.Execute(&html, digest); err != nil {
+		return "", "", err
+	}
+	return text.String(), html.String(), nil
+}
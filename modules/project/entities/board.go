@@ -0,0 +1,15 @@
+package entities
+
+import activityEntities "todolist-v1/modules/activity/entities"
+
+// Board shows the activities of a project in one column per status, each in
+// its manual order.
+type Board struct {
+	Project Project
+	Columns []Column
+}
+
+type Column struct {
+	Status     string
+	Activities []activityEntities.Activity
+}
@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+// Project groups activities into a list shown as a board. Personal projects
+// belong to their owner, workspace projects to every member.
+type Project struct {
+	Id          int       `json:"id"           gorm:"column:id;primaryKey;autoIncrement"`
+	OwnerId     int       `json:"owner_id"     gorm:"column:owner_id;not null"`
+	WorkspaceId *int      `json:"workspace_id" gorm:"column:workspace_id"`
+	Name        string    `json:"name"         gorm:"column:name;size:100;not null"`
+	CreatedAt   time.Time `json:"created_at"   gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at"   gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (Project) TableName() string { return "projects" }
@@ -0,0 +1,259 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+	"time"
+	authEntities "todolist-v1/modules/auth/entities"
+	"todolist-v1/modules/auth/middleware"
+	"todolist-v1/modules/project/entities"
+	"todolist-v1/modules/project/models"
+	"todolist-v1/modules/project/repository"
+	"todolist-v1/modules/project/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type projectHandlerHttp struct {
+	app            *fiber.App
+	usecase        usecase.ProjectUsecase
+	authMiddleware fiber.Handler
+	validate       *validator.Validate
+}
+
+func NewProjectHttpHandler(app *fiber.App, usecase usecase.ProjectUsecase, authMiddleware fiber.Handler) ProjectHandler {
+	return &projectHandlerHttp{
+		app:            app,
+		usecase:        usecase,
+		authMiddleware: authMiddleware,
+		validate:       validator.New(),
+	}
+}
+
+func (handler *projectHandlerHttp) GetAll(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	projects, err := handler.usecase.GetAll(principal.UserId)
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	projectResponses := make([]models.ProjectResponse, 0, len(projects))
+	for _, project := range projects {
+		projectResponses = append(projectResponses, toProjectResponse(project))
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        projectResponses,
+		"status_code": fiber.StatusOK,
+		"message":     "Projects retrieved successfully",
+	})
+}
+
+func (handler *projectHandlerHttp) Get(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return handler.invalidId(ctx)
+	}
+
+	project, err := handler.usecase.Get(principal.UserId, id)
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        toProjectResponse(project),
+		"status_code": fiber.StatusOK,
+		"message":     "Project retrieved successfully",
+	})
+}
+
+func (handler *projectHandlerHttp) Create(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	var request models.ProjectCreateRequest
+	if err := handler.parse(ctx, &request); err != nil {
+		return handler.badRequest(ctx, err.Error())
+	}
+
+	project, err := handler.usecase.Create(principal.UserId, entities.Project{
+		Name:        request.Name,
+		WorkspaceId: request.WorkspaceId,
+	})
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data":        toProjectResponse(project),
+		"status_code": fiber.StatusCreated,
+		"message":     "Project created successfully",
+	})
+}
+
+func (handler *projectHandlerHttp) Update(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return handler.invalidId(ctx)
+	}
+
+	var request models.ProjectUpdateRequest
+	if err := handler.parse(ctx, &request); err != nil {
+		return handler.badRequest(ctx, err.Error())
+	}
+
+	project, err := handler.usecase.Update(principal.UserId, id, entities.Project{Name: request.Name})
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        toProjectResponse(project),
+		"status_code": fiber.StatusOK,
+		"message":     "Project updated successfully",
+	})
+}
+
+func (handler *projectHandlerHttp) Delete(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return handler.invalidId(ctx)
+	}
+
+	if err := handler.usecase.Delete(principal.UserId, id); err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusOK,
+		"message":     "Project deleted successfully",
+	})
+}
+
+// GetBoard returns the project's activities in one column per status, with
+// dates rendered in the caller's preferred timezone.
+func (handler *projectHandlerHttp) GetBoard(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return handler.badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return handler.invalidId(ctx)
+	}
+
+	board, err := handler.usecase.GetBoard(principal.UserId, id)
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        toBoardResponse(board, location),
+		"status_code": fiber.StatusOK,
+		"message":     "Board retrieved successfully",
+	})
+}
+
+func (handler *projectHandlerHttp) RegisterRoutes() {
+	canRead := middleware.RequireScope(authEntities.ScopeActivitiesRead)
+	canWrite := middleware.RequireScope(authEntities.ScopeActivitiesWrite)
+
+	projects := handler.app.Group("/api/projects", handler.authMiddleware)
+	projects.Get("/", canRead, handler.GetAll)
+	projects.Post("/", canWrite, handler.Create)
+	projects.Get("/:id", canRead, handler.Get)
+	projects.Put("/:id", canWrite, handler.Update)
+	projects.Delete("/:id", canWrite, handler.Delete)
+	projects.Get("/:id/board", canRead, handler.GetBoard)
+}
+
+// parse decodes the request body into request and validates it.
+func (handler *projectHandlerHttp) parse(ctx *fiber.Ctx, request any) error {
+	if err := ctx.BodyParser(request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Cannot parse JSON")
+	}
+	return handler.validate.Struct(request)
+}
+
+func (handler *projectHandlerHttp) badRequest(ctx *fiber.Ctx, message string) error {
+	return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusBadRequest,
+		"message":     message,
+	})
+}
+
+func (handler *projectHandlerHttp) invalidId(ctx *fiber.Ctx) error {
+	return handler.badRequest(ctx, "Invalid ID")
+}
+
+func (handler *projectHandlerHttp) fail(ctx *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, repository.ErrProjectNotFound),
+		errors.Is(err, usecase.ErrWorkspaceNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, usecase.ErrForbidden):
+		status = fiber.StatusForbidden
+	}
+
+	return ctx.Status(status).JSON(fiber.Map{
+		"data":        nil,
+		"status_code": status,
+		"message":     err.Error(),
+	})
+}
+
+func toProjectResponse(project entities.Project) models.ProjectResponse {
+	return models.ProjectResponse{
+		Id:          project.Id,
+		OwnerId:     project.OwnerId,
+		WorkspaceId: project.WorkspaceId,
+		Name:        project.Name,
+		CreatedAt:   project.CreatedAt,
+		UpdatedAt:   project.UpdatedAt,
+	}
+}
+
+func toBoardResponse(board entities.Board, location *time.Location) models.BoardResponse {
+	response := models.BoardResponse{
+		Project: toProjectResponse(board.Project),
+		Columns: make([]models.ColumnResponse, 0, len(board.Columns)),
+	}
+	for _, column := range board.Columns {
+		cards := make([]models.CardResponse, 0, len(column.Activities))
+		for _, activity := range column.Activities {
+			card := models.CardResponse{
+				ActivityId:   activity.Id,
+				Title:        activity.Title,
+				Category:     activity.Category,
+				Priority:     activity.Priority,
+				ActivityDate: activity.ActivityDate.In(location),
+				CommentCount: activity.CommentCount,
+			}
+			if activity.DueAt != nil {
+				due := activity.DueAt.In(location)
+				card.DueAt = &due
+			}
+			if activity.Rank != nil {
+				card.Rank = *activity.Rank
+			}
+			cards = append(cards, card)
+		}
+		response.Columns = append(response.Columns, models.ColumnResponse{
+			Status: column.Status,
+			Cards:  cards,
+		})
+	}
+	return response
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+type ProjectCreateRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	WorkspaceId *int   `json:"workspace_id"`
+}
+
+type ProjectUpdateRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type ProjectResponse struct {
+	Id          int       `json:"id"`
+	OwnerId     int       `json:"owner_id"`
+	WorkspaceId *int      `json:"workspace_id"`
+	Name        string    `json:"name"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type BoardResponse struct {
+	Project ProjectResponse  `json:"project"`
+	Columns []ColumnResponse `json:"columns"`
+}
+
+type ColumnResponse struct {
+	Status string         `json:"status"`
+	Cards  []CardResponse `json:"cards"`
+}
+
+// CardResponse is an activity on a board, in the column's order.
+type CardResponse struct {
+	ActivityId   int        `json:"activity_id"`
+	Title        string     `json:"title"`
+	Category     string     `json:"category"`
+	Priority     string     `json:"priority"`
+	ActivityDate time.Time  `json:"activity_date"`
+	DueAt        *time.Time `json:"due_at"`
+	Rank         string     `json:"rank"`
+	CommentCount int        `json:"comment_count"`
+}
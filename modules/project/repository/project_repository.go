@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"errors"
+	"todolist-v1/modules/project/entities"
+)
+
+var ErrProjectNotFound = errors.New("project not found")
+
+// ProjectRepository finds projects for a user: their personal projects and
+// those of the workspaces they are a member of.
+type ProjectRepository interface {
+	FindAllForUser(userId int) ([]entities.Project, error)
+	FindByIdForUser(userId int, id int) (entities.Project, error)
+	Save(project entities.Project) (entities.Project, error)
+	Update(id int, project entities.Project) (entities.Project, error)
+	Delete(id int) error
+}
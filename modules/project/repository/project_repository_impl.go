@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"errors"
+	"todolist-v1/modules/project/entities"
+
+	"gorm.io/gorm"
+)
+
+type projectRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewProjectRepository(db *gorm.DB) ProjectRepository {
+	return &projectRepositoryImpl{DB: db}
+}
+
+func (repository *projectRepositoryImpl) visibleTo(userId int) *gorm.DB {
+	return repository.DB.Where(
+		"(projects.workspace_id IS NULL AND projects.owner_id = ?) OR "+
+			"projects.workspace_id IN (SELECT workspace_id FROM workspace_members WHERE user_id = ?)",
+		userId, userId,
+	)
+}
+
+func (repository *projectRepositoryImpl) FindAllForUser(userId int) ([]entities.Project, error) {
+	var projects []entities.Project
+	if err := repository.visibleTo(userId).Order("projects.name, projects.id").Find(&projects).Error; err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+func (repository *projectRepositoryImpl) FindByIdForUser(userId int, id int) (entities.Project, error) {
+	var project entities.Project
+	if err := repository.visibleTo(userId).Where("projects.id = ?", id).First(&project).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.Project{}, ErrProjectNotFound
+		}
+		return entities.Project{}, err
+	}
+	return project, nil
+}
+
+func (repository *projectRepositoryImpl) Save(project entities.Project) (entities.Project, error) {
+	if err := repository.DB.Create(&project).Error; err != nil {
+		return entities.Project{}, err
+	}
+	return project, nil
+}
+
+func (repository *projectRepositoryImpl) Update(id int, project entities.Project) (entities.Project, error) {
+	result := repository.DB.Model(&entities.Project{}).Where("id = ?", id).Update("name", project.Name)
+	if result.Error != nil {
+		return entities.Project{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.Project{}, ErrProjectNotFound
+	}
+
+	var updated entities.Project
+	if err := repository.DB.First(&updated, id).Error; err != nil {
+		return entities.Project{}, err
+	}
+	return updated, nil
+}
+
+func (repository *projectRepositoryImpl) Delete(id int) error {
+	result := repository.DB.Delete(&entities.Project{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrProjectNotFound
+	}
+	return nil
+}
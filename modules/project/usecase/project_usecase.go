@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"errors"
+	"todolist-v1/modules/project/entities"
+)
+
+var (
+	ErrForbidden         = errors.New("insufficient permission for this project")
+	ErrWorkspaceNotFound = errors.New("workspace not found")
+)
+
+// ProjectUsecase manages projects and their boards. Every member of a
+// workspace sees its projects; changing them needs the right to write
+// activities in the workspace. Personal projects are only visible to their
+// owner.
+type ProjectUsecase interface {
+	GetAll(userId int) ([]entities.Project, error)
+	Get(userId int, id int) (entities.Project, error)
+	Create(userId int, project entities.Project) (entities.Project, error)
+	Update(userId int, id int, project entities.Project) (entities.Project, error)
+	// Delete removes the project; its activities are kept outside any
+	// project.
+	Delete(userId int, id int) error
+	GetBoard(userId int, id int) (entities.Board, error)
+}
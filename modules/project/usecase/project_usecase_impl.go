@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"errors"
+	activityEntities "todolist-v1/modules/activity/entities"
+	activityRepo "todolist-v1/modules/activity/repository"
+	"todolist-v1/modules/project/entities"
+	"todolist-v1/modules/project/repository"
+	workspaceRepo "todolist-v1/modules/workspace/repository"
+)
+
+// boardColumns are the statuses shown on a board, in order.
+var boardColumns = []string{
+	activityEntities.StatusNew,
+	activityEntities.StatusInProgress,
+	activityEntities.StatusDone,
+	activityEntities.StatusExpired,
+}
+
+type projectUsecaseImpl struct {
+	projectRepository   repository.ProjectRepository
+	activityRepository  activityRepo.ActivityRepository
+	workspaceRepository workspaceRepo.WorkspaceRepository
+}
+
+func NewProjectUsecase(projectRepository repository.ProjectRepository, activityRepository activityRepo.ActivityRepository, workspaceRepository workspaceRepo.WorkspaceRepository) ProjectUsecase {
+	return &projectUsecaseImpl{
+		projectRepository:   projectRepository,
+		activityRepository:  activityRepository,
+		workspaceRepository: workspaceRepository,
+	}
+}
+
+func (usecase *projectUsecaseImpl) GetAll(userId int) ([]entities.Project, error) {
+	return usecase.projectRepository.FindAllForUser(userId)
+}
+
+func (usecase *projectUsecaseImpl) Get(userId int, id int) (entities.Project, error) {
+	return usecase.projectRepository.FindByIdForUser(userId, id)
+}
+
+func (usecase *projectUsecaseImpl) Create(userId int, project entities.Project) (entities.Project, error) {
+	project.OwnerId = userId
+	if err := usecase.checkWrite(userId, project); err != nil {
+		return entities.Project{}, err
+	}
+	return usecase.projectRepository.Save(project)
+}
+
+func (usecase *projectUsecaseImpl) Update(userId int, id int, project entities.Project) (entities.Project, error) {
+	current, err := usecase.projectRepository.FindByIdForUser(userId, id)
+	if err != nil {
+		return entities.Project{}, err
+	}
+	if err := usecase.checkWrite(userId, current); err != nil {
+		return entities.Project{}, err
+	}
+	return usecase.projectRepository.Update(id, project)
+}
+
+func (usecase *projectUsecaseImpl) Delete(userId int, id int) error {
+	current, err := usecase.projectRepository.FindByIdForUser(userId, id)
+	if err != nil {
+		return err
+	}
+	if err := usecase.checkWrite(userId, current); err != nil {
+		return err
+	}
+	return usecase.projectRepository.Delete(id)
+}
+
+func (usecase *projectUsecaseImpl) GetBoard(userId int, id int) (entities.Board, error) {
+	project, err := usecase.projectRepository.FindByIdForUser(userId, id)
+	if err != nil {
+		return entities.Board{}, err
+	}
+	activities, err := usecase.activityRepository.FindAll(userId, activityEntities.ActivityFilter{
+		ProjectId: &id,
+		Sort:      activityEntities.SortRank,
+	})
+	if err != nil {
+		return entities.Board{}, err
+	}
+
+	board := entities.Board{Project: project}
+	columns := make(map[string]int, len(boardColumns))
+	for i, status := range boardColumns {
+		columns[status] = i
+		board.Columns = append(board.Columns, entities.Column{
+			Status:     status,
+			Activities: []activityEntities.Activity{},
+		})
+	}
+	for _, activity := range activities {
+		i := columns[activity.Status]
+		board.Columns[i].Activities = append(board.Columns[i].Activities, activity)
+	}
+	return board, nil
+}
+
+// checkWrite allows the owner of a personal project and members who may
+// write activities in the workspace of a workspace project.
+func (usecase *projectUsecaseImpl) checkWrite(userId int, project entities.Project) error {
+	if project.WorkspaceId == nil {
+		if project.OwnerId != userId {
+			return ErrForbidden
+		}
+		return nil
+	}
+
+	member, err := usecase.workspaceRepository.FindMember(*project.WorkspaceId, userId)
+	if errors.Is(err, workspaceRepo.ErrMemberNotFound) {
+		return ErrWorkspaceNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if !member.CanWriteActivities() {
+		return ErrForbidden
+	}
+	return nil
+}
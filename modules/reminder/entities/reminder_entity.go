@@ -0,0 +1,28 @@
+package entities
+
+import "time"
+
+// Reminder notifies its user about an activity, either OffsetMinutes before
+// the activity's date or at the absolute time RemindAt. Exactly one of the
+// two is set.
+type Reminder struct {
+	Id            int        `json:"id"             gorm:"column:id;primaryKey;autoIncrement"`
+	ActivityId    int        `json:"activity_id"    gorm:"column:activity_id;not null"`
+	UserId        int        `json:"user_id"        gorm:"column:user_id;not null"`
+	OffsetMinutes *int       `json:"offset_minutes" gorm:"column:offset_minutes"`
+	RemindAt      *time.Time `json:"remind_at"      gorm:"column:remind_at"`
+	Attempts      int        `json:"attempts"       gorm:"column:attempts;not null;default:0"`
+	LockedUntil   *time.Time `json:"-"              gorm:"column:locked_until"`
+	LastError     *string    `json:"last_error"     gorm:"column:last_error"`
+	SentAt        *time.Time `json:"sent_at"        gorm:"column:sent_at"`
+	FailedAt      *time.Time `json:"failed_at"      gorm:"column:failed_at"`
+	CreatedAt     time.Time  `json:"created_at"     gorm:"column:created_at;autoCreateTime"`
+
+	// Filled in from the activity and the user when reminders are read.
+	DueAt         time.Time `json:"due_at"         gorm:"column:due_at;->"`
+	ActivityTitle string    `json:"activity_title" gorm:"column:activity_title;->"`
+	ActivityDate  time.Time `json:"activity_date"  gorm:"column:activity_date;->"`
+	Email         string    `json:"email"          gorm:"column:email;->"`
+}
+
+func (Reminder) TableName() string { return "reminders" }
@@ -0,0 +1,10 @@
+package handler
+
+import "github.com/gofiber/fiber/v2"
+
+type ReminderHandler interface {
+	GetAll(ctx *fiber.Ctx) error
+	Create(ctx *fiber.Ctx) error
+	Delete(ctx *fiber.Ctx) error
+	RegisterRoutes()
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+	activityRepo "todolist-v1/modules/activity/repository"
+	authEntities "todolist-v1/modules/auth/entities"
+	"todolist-v1/modules/auth/middleware"
+	"todolist-v1/modules/reminder/entities"
+	"todolist-v1/modules/reminder/models"
+	"todolist-v1/modules/reminder/repository"
+	"todolist-v1/modules/reminder/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type reminderHandlerHttp struct {
+	app            *fiber.App
+	usecase        usecase.ReminderUsecase
+	authMiddleware fiber.Handler
+	validate       *validator.Validate
+}
+
+func NewReminderHttpHandler(app *fiber.App, usecase usecase.ReminderUsecase, authMiddleware fiber.Handler) ReminderHandler {
+	return &reminderHandlerHttp{
+		app:            app,
+		usecase:        usecase,
+		authMiddleware: authMiddleware,
+		validate:       validator.New(),
+	}
+}
+
+func (handler *reminderHandlerHttp) GetAll(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	activityId, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return handler.badRequest(ctx, "Invalid ID")
+	}
+
+	reminders, err := handler.usecase.GetAll(principal.UserId, activityId)
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	reminderResponses := make([]models.ReminderResponse, 0, len(reminders))
+	for _, reminder := range reminders {
+		reminderResponses = append(reminderResponses, toReminderResponse(reminder))
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        reminderResponses,
+		"status_code": fiber.StatusOK,
+		"message":     "Reminders retrieved successfully",
+	})
+}
+
+func (handler *reminderHandlerHttp) Create(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	activityId, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return handler.badRequest(ctx, "Invalid ID")
+	}
+
+	var request models.ReminderRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return handler.badRequest(ctx, "Cannot parse JSON")
+	}
+	if err := handler.validate.Struct(request); err != nil {
+		return handler.badRequest(ctx, err.Error())
+	}
+
+	reminder, err := handler.usecase.Create(principal.UserId, activityId, entities.Reminder{
+		OffsetMinutes: request.OffsetMinutes,
+		RemindAt:      request.RemindAt,
+	})
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data":        toReminderResponse(reminder),
+		"status_code": fiber.StatusCreated,
+		"message":     "Reminder created successfully",
+	})
+}
+
+func (handler *reminderHandlerHttp) Delete(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	activityId, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return handler.badRequest(ctx, "Invalid ID")
+	}
+	id, err := strconv.Atoi(ctx.Params("reminderId"))
+	if err != nil {
+		return handler.badRequest(ctx, "Invalid reminder ID")
+	}
+
+	if err := handler.usecase.Delete(principal.UserId, activityId, id); err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusOK,
+		"message":     "Reminder deleted successfully",
+	})
+}
+
+func (handler *reminderHandlerHttp) RegisterRoutes() {
+	activities := handler.app.Group("/api/activities/:id")
+	canRead := middleware.RequireScope(authEntities.ScopeActivitiesRead)
+	canWrite := middleware.RequireScope(authEntities.ScopeActivitiesWrite)
+
+	activities.Get("/reminders", handler.authMiddleware, canRead, handler.GetAll)
+	activities.Post("/reminders", handler.authMiddleware, canWrite, handler.Create)
+	activities.Delete("/reminders/:reminderId", handler.authMiddleware, canWrite, handler.Delete)
+}
+
+func (handler *reminderHandlerHttp) badRequest(ctx *fiber.Ctx, message string) error {
+	return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusBadRequest,
+		"message":     message,
+	})
+}
+
+func (handler *reminderHandlerHttp) fail(ctx *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	if errors.Is(err, repository.ErrReminderNotFound) || errors.Is(err, activityRepo.ErrActivityNotFound) {
+		status = fiber.StatusNotFound
+	}
+
+	return ctx.Status(status).JSON(fiber.Map{
+		"data":        nil,
+		"status_code": status,
+		"message":     err.Error(),
+	})
+}
+
+func toReminderResponse(reminder entities.Reminder) models.ReminderResponse {
+	return models.ReminderResponse{
+		Id:            reminder.Id,
+		ActivityId:    reminder.ActivityId,
+		OffsetMinutes: reminder.OffsetMinutes,
+		RemindAt:      reminder.RemindAt,
+		DueAt:         reminder.DueAt,
+		SentAt:        reminder.SentAt,
+		FailedAt:      reminder.FailedAt,
+		CreatedAt:     reminder.CreatedAt,
+	}
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// ReminderRequest sets either a number of minutes before the activity or an
+// absolute time.
+type ReminderRequest struct {
+	OffsetMinutes *int       `json:"offset_minutes" validate:"required_without=RemindAt,excluded_with=RemindAt,omitempty,min=0,max=525600"`
+	RemindAt      *time.Time `json:"remind_at" validate:"required_without=OffsetMinutes"`
+}
+
+type ReminderResponse struct {
+	Id            int        `json:"id"`
+	ActivityId    int        `json:"activity_id"`
+	OffsetMinutes *int       `json:"offset_minutes"`
+	RemindAt      *time.Time `json:"remind_at"`
+	DueAt         time.Time  `json:"due_at"`
+	SentAt        *time.Time `json:"sent_at"`
+	FailedAt      *time.Time `json:"failed_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
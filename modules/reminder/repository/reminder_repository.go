@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"errors"
+	"time"
+	"todolist-v1/modules/reminder/entities"
+)
+
+var ErrReminderNotFound = errors.New("reminder not found")
+
+// ReminderRepository stores reminders. Reminders are returned with their
+// due time and the activity and user details needed to deliver them.
+type ReminderRepository interface {
+	// FindAll returns the reminders userId set on an activity.
+	FindAll(userId int, activityId int) ([]entities.Reminder, error)
+	FindById(userId int, id int) (entities.Reminder, error)
+	Save(reminder entities.Reminder) (entities.Reminder, error)
+	Delete(userId int, activityId int, id int) error
+	// ClaimDue leases up to limit pending reminders that are due. Claimed
+	// reminders are skipped by every other caller until the lease expires,
+	// which makes each delivery attempt happen on one replica only.
+	ClaimDue(limit int, lease time.Duration) ([]entities.Reminder, error)
+	MarkSent(id int) error
+	// MarkFailed records a failed delivery. The reminder is claimed again
+	// after retryIn unless final is set, in which case it is given up.
+	MarkFailed(id int, reason string, retryIn time.Duration, final bool) error
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"errors"
+	"time"
+	"todolist-v1/modules/reminder/entities"
+
+	"gorm.io/gorm"
+)
+
+// dueAt computes when a reminder fires. Relative reminders follow their
+// activity when it is rescheduled.
+const dueAt = "COALESCE(reminders.remind_at, activities.activity_date - make_interval(mins => reminders.offset_minutes))"
+
+type reminderRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewReminderRepository(db *gorm.DB) ReminderRepository {
+	return &reminderRepositoryImpl{DB: db}
+}
+
+func withDetails(db *gorm.DB) *gorm.DB {
+	return db.
+		Select("reminders.*, " + dueAt + " AS due_at, " +
+			"activities.title AS activity_title, activities.activity_date, users.email").
+		Joins("JOIN activities ON activities.id = reminders.activity_id").
+		Joins("JOIN users ON users.id = reminders.user_id")
+}
+
+func (repository *reminderRepositoryImpl) FindAll(userId int, activityId int) ([]entities.Reminder, error) {
+	var reminders []entities.Reminder
+	err := repository.DB.Scopes(withDetails).
+		Where("reminders.user_id = ? AND reminders.activity_id = ?", userId, activityId).
+		Order("due_at").
+		Find(&reminders).Error
+	if err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+func (repository *reminderRepositoryImpl) FindById(userId int, id int) (entities.Reminder, error) {
+	var reminder entities.Reminder
+	err := repository.DB.Scopes(withDetails).
+		Where("reminders.user_id = ? AND reminders.id = ?", userId, id).
+		First(&reminder).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.Reminder{}, ErrReminderNotFound
+		}
+		return entities.Reminder{}, err
+	}
+	return reminder, nil
+}
+
+func (repository *reminderRepositoryImpl) Save(reminder entities.Reminder) (entities.Reminder, error) {
+	if err := repository.DB.Create(&reminder).Error; err != nil {
+		return entities.Reminder{}, err
+	}
+	return repository.FindById(reminder.UserId, reminder.Id)
+}
+
+func (repository *reminderRepositoryImpl) Delete(userId int, activityId int, id int) error {
+	result := repository.DB.
+		Where("id = ? AND user_id = ? AND activity_id = ?", id, userId, activityId).
+		Delete(&entities.Reminder{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrReminderNotFound
+	}
+	return nil
+}
+
+func (repository *reminderRepositoryImpl) ClaimDue(limit int, lease time.Duration) ([]entities.Reminder, error) {
+	var ids []int
+	err := repository.DB.Raw(`
+		UPDATE reminders SET locked_until = NOW() + make_interval(secs => ?), attempts = attempts + 1
+		WHERE id IN (
+			SELECT reminders.id FROM reminders
+			JOIN activities ON activities.id = reminders.activity_id
+			WHERE reminders.sent_at IS NULL AND reminders.failed_at IS NULL
+				AND activities.deleted_at IS NULL
+				AND (reminders.locked_until IS NULL OR reminders.locked_until <= NOW())
+				AND `+dueAt+` <= NOW()
+			ORDER BY `+dueAt+`
+			LIMIT ?
+			FOR UPDATE OF reminders SKIP LOCKED
+		)
+		RETURNING id`, lease.Seconds(), limit).Scan(&ids).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var reminders []entities.Reminder
+	if err := repository.DB.Scopes(withDetails).Where("reminders.id IN ?", ids).Order("due_at").Find(&reminders).Error; err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+func (repository *reminderRepositoryImpl) MarkSent(id int) error {
+	return repository.DB.Model(&entities.Reminder{}).Where("id = ?", id).Updates(map[string]any{
+		"sent_at":      gorm.Expr("NOW()"),
+		"locked_until": nil,
+		"last_error":   nil,
+	}).Error
+}
+
+func (repository *reminderRepositoryImpl) MarkFailed(id int, reason string, retryIn time.Duration, final bool) error {
+	updates := map[string]any{
+		"last_error":   reason,
+		"locked_until": gorm.Expr("NOW() + make_interval(secs => ?)", retryIn.Seconds()),
+	}
+	if final {
+		updates["failed_at"] = gorm.Expr("NOW()")
+		updates["locked_until"] = nil
+	}
+	return repository.DB.Model(&entities.Reminder{}).Where("id = ?", id).Updates(updates).Error
+}
@@ -0,0 +1,20 @@
+package usecase
+
+import "todolist-v1/modules/reminder/entities"
+
+// ReminderUsecase manages personal reminders on the activities a user can
+// see and delivers them when they are due.
+type ReminderUsecase interface {
+	GetAll(userId int, activityId int) ([]entities.Reminder, error)
+	Create(userId int, activityId int, reminder entities.Reminder) (entities.Reminder, error)
+	Delete(userId int, activityId int, id int) error
+	// DispatchDue delivers the reminders that are due and returns how many
+	// were sent. It is safe to run on several replicas at once, which never
+	// deliver a reminder at the same time. Delivery is at least once: a
+	// replica that crashes between delivering a reminder and recording it
+	// as sent leaves it to be delivered again once its claim expires, with
+	// the same notifier.Notification Key. Failed deliveries are retried
+	// with a growing delay until the configured number of attempts is
+	// reached.
+	DispatchDue() (int, error)
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"todolist-v1/config"
+	activityRepo "todolist-v1/modules/activity/repository"
+	"todolist-v1/modules/reminder/entities"
+	"todolist-v1/modules/reminder/repository"
+	"todolist-v1/pkg/notifier"
+)
+
+// claimLease bounds how long a reminder stays claimed by a replica that
+// crashed while delivering it. Reminders are claimed one at a time, so the
+// lease only has to outlast one delivery, which notifiers time out well
+// before.
+const claimLease = 5 * time.Minute
+
+type reminderUsecaseImpl struct {
+	reminderRepository repository.ReminderRepository
+	activityRepository activityRepo.ActivityRepository
+	notifier           notifier.Notifier
+	batchSize          int
+	maxAttempts        int
+}
+
+func NewReminderUsecase(reminderRepository repository.ReminderRepository, activityRepository activityRepo.ActivityRepository, notifier notifier.Notifier, cfg *config.Config) ReminderUsecase {
+	return &reminderUsecaseImpl{
+		reminderRepository: reminderRepository,
+		activityRepository: activityRepository,
+		notifier:           notifier,
+		batchSize:          cfg.Reminders.BatchSize,
+		maxAttempts:        cfg.Reminders.MaxAttempts,
+	}
+}
+
+func (usecase *reminderUsecaseImpl) GetAll(userId int, activityId int) ([]entities.Reminder, error) {
+	if _, err := usecase.activityRepository.FindById(userId, activityId); err != nil {
+		return nil, err
+	}
+	return usecase.reminderRepository.FindAll(userId, activityId)
+}
+
+func (usecase *reminderUsecaseImpl) Create(userId int, activityId int, reminder entities.Reminder) (entities.Reminder, error) {
+	if _, err := usecase.activityRepository.FindById(userId, activityId); err != nil {
+		return entities.Reminder{}, err
+	}
+
+	return usecase.reminderRepository.Save(entities.Reminder{
+		ActivityId:    activityId,
+		UserId:        userId,
+		OffsetMinutes: reminder.OffsetMinutes,
+		RemindAt:      reminder.RemindAt,
+	})
+}
+
+func (usecase *reminderUsecaseImpl) Delete(userId int, activityId int, id int) error {
+	return usecase.reminderRepository.Delete(userId, activityId, id)
+}
+
+func (usecase *reminderUsecaseImpl) DispatchDue() (int, error) {
+	sent := 0
+	var failures []error
+	for attempted := 0; attempted < usecase.batchSize; attempted++ {
+		reminders, err := usecase.reminderRepository.ClaimDue(1, claimLease)
+		if err != nil {
+			return sent, errors.Join(append(failures, err)...)
+		}
+		if len(reminders) == 0 {
+			break
+		}
+
+		reminder := reminders[0]
+		if err := usecase.notifier.Notify(reminderNotification(reminder)); err != nil {
+			failures = append(failures, fmt.Errorf("reminder %d: %w", reminder.Id, err))
+			final := reminder.Attempts >= usecase.maxAttempts
+			if err := usecase.reminderRepository.MarkFailed(reminder.Id, err.Error(), retryDelay(reminder.Attempts), final); err != nil {
+				return sent, err
+			}
+			continue
+		}
+
+		if err := usecase.reminderRepository.MarkSent(reminder.Id); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+	return sent, errors.Join(failures...)
+}
+
+// retryDelay grows quadratically with the number of attempts made.
+func retryDelay(attempts int) time.Duration {
+	return time.Duration(attempts*attempts) * time.Minute
+}
+
+func reminderNotification(reminder entities.Reminder) notifier.Notification {
+	return notifier.Notification{
+		Kind:    "reminder",
+		Key:     fmt.Sprintf("reminder-%d-%d", reminder.Id, reminder.DueAt.Unix()),
+		To:      reminder.Email,
+		Subject: "Reminder: " + reminder.ActivityTitle,
+		Body: fmt.Sprintf("%q is scheduled for %s.",
+			reminder.ActivityTitle, reminder.ActivityDate.UTC().Format("Mon, 02 Jan 2006 15:04 MST")),
+	}
+}
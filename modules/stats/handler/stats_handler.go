@@ -0,0 +1,8 @@
+package handler
+
+import "github.com/gofiber/fiber/v2"
+
+type StatsHandler interface {
+	Get(ctx *fiber.Ctx) error
+	RegisterRoutes()
+}
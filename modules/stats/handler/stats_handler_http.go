@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"errors"
+	"time"
+	activityEntities "todolist-v1/modules/activity/entities"
+	authEntities "todolist-v1/modules/auth/entities"
+	"todolist-v1/modules/auth/middleware"
+	"todolist-v1/modules/stats/models"
+	"todolist-v1/modules/stats/usecase"
+	"todolist-v1/pkg/date"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// defaultStatsDays is how many days up to to are described without a from.
+const defaultStatsDays = 30
+
+type statsHandlerHttp struct {
+	app            *fiber.App
+	usecase        usecase.StatsUsecase
+	authMiddleware fiber.Handler
+}
+
+func NewStatsHttpHandler(app *fiber.App, usecase usecase.StatsUsecase, authMiddleware fiber.Handler) StatsHandler {
+	return &statsHandlerHttp{
+		app:            app,
+		usecase:        usecase,
+		authMiddleware: authMiddleware,
+	}
+}
+
+// Get returns the statistics of the activities dated from from to to, the
+// last 30 days by default, with days taken in the caller's preferred
+// timezone.
+func (handler *statsHandlerHttp) Get(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return handler.badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	to := date.Of(time.Now().In(location))
+	if value := ctx.Query("to"); value != "" {
+		if to, err = date.Parse(value); err != nil {
+			return handler.badRequest(ctx, "Invalid to, expected YYYY-MM-DD")
+		}
+	}
+	from := to.AddDays(1 - defaultStatsDays)
+	if value := ctx.Query("from"); value != "" {
+		if from, err = date.Parse(value); err != nil {
+			return handler.badRequest(ctx, "Invalid from, expected YYYY-MM-DD")
+		}
+	}
+
+	stats, err := handler.usecase.Get(principal.UserId, from, to, location)
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        toStatsResponse(stats),
+		"status_code": fiber.StatusOK,
+		"message":     "Statistics retrieved successfully",
+	})
+}
+
+func (handler *statsHandlerHttp) RegisterRoutes() {
+	canRead := middleware.RequireScope(authEntities.ScopeActivitiesRead)
+	handler.app.Get("/api/stats", handler.authMiddleware, canRead, handler.Get)
+}
+
+func (handler *statsHandlerHttp) badRequest(ctx *fiber.Ctx, message string) error {
+	return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusBadRequest,
+		"message":     message,
+	})
+}
+
+func (handler *statsHandlerHttp) fail(ctx *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	if errors.Is(err, usecase.ErrInvalidRange) {
+		status = fiber.StatusBadRequest
+	}
+
+	return ctx.Status(status).JSON(fiber.Map{
+		"data":        nil,
+		"status_code": status,
+		"message":     err.Error(),
+	})
+}
+
+func toStatsResponse(stats activityEntities.ActivityStats) models.StatsResponse {
+	response := models.StatsResponse{
+		From:     stats.From,
+		To:       stats.To,
+		Timezone: stats.Location.String(),
+		Overdue:  stats.Overdue,
+		Completions: models.CompletionStats{
+			Count:          stats.Completions.Completed,
+			AverageSeconds: stats.Completions.AverageSeconds,
+		},
+		ByCategory: stats.ByCategory,
+		ByStatus:   stats.ByStatus,
+		Days:       make([]models.DayStats, 0, len(stats.Days)),
+	}
+	for _, day := range stats.Days {
+		response.Days = append(response.Days, models.DayStats{
+			Date:           day.Day,
+			Total:          day.Due,
+			Done:           day.Done,
+			CompletionRate: rate(day.Done, day.Due),
+		})
+	}
+	// The totals come from the distribution rather than the days, which
+	// leave out all-day activities falling outside the range.
+	for _, count := range stats.ByStatus {
+		response.Total += count
+	}
+	response.Done = stats.ByStatus[activityEntities.StatusDone]
+	response.CompletionRate = rate(response.Done, response.Total)
+	return response
+}
+
+// rate returns done out of total, or nil when there is nothing to complete.
+func rate(done int, total int) *float64 {
+	if total == 0 {
+		return nil
+	}
+	value := float64(done) / float64(total)
+	return &value
+}
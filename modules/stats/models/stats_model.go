@@ -0,0 +1,34 @@
+package models
+
+import "todolist-v1/pkg/date"
+
+// StatsResponse describes the activities dated in [from, to]. Completion
+// rates are null for days without activities.
+type StatsResponse struct {
+	From           date.Date       `json:"from"`
+	To             date.Date       `json:"to"`
+	Timezone       string          `json:"timezone"`
+	Total          int             `json:"total"`
+	Done           int             `json:"done"`
+	CompletionRate *float64        `json:"completion_rate"`
+	Overdue        int             `json:"overdue"`
+	Completions    CompletionStats `json:"completions"`
+	ByCategory     map[string]int  `json:"by_category"`
+	ByStatus       map[string]int  `json:"by_status"`
+	Days           []DayStats      `json:"days"`
+}
+
+// CompletionStats counts the activities moved to DONE in the period,
+// whatever their date, and how long they took on average since they were
+// created.
+type CompletionStats struct {
+	Count          int    `json:"count"`
+	AverageSeconds *int64 `json:"average_seconds"`
+}
+
+type DayStats struct {
+	Date           date.Date `json:"date"`
+	Total          int       `json:"total"`
+	Done           int       `json:"done"`
+	CompletionRate *float64  `json:"completion_rate"`
+}
@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"time"
+	activityEntities "todolist-v1/modules/activity/entities"
+	"todolist-v1/pkg/date"
+)
+
+type StatsUsecase interface {
+	// Get describes the activities visible to userId dated from from to to,
+	// inclusive, with days taken in location. Results are cached for a
+	// while.
+	Get(userId int, from date.Date, to date.Date, location *time.Location) (activityEntities.ActivityStats, error)
+	// InvalidateActivity drops the cached statistics of actorId and of
+	// every user the activity is visible to, after actorId changed it.
+	// Only the cache of this process is dropped; other replicas keep theirs
+	// for up to the configured TTL.
+	InvalidateActivity(actorId int, activityId int)
+}
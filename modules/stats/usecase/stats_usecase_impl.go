@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+	"todolist-v1/config"
+	activityEntities "todolist-v1/modules/activity/entities"
+	activityRepo "todolist-v1/modules/activity/repository"
+	"todolist-v1/pkg/cache"
+	"todolist-v1/pkg/date"
+)
+
+var ErrInvalidRange = errors.New("from must not be after to, at most 366 days apart")
+
+// maxRangeDays bounds the period described at once.
+const maxRangeDays = 366
+
+// statsKey identifies a cached result. Results are kept per user since
+// each user sees a different set of activities.
+type statsKey struct {
+	userId int
+	from   date.Date
+	to     date.Date
+	zone   string
+}
+
+type statsUsecaseImpl struct {
+	statsRepository activityRepo.ActivityStatsRepository
+	cache           *cache.Cache[statsKey, activityEntities.ActivityStats]
+}
+
+func NewStatsUsecase(statsRepository activityRepo.ActivityStatsRepository, cfg *config.Config) StatsUsecase {
+	return &statsUsecaseImpl{
+		statsRepository: statsRepository,
+		cache:           cache.New[statsKey, activityEntities.ActivityStats](cfg.Stats.CacheTTL),
+	}
+}
+
+func (usecase *statsUsecaseImpl) Get(userId int, from date.Date, to date.Date, location *time.Location) (activityEntities.ActivityStats, error) {
+	if to < from || to > from.AddDays(maxRangeDays-1) {
+		return activityEntities.ActivityStats{}, ErrInvalidRange
+	}
+
+	key := statsKey{userId: userId, from: from, to: to, zone: location.String()}
+	return usecase.cache.Get(key, func() (activityEntities.ActivityStats, error) {
+		return usecase.compute(userId, from, to, location)
+	})
+}
+
+func (usecase *statsUsecaseImpl) InvalidateActivity(actorId int, activityId int) {
+	users := map[int]bool{actorId: true}
+	if activityId != 0 {
+		viewers, err := usecase.statsRepository.FindViewers(activityId)
+		if err != nil {
+			// Without the viewers, everyone's statistics may be stale.
+			usecase.cache.Invalidate(func(statsKey) bool { return true })
+			return
+		}
+		for _, viewer := range viewers {
+			users[viewer] = true
+		}
+	}
+	usecase.cache.Invalidate(func(key statsKey) bool {
+		return users[key.userId]
+	})
+}
+
+func (usecase *statsUsecaseImpl) compute(userId int, from date.Date, to date.Date, location *time.Location) (activityEntities.ActivityStats, error) {
+	stats := activityEntities.ActivityStats{
+		From:       from,
+		To:         to,
+		Location:   location,
+		ByCategory: make(map[string]int),
+		ByStatus:   make(map[string]int),
+	}
+	start, end := from.In(location), to.AddDays(1).In(location)
+
+	days, err := usecase.statsRepository.CountByDay(userId, start, end, location.String())
+	if err != nil {
+		return stats, err
+	}
+	// Every day of the range is listed, those without activities too.
+	// All-day activities keep the day of their own timezone, which may fall
+	// just outside the range; they are then left out of the days only.
+	counted := make(map[date.Date]activityEntities.DayCompletion, len(days))
+	for _, day := range days {
+		counted[day.Day] = day
+	}
+	for day := from; day <= to; day = day.AddDays(1) {
+		completion := counted[day]
+		completion.Day = day
+		stats.Days = append(stats.Days, completion)
+	}
+
+	counts, err := usecase.statsRepository.CountByCategoryAndStatus(userId, start, end)
+	if err != nil {
+		return stats, err
+	}
+	for _, count := range counts {
+		stats.ByCategory[count.Category] += count.Count
+		stats.ByStatus[count.Status] += count.Count
+	}
+
+	if stats.Overdue, err = usecase.statsRepository.CountOverdue(userId, start, end, time.Now()); err != nil {
+		return stats, err
+	}
+	if stats.Completions, err = usecase.statsRepository.SummarizeCompletions(userId, start, end); err != nil {
+		return stats, err
+	}
+	return stats, nil
+}
@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"time"
+	activityEntities "todolist-v1/modules/activity/entities"
+
+	"github.com/lib/pq"
+)
+
+// Template describes an activity created over and over. TitlePattern,
+// Description and the Checklist items may hold the {{date}} and {{user}}
+// placeholders; each checklist item becomes a child activity. Personal
+// templates belong to their owner, workspace templates to every member.
+type Template struct {
+	Id           int            `json:"id"            gorm:"column:id;primaryKey;autoIncrement"`
+	OwnerId      int            `json:"owner_id"      gorm:"column:owner_id;not null"`
+	WorkspaceId  *int           `json:"workspace_id"  gorm:"column:workspace_id"`
+	Name         string         `json:"name"          gorm:"column:name;size:100;not null"`
+	TitlePattern string         `json:"title_pattern" gorm:"column:title_pattern;size:250;not null"`
+	Category     string         `json:"category"      gorm:"column:category;type:category_type;not null"`
+	Description  string         `json:"description"   gorm:"column:description;not null"`
+	Checklist    pq.StringArray `json:"checklist"     gorm:"column:checklist;type:text[];not null"`
+	CreatedAt    time.Time      `json:"created_at"    gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt    time.Time      `json:"updated_at"    gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (Template) TableName() string { return "templates" }
+
+// Instance tells when and where to create the activity of a template. At
+// dates it; without it the activity is all-day today, in Location. A
+// project only holds the activity itself, not its checklist. Policy tells
+// what to do when the activity is an event overlapping others.
+type Instance struct {
+	At          *time.Time
+	Location    *time.Location
+	WorkspaceId *int
+	ProjectId   *int
+	Policy      activityEntities.ConflictPolicy
+}
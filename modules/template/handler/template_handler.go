@@ -0,0 +1,13 @@
+package handler
+
+import "github.com/gofiber/fiber/v2"
+
+type TemplateHandler interface {
+	GetAll(ctx *fiber.Ctx) error
+	Get(ctx *fiber.Ctx) error
+	Create(ctx *fiber.Ctx) error
+	Update(ctx *fiber.Ctx) error
+	Delete(ctx *fiber.Ctx) error
+	Instantiate(ctx *fiber.Ctx) error
+	RegisterRoutes()
+}
@@ -0,0 +1,326 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+	"time"
+	activityEntities "todolist-v1/modules/activity/entities"
+	activityUsecase "todolist-v1/modules/activity/usecase"
+	authEntities "todolist-v1/modules/auth/entities"
+	"todolist-v1/modules/auth/middleware"
+	projectRepo "todolist-v1/modules/project/repository"
+	"todolist-v1/modules/template/entities"
+	"todolist-v1/modules/template/models"
+	"todolist-v1/modules/template/repository"
+	"todolist-v1/modules/template/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type templateHandlerHttp struct {
+	app            *fiber.App
+	usecase        usecase.TemplateUsecase
+	authMiddleware fiber.Handler
+	validate       *validator.Validate
+}
+
+func NewTemplateHttpHandler(app *fiber.App, usecase usecase.TemplateUsecase, authMiddleware fiber.Handler) TemplateHandler {
+	return &templateHandlerHttp{
+		app:            app,
+		usecase:        usecase,
+		authMiddleware: authMiddleware,
+		validate:       validator.New(),
+	}
+}
+
+func (handler *templateHandlerHttp) GetAll(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	templates, err := handler.usecase.GetAll(principal.UserId)
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	templateResponses := make([]models.TemplateResponse, 0, len(templates))
+	for _, template := range templates {
+		templateResponses = append(templateResponses, toTemplateResponse(template))
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        templateResponses,
+		"status_code": fiber.StatusOK,
+		"message":     "Templates retrieved successfully",
+	})
+}
+
+func (handler *templateHandlerHttp) Get(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return handler.invalidId(ctx)
+	}
+
+	template, err := handler.usecase.Get(principal.UserId, id)
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        toTemplateResponse(template),
+		"status_code": fiber.StatusOK,
+		"message":     "Template retrieved successfully",
+	})
+}
+
+func (handler *templateHandlerHttp) Create(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	var request models.TemplateCreateRequest
+	if err := handler.parse(ctx, &request); err != nil {
+		return handler.badRequest(ctx, err.Error())
+	}
+
+	template, err := handler.usecase.Create(principal.UserId, entities.Template{
+		WorkspaceId:  request.WorkspaceId,
+		Name:         request.Name,
+		TitlePattern: request.TitlePattern,
+		Category:     request.Category,
+		Description:  request.Description,
+		Checklist:    checklist(request.Checklist),
+	})
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data":        toTemplateResponse(template),
+		"status_code": fiber.StatusCreated,
+		"message":     "Template created successfully",
+	})
+}
+
+func (handler *templateHandlerHttp) Update(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return handler.invalidId(ctx)
+	}
+
+	var request models.TemplateUpdateRequest
+	if err := handler.parse(ctx, &request); err != nil {
+		return handler.badRequest(ctx, err.Error())
+	}
+
+	template, err := handler.usecase.Update(principal.UserId, id, entities.Template{
+		Name:         request.Name,
+		TitlePattern: request.TitlePattern,
+		Category:     request.Category,
+		Description:  request.Description,
+		Checklist:    checklist(request.Checklist),
+	})
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        toTemplateResponse(template),
+		"status_code": fiber.StatusOK,
+		"message":     "Template updated successfully",
+	})
+}
+
+func (handler *templateHandlerHttp) Delete(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return handler.invalidId(ctx)
+	}
+
+	if err := handler.usecase.Delete(principal.UserId, id); err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusOK,
+		"message":     "Template deleted successfully",
+	})
+}
+
+// Instantiate creates the activity of a template and its checklist, with
+// {{date}} taken in the caller's preferred timezone.
+func (handler *templateHandlerHttp) Instantiate(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+	location, err := middleware.PreferredLocation(ctx)
+	if err != nil {
+		return handler.badRequest(ctx, "Invalid X-Timezone header")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return handler.invalidId(ctx)
+	}
+
+	var request models.InstantiateRequest
+	if len(ctx.Body()) > 0 {
+		if err := handler.parse(ctx, &request); err != nil {
+			return handler.badRequest(ctx, err.Error())
+		}
+	}
+
+	policy := activityEntities.ConflictsWarn
+	if request.RejectConflicts {
+		policy = activityEntities.ConflictsReject
+	}
+	activity, children, conflicts, err := handler.usecase.Instantiate(principal.UserId, id, entities.Instance{
+		At:          request.At,
+		Location:    location,
+		WorkspaceId: request.WorkspaceId,
+		ProjectId:   request.ProjectId,
+		Policy:      policy,
+	})
+	var conflict *activityUsecase.ConflictError
+	if errors.As(err, &conflict) {
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"data":        fiber.Map{"conflicts": toConflictResponses(conflict.Conflicts, location)},
+			"status_code": fiber.StatusConflict,
+			"message":     err.Error(),
+		})
+	}
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	response := models.InstanceResponse{
+		Activity:  toCreatedActivityResponse(activity, location),
+		Children:  make([]models.CreatedActivityResponse, 0, len(children)),
+		Conflicts: toConflictResponses(conflicts, location),
+	}
+	for _, child := range children {
+		response.Children = append(response.Children, toCreatedActivityResponse(child, location))
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data":        response,
+		"status_code": fiber.StatusCreated,
+		"message":     "Activity created from template successfully",
+	})
+}
+
+func (handler *templateHandlerHttp) RegisterRoutes() {
+	canRead := middleware.RequireScope(authEntities.ScopeActivitiesRead)
+	canWrite := middleware.RequireScope(authEntities.ScopeActivitiesWrite)
+
+	templates := handler.app.Group("/api/templates", handler.authMiddleware)
+	templates.Get("/", canRead, handler.GetAll)
+	templates.Post("/", canWrite, handler.Create)
+	templates.Get("/:id", canRead, handler.Get)
+	templates.Put("/:id", canWrite, handler.Update)
+	templates.Delete("/:id", canWrite, handler.Delete)
+	templates.Post("/:id/instantiate", canWrite, handler.Instantiate)
+}
+
+// parse decodes the request body into request and validates it.
+func (handler *templateHandlerHttp) parse(ctx *fiber.Ctx, request any) error {
+	if err := ctx.BodyParser(request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Cannot parse JSON")
+	}
+	return handler.validate.Struct(request)
+}
+
+func (handler *templateHandlerHttp) badRequest(ctx *fiber.Ctx, message string) error {
+	return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusBadRequest,
+		"message":     message,
+	})
+}
+
+func (handler *templateHandlerHttp) invalidId(ctx *fiber.Ctx) error {
+	return handler.badRequest(ctx, "Invalid ID")
+}
+
+func (handler *templateHandlerHttp) fail(ctx *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, repository.ErrTemplateNotFound),
+		errors.Is(err, usecase.ErrWorkspaceNotFound),
+		errors.Is(err, activityUsecase.ErrWorkspaceNotFound),
+		errors.Is(err, projectRepo.ErrProjectNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, usecase.ErrForbidden),
+		errors.Is(err, activityUsecase.ErrForbidden):
+		status = fiber.StatusForbidden
+	case errors.Is(err, usecase.ErrUnknownPlaceholder),
+		errors.Is(err, usecase.ErrTitleTooLong),
+		errors.Is(err, activityUsecase.ErrProjectMismatch):
+		status = fiber.StatusBadRequest
+	}
+
+	return ctx.Status(status).JSON(fiber.Map{
+		"data":        nil,
+		"status_code": status,
+		"message":     err.Error(),
+	})
+}
+
+// checklist keeps an empty checklist from being stored as null.
+func checklist(items []string) []string {
+	if items == nil {
+		return []string{}
+	}
+	return items
+}
+
+func toTemplateResponse(template entities.Template) models.TemplateResponse {
+	return models.TemplateResponse{
+		Id:           template.Id,
+		OwnerId:      template.OwnerId,
+		WorkspaceId:  template.WorkspaceId,
+		Name:         template.Name,
+		TitlePattern: template.TitlePattern,
+		Category:     template.Category,
+		Description:  template.Description,
+		Checklist:    checklist(template.Checklist),
+		CreatedAt:    template.CreatedAt,
+		UpdatedAt:    template.UpdatedAt,
+	}
+}
+
+func toCreatedActivityResponse(activity activityEntities.Activity, location *time.Location) models.CreatedActivityResponse {
+	return models.CreatedActivityResponse{
+		Id:           activity.Id,
+		ParentId:     activity.ParentId,
+		WorkspaceId:  activity.WorkspaceId,
+		ProjectId:    activity.ProjectId,
+		Title:        activity.Title,
+		Category:     activity.Category,
+		Description:  activity.Description,
+		Status:       activity.Status,
+		ActivityDate: activity.ActivityDate.In(location),
+		AllDay:       activity.AllDay,
+		LocalDate:    activity.LocalDate,
+	}
+}
+
+// toConflictResponses renders the conflicting occurrences in location, the
+// way the activity endpoints do.
+func toConflictResponses(conflicts []activityEntities.ActivityOccurrence, location *time.Location) []models.ConflictResponse {
+	responses := make([]models.ConflictResponse, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		response := models.ConflictResponse{
+			ActivityId: conflict.Id,
+			Title:      conflict.Title,
+			StartAt:    conflict.OccursAt.In(location),
+		}
+		if conflict.EndAt != nil {
+			end := response.StartAt.Add(conflict.EndAt.Sub(conflict.ActivityDate))
+			response.EndAt = &end
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"time"
+	"todolist-v1/pkg/date"
+)
+
+type TemplateCreateRequest struct {
+	WorkspaceId  *int     `json:"workspace_id" validate:"omitempty,min=1"`
+	Name         string   `json:"name" validate:"required,max=100"`
+	TitlePattern string   `json:"title_pattern" validate:"required,max=250"`
+	Category     string   `json:"category" validate:"required,oneof=TASK EVENT"`
+	Description  string   `json:"description"`
+	Checklist    []string `json:"checklist" validate:"max=50,dive,required,max=250"`
+}
+
+type TemplateUpdateRequest struct {
+	Name         string   `json:"name" validate:"required,max=100"`
+	TitlePattern string   `json:"title_pattern" validate:"required,max=250"`
+	Category     string   `json:"category" validate:"required,oneof=TASK EVENT"`
+	Description  string   `json:"description"`
+	Checklist    []string `json:"checklist" validate:"max=50,dive,required,max=250"`
+}
+
+// InstantiateRequest dates the activity at at, or makes it all-day today
+// without it. workspace_id defaults to the template's workspace.
+type InstantiateRequest struct {
+	At          *time.Time `json:"at"`
+	WorkspaceId *int       `json:"workspace_id" validate:"omitempty,min=1"`
+	ProjectId   *int       `json:"project_id" validate:"omitempty,min=1"`
+	// RejectConflicts refuses an event overlapping other events of its
+	// owner instead of creating it and listing them.
+	RejectConflicts bool `json:"reject_conflicts"`
+}
+
+type TemplateResponse struct {
+	Id           int       `json:"id"`
+	OwnerId      int       `json:"owner_id"`
+	WorkspaceId  *int      `json:"workspace_id"`
+	Name         string    `json:"name"`
+	TitlePattern string    `json:"title_pattern"`
+	Category     string    `json:"category"`
+	Description  string    `json:"description"`
+	Checklist    []string  `json:"checklist"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// InstanceResponse lists the activity created from a template and its
+// children, in checklist order.
+type InstanceResponse struct {
+	Activity CreatedActivityResponse   `json:"activity"`
+	Children []CreatedActivityResponse `json:"children"`
+	// Conflicts lists the events the created event overlaps.
+	Conflicts []ConflictResponse `json:"conflicts,omitempty"`
+}
+
+// ConflictResponse is an occurrence of an event overlapping another one.
+type ConflictResponse struct {
+	ActivityId int        `json:"activity_id"`
+	Title      string     `json:"title"`
+	StartAt    time.Time  `json:"start_at"`
+	EndAt      *time.Time `json:"end_at"`
+}
+
+type CreatedActivityResponse struct {
+	Id           int        `json:"id"`
+	ParentId     *int       `json:"parent_id"`
+	WorkspaceId  *int       `json:"workspace_id"`
+	ProjectId    *int       `json:"project_id"`
+	Title        string     `json:"title"`
+	Category     string     `json:"category"`
+	Description  string     `json:"description"`
+	Status       string     `json:"status"`
+	ActivityDate time.Time  `json:"activity_date"`
+	AllDay       bool       `json:"all_day"`
+	LocalDate    *date.Date `json:"local_date"`
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"errors"
+	"todolist-v1/modules/template/entities"
+)
+
+var ErrTemplateNotFound = errors.New("template not found")
+
+// TemplateRepository finds templates for a user: their personal templates
+// and those of the workspaces they are a member of.
+type TemplateRepository interface {
+	FindAllForUser(userId int) ([]entities.Template, error)
+	FindByIdForUser(userId int, id int) (entities.Template, error)
+	Save(template entities.Template) (entities.Template, error)
+	// Update replaces the content of the template, keeping its owner and
+	// workspace.
+	Update(id int, template entities.Template) (entities.Template, error)
+	Delete(id int) error
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"errors"
+	"todolist-v1/modules/template/entities"
+
+	"gorm.io/gorm"
+)
+
+type templateRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewTemplateRepository(db *gorm.DB) TemplateRepository {
+	return &templateRepositoryImpl{DB: db}
+}
+
+func (repository *templateRepositoryImpl) visibleTo(userId int) *gorm.DB {
+	return repository.DB.Where(
+		"(templates.workspace_id IS NULL AND templates.owner_id = ?) OR "+
+			"templates.workspace_id IN (SELECT workspace_id FROM workspace_members WHERE user_id = ?)",
+		userId, userId,
+	)
+}
+
+func (repository *templateRepositoryImpl) FindAllForUser(userId int) ([]entities.Template, error) {
+	var templates []entities.Template
+	if err := repository.visibleTo(userId).Order("templates.name, templates.id").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (repository *templateRepositoryImpl) FindByIdForUser(userId int, id int) (entities.Template, error) {
+	var template entities.Template
+	if err := repository.visibleTo(userId).Where("templates.id = ?", id).First(&template).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.Template{}, ErrTemplateNotFound
+		}
+		return entities.Template{}, err
+	}
+	return template, nil
+}
+
+func (repository *templateRepositoryImpl) Save(template entities.Template) (entities.Template, error) {
+	if err := repository.DB.Create(&template).Error; err != nil {
+		return entities.Template{}, err
+	}
+	return template, nil
+}
+
+func (repository *templateRepositoryImpl) Update(id int, template entities.Template) (entities.Template, error) {
+	result := repository.DB.Model(&entities.Template{}).Where("id = ?", id).Updates(map[string]any{
+		"name":          template.Name,
+		"title_pattern": template.TitlePattern,
+		"category":      template.Category,
+		"description":   template.Description,
+		"checklist":     template.Checklist,
+	})
+	if result.Error != nil {
+		return entities.Template{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.Template{}, ErrTemplateNotFound
+	}
+
+	var updated entities.Template
+	if err := repository.DB.First(&updated, id).Error; err != nil {
+		return entities.Template{}, err
+	}
+	return updated, nil
+}
+
+func (repository *templateRepositoryImpl) Delete(id int) error {
+	result := repository.DB.Delete(&entities.Template{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTemplateNotFound
+	}
+	return nil
+}
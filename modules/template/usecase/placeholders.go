@@ -0,0 +1,30 @@
+package usecase
+
+import "regexp"
+
+// Placeholders templates may hold, written {{date}} or {{ date }}.
+const (
+	placeholderDate = "date"
+	placeholderUser = "user"
+)
+
+var placeholderPattern = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+// checkPlaceholders makes sure texts only hold known placeholders.
+func checkPlaceholders(texts ...string) error {
+	for _, text := range texts {
+		for _, match := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+			if match[1] != placeholderDate && match[1] != placeholderUser {
+				return ErrUnknownPlaceholder
+			}
+		}
+	}
+	return nil
+}
+
+// fill replaces the placeholders of text with their values.
+func fill(text string, values map[string]string) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		return values[placeholderPattern.FindStringSubmatch(placeholder)[1]]
+	})
+}
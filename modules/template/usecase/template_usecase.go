@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"errors"
+	activityEntities "todolist-v1/modules/activity/entities"
+	"todolist-v1/modules/template/entities"
+)
+
+var (
+	ErrForbidden          = errors.New("insufficient permission for this template")
+	ErrWorkspaceNotFound  = errors.New("workspace not found")
+	ErrUnknownPlaceholder = errors.New("unknown placeholder, expected {{date}} or {{user}}")
+	ErrTitleTooLong       = errors.New("a title is longer than 250 characters once filled in")
+)
+
+// TemplateUsecase manages templates and creates activities from them. Every
+// member of a workspace sees and uses its templates; changing them needs the
+// right to write activities in the workspace. Personal templates are only
+// visible to their owner.
+type TemplateUsecase interface {
+	GetAll(userId int) ([]entities.Template, error)
+	Get(userId int, id int) (entities.Template, error)
+	Create(userId int, template entities.Template) (entities.Template, error)
+	Update(userId int, id int, template entities.Template) (entities.Template, error)
+	Delete(userId int, id int) error
+	// Instantiate creates the activity of a template along with one child
+	// task per checklist item, due when the activity takes place, all in one
+	// transaction. {{date}} is filled in with the day of the activity and
+	// {{user}} with the name of the user. The activity goes to the
+	// template's workspace unless the instance names another. An event is
+	// checked for overlaps the way creating it directly is, and the
+	// overlapping events are returned.
+	Instantiate(userId int, id int, instance entities.Instance) (activityEntities.Activity, []activityEntities.Activity, []activityEntities.ActivityOccurrence, error)
+}
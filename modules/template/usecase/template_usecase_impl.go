@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+	activityEntities "todolist-v1/modules/activity/entities"
+	activityUsecase "todolist-v1/modules/activity/usecase"
+	authRepo "todolist-v1/modules/auth/repository"
+	"todolist-v1/modules/template/entities"
+	"todolist-v1/modules/template/repository"
+	workspaceRepo "todolist-v1/modules/workspace/repository"
+	"todolist-v1/pkg/date"
+	"unicode/utf8"
+)
+
+// maxTitleLength matches the longest title an activity may have.
+const maxTitleLength = 250
+
+type templateUsecaseImpl struct {
+	templateRepository  repository.TemplateRepository
+	activities          activityUsecase.ActivityUsecase
+	workspaceRepository workspaceRepo.WorkspaceRepository
+	userRepository      authRepo.UserRepository
+}
+
+func NewTemplateUsecase(templateRepository repository.TemplateRepository, activities activityUsecase.ActivityUsecase, workspaceRepository workspaceRepo.WorkspaceRepository, userRepository authRepo.UserRepository) TemplateUsecase {
+	return &templateUsecaseImpl{
+		templateRepository:  templateRepository,
+		activities:          activities,
+		workspaceRepository: workspaceRepository,
+		userRepository:      userRepository,
+	}
+}
+
+func (usecase *templateUsecaseImpl) GetAll(userId int) ([]entities.Template, error) {
+	return usecase.templateRepository.FindAllForUser(userId)
+}
+
+func (usecase *templateUsecaseImpl) Get(userId int, id int) (entities.Template, error) {
+	return usecase.templateRepository.FindByIdForUser(userId, id)
+}
+
+func (usecase *templateUsecaseImpl) Create(userId int, template entities.Template) (entities.Template, error) {
+	if err := checkTemplate(template); err != nil {
+		return entities.Template{}, err
+	}
+	template.OwnerId = userId
+	if err := usecase.checkWrite(userId, template); err != nil {
+		return entities.Template{}, err
+	}
+	return usecase.templateRepository.Save(template)
+}
+
+func (usecase *templateUsecaseImpl) Update(userId int, id int, template entities.Template) (entities.Template, error) {
+	if err := checkTemplate(template); err != nil {
+		return entities.Template{}, err
+	}
+	current, err := usecase.templateRepository.FindByIdForUser(userId, id)
+	if err != nil {
+		return entities.Template{}, err
+	}
+	if err := usecase.checkWrite(userId, current); err != nil {
+		return entities.Template{}, err
+	}
+	return usecase.templateRepository.Update(id, template)
+}
+
+func (usecase *templateUsecaseImpl) Delete(userId int, id int) error {
+	current, err := usecase.templateRepository.FindByIdForUser(userId, id)
+	if err != nil {
+		return err
+	}
+	if err := usecase.checkWrite(userId, current); err != nil {
+		return err
+	}
+	return usecase.templateRepository.Delete(id)
+}
+
+func (usecase *templateUsecaseImpl) Instantiate(userId int, id int, instance entities.Instance) (activityEntities.Activity, []activityEntities.Activity, []activityEntities.ActivityOccurrence, error) {
+	template, err := usecase.templateRepository.FindByIdForUser(userId, id)
+	if err != nil {
+		return activityEntities.Activity{}, nil, nil, err
+	}
+	user, err := usecase.userRepository.FindById(userId)
+	if err != nil {
+		return activityEntities.Activity{}, nil, nil, err
+	}
+
+	// Without a time the activity is all-day today, its day kept in the
+	// caller's timezone.
+	allDay := instance.At == nil
+	var at time.Time
+	var timezone *string
+	if allDay {
+		at = date.Of(time.Now().In(instance.Location)).In(instance.Location)
+		zone := instance.Location.String()
+		timezone = &zone
+	} else {
+		at = *instance.At
+	}
+	values := map[string]string{
+		placeholderDate: string(date.Of(at.In(instance.Location))),
+		placeholderUser: user.Name,
+	}
+
+	workspaceId := template.WorkspaceId
+	if instance.WorkspaceId != nil {
+		workspaceId = instance.WorkspaceId
+	}
+	activity := activityEntities.Activity{
+		WorkspaceId: workspaceId,
+		ProjectId:   instance.ProjectId,
+		Title:       fill(template.TitlePattern, values),
+		Category:    template.Category,
+		Description: fill(template.Description, values),
+		AllDay:      allDay,
+		Timezone:    timezone,
+	}
+	if template.Category == activityEntities.CategoryEvent {
+		activity.StartAt = &at
+	} else {
+		activity.DueAt = &at
+	}
+	titles := []string{activity.Title}
+
+	children := make([]activityEntities.Activity, 0, len(template.Checklist))
+	for _, item := range template.Checklist {
+		child := activityEntities.Activity{
+			Title:    fill(item, values),
+			Category: activityEntities.CategoryTask,
+			DueAt:    &at,
+			AllDay:   allDay,
+			Timezone: timezone,
+		}
+		children = append(children, child)
+		titles = append(titles, child.Title)
+	}
+	for _, title := range titles {
+		if utf8.RuneCountInString(title) > maxTitleLength {
+			return activityEntities.Activity{}, nil, nil, ErrTitleTooLong
+		}
+	}
+
+	return usecase.activities.CreateWithChildren(userId, activity, children, instance.Policy)
+}
+
+// checkTemplate makes sure the texts of a template only hold known
+// placeholders.
+func checkTemplate(template entities.Template) error {
+	return checkPlaceholders(append([]string{template.TitlePattern, template.Description}, template.Checklist...)...)
+}
+
+// checkWrite allows the owner of a personal template and members who may
+// write activities in the workspace of a workspace template.
+func (usecase *templateUsecaseImpl) checkWrite(userId int, template entities.Template) error {
+	if template.WorkspaceId == nil {
+		if template.OwnerId != userId {
+			return ErrForbidden
+		}
+		return nil
+	}
+
+	member, err := usecase.workspaceRepository.FindMember(*template.WorkspaceId, userId)
+	if errors.Is(err, workspaceRepo.ErrMemberNotFound) {
+		return ErrWorkspaceNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if !member.CanWriteActivities() {
+		return ErrForbidden
+	}
+	return nil
+}
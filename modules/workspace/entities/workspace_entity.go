@@ -0,0 +1,14 @@
+package entities
+
+import "time"
+
+type Workspace struct {
+	Id        int       `json:"id"         gorm:"column:id;primaryKey;autoIncrement"`
+	Name      string    `json:"name"       gorm:"column:name;size:100;not null"`
+	CreatedBy int       `json:"created_by" gorm:"column:created_by;not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	// Role is the role of the user the workspace was loaded for.
+	Role string `json:"role" gorm:"column:role;->"`
+}
+
+func (Workspace) TableName() string { return "workspaces" }
@@ -0,0 +1,17 @@
+package entities
+
+import "time"
+
+type WorkspaceInvitation struct {
+	Id          int        `json:"id"           gorm:"column:id;primaryKey;autoIncrement"`
+	WorkspaceId int        `json:"workspace_id" gorm:"column:workspace_id;not null"`
+	Email       string     `json:"email"        gorm:"column:email;size:254;not null"`
+	Role        string     `json:"role"         gorm:"column:role;not null"`
+	TokenHash   string     `json:"-"            gorm:"column:token_hash;size:64;not null;unique"`
+	InvitedBy   int        `json:"invited_by"   gorm:"column:invited_by;not null"`
+	ExpiresAt   time.Time  `json:"expires_at"   gorm:"column:expires_at;not null"`
+	AcceptedAt  *time.Time `json:"accepted_at"  gorm:"column:accepted_at"`
+	CreatedAt   time.Time  `json:"created_at"   gorm:"column:created_at;autoCreateTime"`
+}
+
+func (WorkspaceInvitation) TableName() string { return "workspace_invitations" }
@@ -0,0 +1,51 @@
+package entities
+
+import "time"
+
+const (
+	RoleOwner  = "owner"
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+	RoleViewer = "viewer"
+)
+
+var roleRank = map[string]int{
+	RoleViewer: 1,
+	RoleMember: 2,
+	RoleAdmin:  3,
+	RoleOwner:  4,
+}
+
+type WorkspaceMember struct {
+	WorkspaceId int       `json:"workspace_id" gorm:"column:workspace_id;primaryKey"`
+	UserId      int       `json:"user_id"      gorm:"column:user_id;primaryKey"`
+	Role        string    `json:"role"         gorm:"column:role;not null"`
+	CreatedAt   time.Time `json:"created_at"   gorm:"column:created_at;autoCreateTime"`
+	Email       string    `json:"email"        gorm:"column:email;->"`
+	Name        string    `json:"name"         gorm:"column:name;->"`
+}
+
+func (WorkspaceMember) TableName() string { return "workspace_members" }
+
+// CanWriteActivities reports whether the member may create, edit and delete
+// activities of the workspace. Viewers only get read access.
+func (member WorkspaceMember) CanWriteActivities() bool {
+	return roleRank[member.Role] >= roleRank[RoleMember]
+}
+
+// CanManageMembers reports whether the member may invite, remove and change
+// the role of other members.
+func (member WorkspaceMember) CanManageMembers() bool {
+	return roleRank[member.Role] >= roleRank[RoleAdmin]
+}
+
+// CanAssignRole reports whether the member may grant role to someone, or
+// change the role of someone currently holding it. Only owners can grant or
+// revoke ownership.
+func (member WorkspaceMember) CanAssignRole(role string) bool {
+	return member.CanManageMembers() && roleRank[role] <= roleRank[member.Role]
+}
+
+func (member WorkspaceMember) IsOwner() bool {
+	return member.Role == RoleOwner
+}
@@ -0,0 +1,19 @@
+package handler
+
+import "github.com/gofiber/fiber/v2"
+
+type WorkspaceHandler interface {
+	GetAll(ctx *fiber.Ctx) error
+	Get(ctx *fiber.Ctx) error
+	Create(ctx *fiber.Ctx) error
+	Update(ctx *fiber.Ctx) error
+	Delete(ctx *fiber.Ctx) error
+	GetMembers(ctx *fiber.Ctx) error
+	UpdateMemberRole(ctx *fiber.Ctx) error
+	RemoveMember(ctx *fiber.Ctx) error
+	GetInvitations(ctx *fiber.Ctx) error
+	Invite(ctx *fiber.Ctx) error
+	RevokeInvitation(ctx *fiber.Ctx) error
+	AcceptInvitation(ctx *fiber.Ctx) error
+	RegisterRoutes()
+}
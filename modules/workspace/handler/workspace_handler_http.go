@@ -0,0 +1,399 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+	authEntities "todolist-v1/modules/auth/entities"
+	"todolist-v1/modules/auth/middleware"
+	"todolist-v1/modules/workspace/entities"
+	"todolist-v1/modules/workspace/models"
+	"todolist-v1/modules/workspace/repository"
+	"todolist-v1/modules/workspace/usecase"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type workspaceHandlerHttp struct {
+	app            *fiber.App
+	usecase        usecase.WorkspaceUsecase
+	authMiddleware fiber.Handler
+	validate       *validator.Validate
+}
+
+func NewWorkspaceHttpHandler(app *fiber.App, usecase usecase.WorkspaceUsecase, authMiddleware fiber.Handler) WorkspaceHandler {
+	return &workspaceHandlerHttp{
+		app:            app,
+		usecase:        usecase,
+		authMiddleware: authMiddleware,
+		validate:       validator.New(),
+	}
+}
+
+func (handler *workspaceHandlerHttp) GetAll(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	workspaces, err := handler.usecase.GetAll(principal.UserId)
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	var workspaceResponses []models.WorkspaceResponse
+	for _, workspace := range workspaces {
+		workspaceResponses = append(workspaceResponses, toWorkspaceResponse(workspace))
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        workspaceResponses,
+		"status_code": fiber.StatusOK,
+		"message":     "Workspaces retrieved successfully",
+	})
+}
+
+func (handler *workspaceHandlerHttp) Get(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return handler.invalidId(ctx)
+	}
+
+	workspace, err := handler.usecase.Get(principal.UserId, id)
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        toWorkspaceResponse(workspace),
+		"status_code": fiber.StatusOK,
+		"message":     "Workspace retrieved successfully",
+	})
+}
+
+func (handler *workspaceHandlerHttp) Create(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	var request models.WorkspaceRequest
+	if err := handler.parse(ctx, &request); err != nil {
+		return handler.badRequest(ctx, err.Error())
+	}
+
+	workspace, err := handler.usecase.Create(principal.UserId, entities.Workspace{Name: request.Name})
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data":        toWorkspaceResponse(workspace),
+		"status_code": fiber.StatusCreated,
+		"message":     "Workspace created successfully",
+	})
+}
+
+func (handler *workspaceHandlerHttp) Update(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return handler.invalidId(ctx)
+	}
+
+	var request models.WorkspaceRequest
+	if err := handler.parse(ctx, &request); err != nil {
+		return handler.badRequest(ctx, err.Error())
+	}
+
+	workspace, err := handler.usecase.Update(principal.UserId, id, entities.Workspace{Name: request.Name})
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        toWorkspaceResponse(workspace),
+		"status_code": fiber.StatusOK,
+		"message":     "Workspace updated successfully",
+	})
+}
+
+func (handler *workspaceHandlerHttp) Delete(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return handler.invalidId(ctx)
+	}
+
+	if err := handler.usecase.Delete(principal.UserId, id); err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusOK,
+		"message":     "Workspace deleted successfully",
+	})
+}
+
+func (handler *workspaceHandlerHttp) GetMembers(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return handler.invalidId(ctx)
+	}
+
+	members, err := handler.usecase.GetMembers(principal.UserId, id)
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	var memberResponses []models.MemberResponse
+	for _, member := range members {
+		memberResponses = append(memberResponses, models.MemberResponse{
+			UserId:    member.UserId,
+			Email:     member.Email,
+			Name:      member.Name,
+			Role:      member.Role,
+			CreatedAt: member.CreatedAt,
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        memberResponses,
+		"status_code": fiber.StatusOK,
+		"message":     "Members retrieved successfully",
+	})
+}
+
+func (handler *workspaceHandlerHttp) UpdateMemberRole(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return handler.invalidId(ctx)
+	}
+	memberId, err := strconv.Atoi(ctx.Params("userId"))
+	if err != nil {
+		return handler.invalidId(ctx)
+	}
+
+	var request models.MemberRoleRequest
+	if err := handler.parse(ctx, &request); err != nil {
+		return handler.badRequest(ctx, err.Error())
+	}
+
+	if err := handler.usecase.UpdateMemberRole(principal.UserId, id, memberId, request.Role); err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusOK,
+		"message":     "Member role updated successfully",
+	})
+}
+
+func (handler *workspaceHandlerHttp) RemoveMember(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return handler.invalidId(ctx)
+	}
+	memberId, err := strconv.Atoi(ctx.Params("userId"))
+	if err != nil {
+		return handler.invalidId(ctx)
+	}
+
+	if err := handler.usecase.RemoveMember(principal.UserId, id, memberId); err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusOK,
+		"message":     "Member removed successfully",
+	})
+}
+
+func (handler *workspaceHandlerHttp) GetInvitations(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return handler.invalidId(ctx)
+	}
+
+	invitations, err := handler.usecase.GetInvitations(principal.UserId, id)
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	var invitationResponses []models.InvitationResponse
+	for _, invitation := range invitations {
+		invitationResponses = append(invitationResponses, toInvitationResponse(invitation, ""))
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        invitationResponses,
+		"status_code": fiber.StatusOK,
+		"message":     "Invitations retrieved successfully",
+	})
+}
+
+func (handler *workspaceHandlerHttp) Invite(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return handler.invalidId(ctx)
+	}
+
+	var request models.InvitationCreateRequest
+	if err := handler.parse(ctx, &request); err != nil {
+		return handler.badRequest(ctx, err.Error())
+	}
+
+	invitation, token, err := handler.usecase.Invite(principal.UserId, id, request.Email, request.Role)
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data":        toInvitationResponse(invitation, token),
+		"status_code": fiber.StatusCreated,
+		"message":     "Invitation created successfully",
+	})
+}
+
+func (handler *workspaceHandlerHttp) RevokeInvitation(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return handler.invalidId(ctx)
+	}
+	invitationId, err := strconv.Atoi(ctx.Params("invitationId"))
+	if err != nil {
+		return handler.invalidId(ctx)
+	}
+
+	if err := handler.usecase.RevokeInvitation(principal.UserId, id, invitationId); err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusOK,
+		"message":     "Invitation revoked successfully",
+	})
+}
+
+func (handler *workspaceHandlerHttp) AcceptInvitation(ctx *fiber.Ctx) error {
+	principal, _ := middleware.CurrentPrincipal(ctx)
+
+	var request models.InvitationAcceptRequest
+	if err := handler.parse(ctx, &request); err != nil {
+		return handler.badRequest(ctx, err.Error())
+	}
+
+	member, err := handler.usecase.AcceptInvitation(principal.UserId, principal.Email, request.Token)
+	if err != nil {
+		return handler.fail(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"data": models.MemberResponse{
+			UserId:    member.UserId,
+			Email:     principal.Email,
+			Role:      member.Role,
+			CreatedAt: member.CreatedAt,
+		},
+		"status_code": fiber.StatusOK,
+		"message":     "Invitation accepted successfully",
+	})
+}
+
+func (handler *workspaceHandlerHttp) RegisterRoutes() {
+	canRead := middleware.RequireScope(authEntities.ScopeWorkspacesRead)
+	canWrite := middleware.RequireScope(authEntities.ScopeWorkspacesWrite)
+
+	workspaces := handler.app.Group("/api/workspaces", handler.authMiddleware)
+	workspaces.Get("/", canRead, handler.GetAll)
+	workspaces.Post("/", canWrite, handler.Create)
+	workspaces.Get("/:id", canRead, handler.Get)
+	workspaces.Put("/:id", canWrite, handler.Update)
+	workspaces.Delete("/:id", canWrite, handler.Delete)
+	workspaces.Get("/:id/members", canRead, handler.GetMembers)
+	workspaces.Put("/:id/members/:userId", canWrite, handler.UpdateMemberRole)
+	workspaces.Delete("/:id/members/:userId", canWrite, handler.RemoveMember)
+	workspaces.Get("/:id/invitations", canRead, handler.GetInvitations)
+	workspaces.Post("/:id/invitations", canWrite, handler.Invite)
+	workspaces.Delete("/:id/invitations/:invitationId", canWrite, handler.RevokeInvitation)
+
+	handler.app.Post("/api/invitations/accept", handler.authMiddleware, middleware.RequireSession(), handler.AcceptInvitation)
+}
+
+// parse decodes the request body into request and validates it.
+func (handler *workspaceHandlerHttp) parse(ctx *fiber.Ctx, request any) error {
+	if err := ctx.BodyParser(request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Cannot parse JSON")
+	}
+	return handler.validate.Struct(request)
+}
+
+func (handler *workspaceHandlerHttp) badRequest(ctx *fiber.Ctx, message string) error {
+	return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"data":        nil,
+		"status_code": fiber.StatusBadRequest,
+		"message":     message,
+	})
+}
+
+func (handler *workspaceHandlerHttp) invalidId(ctx *fiber.Ctx) error {
+	return handler.badRequest(ctx, "Invalid ID")
+}
+
+func (handler *workspaceHandlerHttp) fail(ctx *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, repository.ErrWorkspaceNotFound),
+		errors.Is(err, repository.ErrMemberNotFound),
+		errors.Is(err, repository.ErrInvitationNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, usecase.ErrForbidden),
+		errors.Is(err, usecase.ErrInvitationMismatch):
+		status = fiber.StatusForbidden
+	case errors.Is(err, usecase.ErrLastOwner),
+		errors.Is(err, repository.ErrAlreadyMember):
+		status = fiber.StatusConflict
+	}
+
+	return ctx.Status(status).JSON(fiber.Map{
+		"data":        nil,
+		"status_code": status,
+		"message":     err.Error(),
+	})
+}
+
+func toWorkspaceResponse(workspace entities.Workspace) models.WorkspaceResponse {
+	return models.WorkspaceResponse{
+		Id:        workspace.Id,
+		Name:      workspace.Name,
+		Role:      workspace.Role,
+		CreatedBy: workspace.CreatedBy,
+		CreatedAt: workspace.CreatedAt,
+	}
+}
+
+func toInvitationResponse(invitation entities.WorkspaceInvitation, token string) models.InvitationResponse {
+	return models.InvitationResponse{
+		Id:          invitation.Id,
+		WorkspaceId: invitation.WorkspaceId,
+		Email:       invitation.Email,
+		Role:        invitation.Role,
+		ExpiresAt:   invitation.ExpiresAt,
+		CreatedAt:   invitation.CreatedAt,
+		Token:       token,
+	}
+}
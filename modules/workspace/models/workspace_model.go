@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+type WorkspaceRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type MemberRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=owner admin member viewer"`
+}
+
+type InvitationCreateRequest struct {
+	Email string `json:"email" validate:"required,email,max=254"`
+	Role  string `json:"role" validate:"required,oneof=owner admin member viewer"`
+}
+
+type InvitationAcceptRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type WorkspaceResponse struct {
+	Id        int       `json:"id"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	CreatedBy int       `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type MemberResponse struct {
+	UserId    int       `json:"user_id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type InvitationResponse struct {
+	Id          int       `json:"id"`
+	WorkspaceId int       `json:"workspace_id"`
+	Email       string    `json:"email"`
+	Role        string    `json:"role"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	CreatedAt   time.Time `json:"created_at"`
+	// Token is only returned when the invitation is created.
+	Token string `json:"token,omitempty"`
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"errors"
+	"todolist-v1/modules/workspace/entities"
+)
+
+var (
+	ErrInvitationNotFound = errors.New("invitation not found")
+	ErrAlreadyMember      = errors.New("user is already a member of the workspace")
+)
+
+type InvitationRepository interface {
+	FindPending(workspaceId int) ([]entities.WorkspaceInvitation, error)
+	// FindPendingByHash returns the unaccepted, unexpired invitation whose
+	// token hash matches.
+	FindPendingByHash(hash string) (entities.WorkspaceInvitation, error)
+	Save(invitation entities.WorkspaceInvitation) (entities.WorkspaceInvitation, error)
+	Delete(workspaceId int, id int) error
+	// Accept marks the invitation accepted and adds userId to the workspace
+	// with the invited role in a single transaction.
+	Accept(id int, userId int) (entities.WorkspaceMember, error)
+}
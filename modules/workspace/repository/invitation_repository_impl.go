@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"errors"
+	"time"
+	"todolist-v1/modules/workspace/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type invitationRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewInvitationRepository(db *gorm.DB) InvitationRepository {
+	return &invitationRepositoryImpl{DB: db}
+}
+
+func (repository *invitationRepositoryImpl) FindPending(workspaceId int) ([]entities.WorkspaceInvitation, error) {
+	var invitations []entities.WorkspaceInvitation
+	err := repository.DB.
+		Where("workspace_id = ? AND accepted_at IS NULL AND expires_at > ?", workspaceId, time.Now()).
+		Order("created_at DESC").
+		Find(&invitations).Error
+	if err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+func (repository *invitationRepositoryImpl) FindPendingByHash(hash string) (entities.WorkspaceInvitation, error) {
+	var invitation entities.WorkspaceInvitation
+	err := repository.DB.
+		Where("token_hash = ? AND accepted_at IS NULL AND expires_at > ?", hash, time.Now()).
+		First(&invitation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.WorkspaceInvitation{}, ErrInvitationNotFound
+		}
+		return entities.WorkspaceInvitation{}, err
+	}
+	return invitation, nil
+}
+
+func (repository *invitationRepositoryImpl) Save(invitation entities.WorkspaceInvitation) (entities.WorkspaceInvitation, error) {
+	if err := repository.DB.Create(&invitation).Error; err != nil {
+		return entities.WorkspaceInvitation{}, err
+	}
+	return invitation, nil
+}
+
+func (repository *invitationRepositoryImpl) Delete(workspaceId int, id int) error {
+	result := repository.DB.
+		Where("workspace_id = ? AND accepted_at IS NULL", workspaceId).
+		Delete(&entities.WorkspaceInvitation{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvitationNotFound
+	}
+	return nil
+}
+
+func (repository *invitationRepositoryImpl) Accept(id int, userId int) (entities.WorkspaceMember, error) {
+	var member entities.WorkspaceMember
+	err := repository.DB.Transaction(func(tx *gorm.DB) error {
+		var invitation entities.WorkspaceInvitation
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND accepted_at IS NULL", id).
+			First(&invitation).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrInvitationNotFound
+			}
+			return err
+		}
+
+		member = entities.WorkspaceMember{
+			WorkspaceId: invitation.WorkspaceId,
+			UserId:      userId,
+			Role:        invitation.Role,
+		}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&member)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrAlreadyMember
+		}
+
+		return tx.Model(&invitation).Update("accepted_at", time.Now()).Error
+	})
+	if err != nil {
+		return entities.WorkspaceMember{}, err
+	}
+	return member, nil
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"errors"
+	"todolist-v1/modules/workspace/entities"
+)
+
+var (
+	ErrWorkspaceNotFound = errors.New("workspace not found")
+	ErrMemberNotFound    = errors.New("workspace member not found")
+)
+
+type WorkspaceRepository interface {
+	// FindAllByUser returns the workspaces userId is a member of, with Role
+	// set to the user's role.
+	FindAllByUser(userId int) ([]entities.Workspace, error)
+	FindByIdForUser(userId int, id int) (entities.Workspace, error)
+	// Save creates the workspace and makes its creator the owner.
+	Save(workspace entities.Workspace) (entities.Workspace, error)
+	Update(id int, workspace entities.Workspace) (entities.Workspace, error)
+	Delete(id int) error
+
+	FindMember(workspaceId int, userId int) (entities.WorkspaceMember, error)
+	// LockMember finds the membership and locks it until the surrounding
+	// transaction ends.
+	LockMember(workspaceId int, userId int) (entities.WorkspaceMember, error)
+	FindMembers(workspaceId int) ([]entities.WorkspaceMember, error)
+	// SharesWorkspace reports whether both users are members of a common
+	// workspace.
+	SharesWorkspace(userId int, otherUserId int) (bool, error)
+	// CountOwners counts the owners of the workspace and locks their
+	// memberships until the surrounding transaction ends, so that of two
+	// transactions demoting or removing owners the second sees the first.
+	CountOwners(workspaceId int) (int64, error)
+	UpdateMemberRole(workspaceId int, userId int, role string) error
+	DeleteMember(workspaceId int, userId int) error
+	// Transaction runs fn with a repository working in one transaction,
+	// committed when fn returns nil.
+	Transaction(fn func(workspaces WorkspaceRepository) error) error
+}
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"errors"
+	"todolist-v1/modules/workspace/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type workspaceRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewWorkspaceRepository(db *gorm.DB) WorkspaceRepository {
+	return &workspaceRepositoryImpl{DB: db}
+}
+
+func (repository *workspaceRepositoryImpl) memberOf(userId int) *gorm.DB {
+	return repository.DB.
+		Select("workspaces.*, workspace_members.role").
+		Joins("JOIN workspace_members ON workspace_members.workspace_id = workspaces.id AND workspace_members.user_id = ?", userId)
+}
+
+func (repository *workspaceRepositoryImpl) FindAllByUser(userId int) ([]entities.Workspace, error) {
+	var workspaces []entities.Workspace
+	if err := repository.memberOf(userId).Order("workspaces.name").Find(&workspaces).Error; err != nil {
+		return nil, err
+	}
+	return workspaces, nil
+}
+
+func (repository *workspaceRepositoryImpl) FindByIdForUser(userId int, id int) (entities.Workspace, error) {
+	var workspace entities.Workspace
+	if err := repository.memberOf(userId).Where("workspaces.id = ?", id).First(&workspace).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.Workspace{}, ErrWorkspaceNotFound
+		}
+		return entities.Workspace{}, err
+	}
+	return workspace, nil
+}
+
+func (repository *workspaceRepositoryImpl) Save(workspace entities.Workspace) (entities.Workspace, error) {
+	err := repository.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&workspace).Error; err != nil {
+			return err
+		}
+		return tx.Create(&entities.WorkspaceMember{
+			WorkspaceId: workspace.Id,
+			UserId:      workspace.CreatedBy,
+			Role:        entities.RoleOwner,
+		}).Error
+	})
+	if err != nil {
+		return entities.Workspace{}, err
+	}
+
+	workspace.Role = entities.RoleOwner
+	return workspace, nil
+}
+
+func (repository *workspaceRepositoryImpl) Update(id int, workspace entities.Workspace) (entities.Workspace, error) {
+	result := repository.DB.Model(&entities.Workspace{}).Where("id = ?", id).Update("name", workspace.Name)
+	if result.Error != nil {
+		return entities.Workspace{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.Workspace{}, ErrWorkspaceNotFound
+	}
+
+	var updated entities.Workspace
+	if err := repository.DB.First(&updated, id).Error; err != nil {
+		return entities.Workspace{}, err
+	}
+	return updated, nil
+}
+
+func (repository *workspaceRepositoryImpl) Delete(id int) error {
+	result := repository.DB.Delete(&entities.Workspace{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrWorkspaceNotFound
+	}
+	return nil
+}
+
+func (repository *workspaceRepositoryImpl) FindMember(workspaceId int, userId int) (entities.WorkspaceMember, error) {
+	var member entities.WorkspaceMember
+	err := repository.DB.
+		Where("workspace_id = ? AND user_id = ?", workspaceId, userId).
+		First(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.WorkspaceMember{}, ErrMemberNotFound
+		}
+		return entities.WorkspaceMember{}, err
+	}
+	return member, nil
+}
+
+func (repository *workspaceRepositoryImpl) LockMember(workspaceId int, userId int) (entities.WorkspaceMember, error) {
+	var member entities.WorkspaceMember
+	err := repository.DB.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("workspace_id = ? AND user_id = ?", workspaceId, userId).
+		First(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.WorkspaceMember{}, ErrMemberNotFound
+		}
+		return entities.WorkspaceMember{}, err
+	}
+	return member, nil
+}
+
+func (repository *workspaceRepositoryImpl) SharesWorkspace(userId int, otherUserId int) (bool, error) {
+	var count int64
+	err := repository.DB.Table("workspace_members AS mine").
+		Joins("JOIN workspace_members AS theirs ON theirs.workspace_id = mine.workspace_id").
+		Where("mine.user_id = ? AND theirs.user_id = ?", userId, otherUserId).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (repository *workspaceRepositoryImpl) FindMembers(workspaceId int) ([]entities.WorkspaceMember, error) {
+	var members []entities.WorkspaceMember
+	err := repository.DB.
+		Select("workspace_members.*, users.email, users.name").
+		Joins("JOIN users ON users.id = workspace_members.user_id").
+		Where("workspace_members.workspace_id = ?", workspaceId).
+		Order("workspace_members.created_at").
+		Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (repository *workspaceRepositoryImpl) CountOwners(workspaceId int) (int64, error) {
+	var owners []int
+	err := repository.DB.Model(&entities.WorkspaceMember{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("workspace_id = ? AND role = ?", workspaceId, entities.RoleOwner).
+		Pluck("user_id", &owners).Error
+	return int64(len(owners)), err
+}
+
+func (repository *workspaceRepositoryImpl) UpdateMemberRole(workspaceId int, userId int, role string) error {
+	result := repository.DB.Model(&entities.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ?", workspaceId, userId).
+		Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrMemberNotFound
+	}
+	return nil
+}
+
+func (repository *workspaceRepositoryImpl) DeleteMember(workspaceId int, userId int) error {
+	result := repository.DB.
+		Where("workspace_id = ? AND user_id = ?", workspaceId, userId).
+		Delete(&entities.WorkspaceMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrMemberNotFound
+	}
+	return nil
+}
+
+func (repository *workspaceRepositoryImpl) Transaction(fn func(workspaces WorkspaceRepository) error) error {
+	return repository.DB.Transaction(func(tx *gorm.DB) error {
+		return fn(&workspaceRepositoryImpl{DB: tx})
+	})
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"errors"
+	"todolist-v1/modules/workspace/entities"
+)
+
+var (
+	ErrForbidden          = errors.New("insufficient workspace role")
+	ErrLastOwner          = errors.New("a workspace must keep at least one owner")
+	ErrInvitationMismatch = errors.New("invitation was issued for a different email address")
+)
+
+// WorkspaceUsecase enforces workspace roles. Callers that are not members of
+// a workspace get repository.ErrWorkspaceNotFound, never ErrForbidden, so the
+// existence of other teams' workspaces is not revealed.
+type WorkspaceUsecase interface {
+	GetAll(userId int) ([]entities.Workspace, error)
+	Get(userId int, id int) (entities.Workspace, error)
+	Create(userId int, workspace entities.Workspace) (entities.Workspace, error)
+	Update(userId int, id int, workspace entities.Workspace) (entities.Workspace, error)
+	Delete(userId int, id int) error
+
+	GetMembers(userId int, workspaceId int) ([]entities.WorkspaceMember, error)
+	UpdateMemberRole(userId int, workspaceId int, memberId int, role string) error
+	// RemoveMember removes memberId from the workspace. Members may always
+	// remove themselves.
+	RemoveMember(userId int, workspaceId int, memberId int) error
+
+	GetInvitations(userId int, workspaceId int) ([]entities.WorkspaceInvitation, error)
+	// Invite returns the stored invitation and the plain-text token to hand
+	// to the invitee; only a hash of the token is persisted.
+	Invite(userId int, workspaceId int, email string, role string) (entities.WorkspaceInvitation, string, error)
+	RevokeInvitation(userId int, workspaceId int, invitationId int) error
+	AcceptInvitation(userId int, email string, token string) (entities.WorkspaceMember, error)
+}
@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+	"todolist-v1/modules/workspace/entities"
+	"todolist-v1/modules/workspace/repository"
+)
+
+const invitationTTL = 7 * 24 * time.Hour
+
+type workspaceUsecaseImpl struct {
+	workspaceRepository  repository.WorkspaceRepository
+	invitationRepository repository.InvitationRepository
+}
+
+func NewWorkspaceUsecase(workspaceRepository repository.WorkspaceRepository, invitationRepository repository.InvitationRepository) WorkspaceUsecase {
+	return &workspaceUsecaseImpl{
+		workspaceRepository:  workspaceRepository,
+		invitationRepository: invitationRepository,
+	}
+}
+
+func (usecase *workspaceUsecaseImpl) GetAll(userId int) ([]entities.Workspace, error) {
+	return usecase.workspaceRepository.FindAllByUser(userId)
+}
+
+func (usecase *workspaceUsecaseImpl) Get(userId int, id int) (entities.Workspace, error) {
+	return usecase.workspaceRepository.FindByIdForUser(userId, id)
+}
+
+func (usecase *workspaceUsecaseImpl) Create(userId int, workspace entities.Workspace) (entities.Workspace, error) {
+	workspace.CreatedBy = userId
+	return usecase.workspaceRepository.Save(workspace)
+}
+
+func (usecase *workspaceUsecaseImpl) Update(userId int, id int, workspace entities.Workspace) (entities.Workspace, error) {
+	caller, err := usecase.member(userId, id)
+	if err != nil {
+		return entities.Workspace{}, err
+	}
+	if !caller.CanManageMembers() {
+		return entities.Workspace{}, ErrForbidden
+	}
+
+	updated, err := usecase.workspaceRepository.Update(id, workspace)
+	if err != nil {
+		return entities.Workspace{}, err
+	}
+	updated.Role = caller.Role
+	return updated, nil
+}
+
+func (usecase *workspaceUsecaseImpl) Delete(userId int, id int) error {
+	caller, err := usecase.member(userId, id)
+	if err != nil {
+		return err
+	}
+	if !caller.IsOwner() {
+		return ErrForbidden
+	}
+	return usecase.workspaceRepository.Delete(id)
+}
+
+func (usecase *workspaceUsecaseImpl) GetMembers(userId int, workspaceId int) ([]entities.WorkspaceMember, error) {
+	if _, err := usecase.member(userId, workspaceId); err != nil {
+		return nil, err
+	}
+	return usecase.workspaceRepository.FindMembers(workspaceId)
+}
+
+// UpdateMemberRole and RemoveMember lock the owners first, then the caller,
+// so that the caller's role cannot change until the change is made.
+func (usecase *workspaceUsecaseImpl) UpdateMemberRole(userId int, workspaceId int, memberId int, role string) error {
+	return usecase.workspaceRepository.Transaction(func(workspaces repository.WorkspaceRepository) error {
+		owners, err := workspaces.CountOwners(workspaceId)
+		if err != nil {
+			return err
+		}
+		caller, err := lockMember(workspaces, userId, workspaceId)
+		if err != nil {
+			return err
+		}
+		target, err := workspaces.FindMember(workspaceId, memberId)
+		if err != nil {
+			return err
+		}
+		if !caller.CanAssignRole(role) || !caller.CanAssignRole(target.Role) {
+			return ErrForbidden
+		}
+		if target.IsOwner() && role != entities.RoleOwner && owners <= 1 {
+			return ErrLastOwner
+		}
+		return workspaces.UpdateMemberRole(workspaceId, memberId, role)
+	})
+}
+
+func (usecase *workspaceUsecaseImpl) RemoveMember(userId int, workspaceId int, memberId int) error {
+	return usecase.workspaceRepository.Transaction(func(workspaces repository.WorkspaceRepository) error {
+		owners, err := workspaces.CountOwners(workspaceId)
+		if err != nil {
+			return err
+		}
+		caller, err := lockMember(workspaces, userId, workspaceId)
+		if err != nil {
+			return err
+		}
+		target, err := workspaces.FindMember(workspaceId, memberId)
+		if err != nil {
+			return err
+		}
+		if memberId != userId && !caller.CanAssignRole(target.Role) {
+			return ErrForbidden
+		}
+		if target.IsOwner() && owners <= 1 {
+			return ErrLastOwner
+		}
+		return workspaces.DeleteMember(workspaceId, memberId)
+	})
+}
+
+func (usecase *workspaceUsecaseImpl) GetInvitations(userId int, workspaceId int) ([]entities.WorkspaceInvitation, error) {
+	caller, err := usecase.member(userId, workspaceId)
+	if err != nil {
+		return nil, err
+	}
+	if !caller.CanManageMembers() {
+		return nil, ErrForbidden
+	}
+	return usecase.invitationRepository.FindPending(workspaceId)
+}
+
+func (usecase *workspaceUsecaseImpl) Invite(userId int, workspaceId int, email string, role string) (entities.WorkspaceInvitation, string, error) {
+	caller, err := usecase.member(userId, workspaceId)
+	if err != nil {
+		return entities.WorkspaceInvitation{}, "", err
+	}
+	if !caller.CanAssignRole(role) {
+		return entities.WorkspaceInvitation{}, "", ErrForbidden
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return entities.WorkspaceInvitation{}, "", err
+	}
+	token := hex.EncodeToString(buf)
+
+	invitation, err := usecase.invitationRepository.Save(entities.WorkspaceInvitation{
+		WorkspaceId: workspaceId,
+		Email:       strings.TrimSpace(email),
+		Role:        role,
+		TokenHash:   hashInvitationToken(token),
+		InvitedBy:   userId,
+		ExpiresAt:   time.Now().Add(invitationTTL),
+	})
+	if err != nil {
+		return entities.WorkspaceInvitation{}, "", err
+	}
+	return invitation, token, nil
+}
+
+func (usecase *workspaceUsecaseImpl) RevokeInvitation(userId int, workspaceId int, invitationId int) error {
+	caller, err := usecase.member(userId, workspaceId)
+	if err != nil {
+		return err
+	}
+	if !caller.CanManageMembers() {
+		return ErrForbidden
+	}
+	return usecase.invitationRepository.Delete(workspaceId, invitationId)
+}
+
+func (usecase *workspaceUsecaseImpl) AcceptInvitation(userId int, email string, token string) (entities.WorkspaceMember, error) {
+	invitation, err := usecase.invitationRepository.FindPendingByHash(hashInvitationToken(token))
+	if err != nil {
+		return entities.WorkspaceMember{}, err
+	}
+	if !strings.EqualFold(invitation.Email, email) {
+		return entities.WorkspaceMember{}, ErrInvitationMismatch
+	}
+	return usecase.invitationRepository.Accept(invitation.Id, userId)
+}
+
+// member returns the caller's membership, reporting non-members as
+// repository.ErrWorkspaceNotFound.
+func (usecase *workspaceUsecaseImpl) member(userId int, workspaceId int) (entities.WorkspaceMember, error) {
+	member, err := usecase.workspaceRepository.FindMember(workspaceId, userId)
+	if errors.Is(err, repository.ErrMemberNotFound) {
+		return entities.WorkspaceMember{}, repository.ErrWorkspaceNotFound
+	}
+	return member, err
+}
+
+// lockMember is member for a repository working in a transaction, locking
+// the membership until it ends.
+func lockMember(workspaces repository.WorkspaceRepository, userId int, workspaceId int) (entities.WorkspaceMember, error) {
+	member, err := workspaces.LockMember(workspaceId, userId)
+	if errors.Is(err, repository.ErrMemberNotFound) {
+		return entities.WorkspaceMember{}, repository.ErrWorkspaceNotFound
+	}
+	return member, err
+}
+
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,88 @@
+// Package cache keeps computed values in memory for a limited time, so that
+// expensive queries run at most once per key and period however often they
+// are asked for.
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// call is a load in progress that later callers for the same key wait for.
+type call[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// Cache maps keys to values that expire ttl after they were loaded. It is
+// safe for concurrent use.
+type Cache[K comparable, V any] struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[K]entry[V]
+	loading map[K]*call[V]
+	// generation changes on every invalidation, so that loads started
+	// before it do not store values that may already be stale.
+	generation uint64
+}
+
+func New[K comparable, V any](ttl time.Duration) *Cache[K, V] {
+	return &Cache[K, V]{
+		ttl:     ttl,
+		entries: make(map[K]entry[V]),
+		loading: make(map[K]*call[V]),
+	}
+}
+
+// Get returns the value stored for key, or loads and stores it when there is
+// none or it expired. Concurrent calls for the same key share a single load.
+// Errors are returned to every waiting caller but not stored.
+func (cache *Cache[K, V]) Get(key K, load func() (V, error)) (V, error) {
+	cache.mu.Lock()
+	if cached, ok := cache.entries[key]; ok && time.Now().Before(cached.expiresAt) {
+		cache.mu.Unlock()
+		return cached.value, nil
+	}
+	if pending, ok := cache.loading[key]; ok {
+		cache.mu.Unlock()
+		<-pending.done
+		return pending.value, pending.err
+	}
+	pending := &call[V]{done: make(chan struct{})}
+	cache.loading[key] = pending
+	generation := cache.generation
+	cache.mu.Unlock()
+
+	pending.value, pending.err = load()
+
+	cache.mu.Lock()
+	delete(cache.loading, key)
+	if pending.err == nil && generation == cache.generation {
+		cache.entries[key] = entry[V]{value: pending.value, expiresAt: time.Now().Add(cache.ttl)}
+	}
+	cache.mu.Unlock()
+	close(pending.done)
+
+	return pending.value, pending.err
+}
+
+// Invalidate drops the values whose keys match, along with expired ones.
+func (cache *Cache[K, V]) Invalidate(match func(key K) bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.generation++
+	now := time.Now()
+	for key, cached := range cache.entries {
+		if match(key) || !now.Before(cached.expiresAt) {
+			delete(cache.entries, key)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Package date provides a calendar day without a time of day or timezone,
+// stored in DATE columns and encoded as YYYY-MM-DD.
+package date
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// Date is a calendar day in YYYY-MM-DD form.
+type Date string
+
+// Of returns the day t falls on in its own location.
+func Of(t time.Time) Date {
+	return Date(t.Format(time.DateOnly))
+}
+
+// Parse checks that value is a valid YYYY-MM-DD day.
+func Parse(value string) (Date, error) {
+	if _, err := time.Parse(time.DateOnly, value); err != nil {
+		return "", err
+	}
+	return Date(value), nil
+}
+
+// In returns the start of the day in loc.
+func (d Date) In(loc *time.Location) time.Time {
+	t, _ := time.ParseInLocation(time.DateOnly, string(d), loc)
+	return t
+}
+
+// AddDays returns the day the given number of days later.
+func (d Date) AddDays(days int) Date {
+	return Of(d.In(time.UTC).AddDate(0, 0, days))
+}
+
+// Value stores the day as text so that the database session timezone cannot
+// shift it.
+func (d Date) Value() (driver.Value, error) {
+	return string(d), nil
+}
+
+func (d *Date) Scan(value any) error {
+	switch v := value.(type) {
+	case time.Time:
+		*d = Date(v.Format(time.DateOnly))
+	case string:
+		*d = Date(v)
+	case []byte:
+		*d = Date(v)
+	default:
+		return fmt.Errorf("cannot scan %T into date.Date", value)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package date
+
+import (
+	"errors"
+	"time"
+	_ "time/tzdata"
+)
+
+var ErrUnknownLocation = errors.New("unknown timezone")
+
+// LoadLocation returns the IANA timezone with the given name, such as
+// Europe/Paris. Unlike time.LoadLocation it refuses "" and "Local", which
+// stand for the timezone of the process: timezone names end up in SQL,
+// where Postgres must know them too.
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, ErrUnknownLocation
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil || location.String() != name {
+		return nil, ErrUnknownLocation
+	}
+	return location, nil
+}
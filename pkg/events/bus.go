@@ -0,0 +1,35 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+type inProcessBus struct {
+	mutex    sync.RWMutex
+	handlers map[string][]Handler
+}
+
+func NewBus() Bus {
+	return &inProcessBus{handlers: make(map[string][]Handler)}
+}
+
+func (bus *inProcessBus) Publish(event Event) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	bus.mutex.RLock()
+	handlers := append(append([]Handler{}, bus.handlers[event.Name]...), bus.handlers[AllEvents]...)
+	bus.mutex.RUnlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
+
+func (bus *inProcessBus) Subscribe(name string, handler Handler) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	bus.handlers[name] = append(bus.handlers[name], handler)
+}
@@ -0,0 +1,27 @@
+package events
+
+import "time"
+
+// Event describes a change that has been committed. Payload holds the
+// entity the event is about, such as the history entry of an activity
+// change or a comment.
+type Event struct {
+	Name       string
+	ActorId    int
+	ActivityId int
+	Payload    any
+	OccurredAt time.Time
+}
+
+type Handler func(event Event)
+
+// AllEvents subscribes a handler to every event name.
+const AllEvents = "*"
+
+// Bus delivers published events to the handlers subscribed to their name.
+// Handlers run synchronously inside Publish and should hand slow work off
+// to a goroutine of their own.
+type Bus interface {
+	Publish(event Event)
+	Subscribe(name string, handler Handler)
+}
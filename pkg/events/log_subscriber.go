@@ -0,0 +1,14 @@
+package events
+
+import "github.com/sirupsen/logrus"
+
+// NewLogSubscriber returns a handler that writes every event to log.
+func NewLogSubscriber(log *logrus.Logger) Handler {
+	return func(event Event) {
+		log.WithFields(logrus.Fields{
+			"event":       event.Name,
+			"actor_id":    event.ActorId,
+			"activity_id": event.ActivityId,
+		}).Info("Event published")
+	}
+}
@@ -0,0 +1,203 @@
+package filterquery
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"todolist-v1/pkg/date"
+
+	"gorm.io/gorm/clause"
+)
+
+// Kind tells how a field compares with values.
+type Kind int
+
+const (
+	// Enum fields hold one of Values, matched case-insensitively. Only
+	// Ordered enums take <, <=, > and >=, comparing values as text.
+	Enum Kind = iota
+	// Tag fields are text arrays; : and = match arrays holding the value,
+	// lowercased and without a leading #.
+	Tag
+	// Text fields are matched case-insensitively: : looks for the value
+	// anywhere in the text, = and != compare all of it.
+	Text
+	// Date fields are timestamps compared by the day they fall on,
+	// written YYYY-MM-DD. Rows without a timestamp never match.
+	Date
+	// Number fields are integer ids.
+	Number
+)
+
+// Field is a field expressions may compare, backed by Column.
+type Field struct {
+	Column  string
+	Kind    Kind
+	Values  []string
+	Ordered bool
+}
+
+// Schema maps the field names expressions use to their fields.
+type Schema map[string]Field
+
+// Compile turns an expression into a condition over the schema's columns,
+// with every value passed as a parameter. Days of Date fields start at
+// midnight in location.
+func Compile(node Node, schema Schema, location *time.Location) (clause.Expr, error) {
+	switch node := node.(type) {
+	case *And:
+		return combine(node.Left, "AND", node.Right, schema, location)
+	case *Or:
+		return combine(node.Left, "OR", node.Right, schema, location)
+	case *Not:
+		operand, err := Compile(node.Operand, schema, location)
+		if err != nil {
+			return clause.Expr{}, err
+		}
+		return clause.Expr{SQL: "NOT " + operand.SQL, Vars: operand.Vars}, nil
+	case *Comparison:
+		field, ok := schema[node.Field]
+		if !ok {
+			return clause.Expr{}, errorAt(node.FieldPosition, "unknown field %q, expected one of %s", node.Field, schema.names())
+		}
+		condition, err := field.compare(node, location)
+		if err != nil {
+			return clause.Expr{}, err
+		}
+		condition.SQL = "(" + condition.SQL + ")"
+		return condition, nil
+	}
+	panic("filterquery: unknown node")
+}
+
+func combine(left Node, operator string, right Node, schema Schema, location *time.Location) (clause.Expr, error) {
+	l, err := Compile(left, schema, location)
+	if err != nil {
+		return clause.Expr{}, err
+	}
+	r, err := Compile(right, schema, location)
+	if err != nil {
+		return clause.Expr{}, err
+	}
+	return clause.Expr{
+		SQL:  "(" + l.SQL + " " + operator + " " + r.SQL + ")",
+		Vars: append(append([]any{}, l.Vars...), r.Vars...),
+	}, nil
+}
+
+// names lists the schema's fields for error messages.
+func (schema Schema) names() string {
+	names := make([]string, 0, len(schema))
+	for name := range schema {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return strings.Join(names, ", ")
+}
+
+// equality returns the SQL operator for : and = or !=, and false for the
+// ordering operators.
+func equality(operator string) (string, bool) {
+	switch operator {
+	case OpMatch, OpEqual:
+		return "=", true
+	case OpNotEqual:
+		return "<>", true
+	}
+	return "", false
+}
+
+func (field Field) compare(c *Comparison, location *time.Location) (clause.Expr, error) {
+	column := field.Column
+	switch field.Kind {
+	case Enum:
+		value, ok := field.value(c.Value)
+		if !ok {
+			return clause.Expr{}, errorAt(c.ValuePosition, "invalid %s %q, expected one of %s", c.Field, c.Value, strings.Join(field.Values, ", "))
+		}
+		operator, ok := equality(c.Operator)
+		if !ok {
+			if !field.Ordered {
+				return clause.Expr{}, field.unsupported(c)
+			}
+			operator = c.Operator
+		}
+		return clause.Expr{SQL: column + " " + operator + " ?", Vars: []any{value}}, nil
+
+	case Tag:
+		tag := strings.ToLower(strings.TrimPrefix(c.Value, "#"))
+		if tag == "" {
+			return clause.Expr{}, errorAt(c.ValuePosition, "empty tag")
+		}
+		switch c.Operator {
+		case OpMatch, OpEqual:
+			return clause.Expr{SQL: "? = ANY(" + column + ")", Vars: []any{tag}}, nil
+		case OpNotEqual:
+			return clause.Expr{SQL: "NOT ? = ANY(" + column + ")", Vars: []any{tag}}, nil
+		}
+		return clause.Expr{}, field.unsupported(c)
+
+	case Text:
+		switch c.Operator {
+		case OpMatch:
+			return clause.Expr{SQL: column + " ILIKE ?", Vars: []any{"%" + escapeLike(c.Value) + "%"}}, nil
+		case OpEqual:
+			return clause.Expr{SQL: column + " ILIKE ?", Vars: []any{escapeLike(c.Value)}}, nil
+		case OpNotEqual:
+			return clause.Expr{SQL: column + " NOT ILIKE ?", Vars: []any{escapeLike(c.Value)}}, nil
+		}
+		return clause.Expr{}, field.unsupported(c)
+
+	case Date:
+		day, err := date.Parse(c.Value)
+		if err != nil {
+			return clause.Expr{}, errorAt(c.ValuePosition, "invalid date %q, expected YYYY-MM-DD", c.Value)
+		}
+		start, end := day.In(location), day.AddDays(1).In(location)
+		switch c.Operator {
+		case OpMatch, OpEqual:
+			return clause.Expr{SQL: column + " >= ? AND " + column + " < ?", Vars: []any{start, end}}, nil
+		case OpNotEqual:
+			return clause.Expr{SQL: column + " < ? OR " + column + " >= ?", Vars: []any{start, end}}, nil
+		case OpLess:
+			return clause.Expr{SQL: column + " < ?", Vars: []any{start}}, nil
+		case OpLessEqual:
+			return clause.Expr{SQL: column + " < ?", Vars: []any{end}}, nil
+		case OpGreater:
+			return clause.Expr{SQL: column + " >= ?", Vars: []any{end}}, nil
+		default:
+			return clause.Expr{SQL: column + " >= ?", Vars: []any{start}}, nil
+		}
+
+	default:
+		id, err := strconv.Atoi(c.Value)
+		if err != nil {
+			return clause.Expr{}, errorAt(c.ValuePosition, "invalid %s %q, expected a number", c.Field, c.Value)
+		}
+		operator, ok := equality(c.Operator)
+		if !ok {
+			return clause.Expr{}, field.unsupported(c)
+		}
+		return clause.Expr{SQL: column + " " + operator + " ?", Vars: []any{id}}, nil
+	}
+}
+
+// value finds the enum value matching raw.
+func (field Field) value(raw string) (string, bool) {
+	for _, value := range field.Values {
+		if strings.EqualFold(value, raw) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func (field Field) unsupported(c *Comparison) *Error {
+	return errorAt(c.OpPosition, "operator %q does not apply to %s", c.Operator, c.Field)
+}
+
+// escapeLike makes the wildcards of ILIKE patterns match themselves.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
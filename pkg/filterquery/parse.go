@@ -0,0 +1,305 @@
+// Package filterquery reads filter expressions such as
+//
+//	status:NEW AND (category:TASK OR tag:urgent) AND date<2026-11-01
+//
+// and compiles them into parameterized SQL conditions.
+//
+// An expression compares fields with values using : (matches), =, !=, <, <=,
+// > or >=, and combines comparisons with NOT, AND and OR, binding in that
+// order, and parentheses. Keywords are case-insensitive. Values holding
+// spaces or operator characters are quoted: title:"weekly sync". Which
+// fields exist and the values they take is up to the Schema an expression
+// is compiled against.
+//
+// Errors point at the position of the offending character, counting runes
+// from 1.
+package filterquery
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxDepth bounds how deeply expressions nest, parentheses and NOT included.
+const maxDepth = 32
+
+// Error is a syntax error or a comparison that does not fit the schema.
+type Error struct {
+	Position int
+	Message  string
+}
+
+func (err *Error) Error() string {
+	return fmt.Sprintf("%s at position %d", err.Message, err.Position)
+}
+
+func errorAt(position int, format string, args ...any) *Error {
+	return &Error{Position: position, Message: fmt.Sprintf(format, args...)}
+}
+
+// Node is a parsed expression: an *And, *Or, *Not or *Comparison.
+type Node interface {
+	node()
+}
+
+type And struct{ Left, Right Node }
+
+type Or struct{ Left, Right Node }
+
+type Not struct{ Operand Node }
+
+// Comparison compares a field with a value. Positions are those of the
+// field, the operator and the value in the expression.
+type Comparison struct {
+	Field         string
+	Operator      string
+	Value         string
+	FieldPosition int
+	OpPosition    int
+	ValuePosition int
+}
+
+func (*And) node()        {}
+func (*Or) node()         {}
+func (*Not) node()        {}
+func (*Comparison) node() {}
+
+// Operators comparisons accept.
+const (
+	OpMatch        = ":"
+	OpEqual        = "="
+	OpNotEqual     = "!="
+	OpLess         = "<"
+	OpLessEqual    = "<="
+	OpGreater      = ">"
+	OpGreaterEqual = ">="
+)
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenOpen
+	tokenClose
+)
+
+type token struct {
+	kind     tokenKind
+	text     string
+	position int
+}
+
+// keyword returns the keyword the token stands for, if any. Quoted words
+// are never keywords.
+func (t token) keyword() string {
+	if t.kind != tokenWord {
+		return ""
+	}
+	switch upper := strings.ToUpper(t.text); upper {
+	case "AND", "OR", "NOT":
+		return upper
+	}
+	return ""
+}
+
+// describe names the token in error messages.
+func (t token) describe() string {
+	if t.kind == tokenEnd {
+		return "end of query"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// isSpecial reports whether r ends a bare word.
+func isSpecial(r rune) bool {
+	return strings.ContainsRune(`():=!<>"`, r) || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+}
+
+func tokenize(text string) ([]token, error) {
+	runes := []rune(text)
+	var tokens []token
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		position := i + 1
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenOpen, text: "(", position: position})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenClose, text: ")", position: position})
+			i++
+		case r == ':' || r == '=':
+			tokens = append(tokens, token{kind: tokenOperator, text: string(r), position: position})
+			i++
+		case r == '<' || r == '>' || r == '!':
+			operator := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				operator += "="
+			}
+			if operator == "!" {
+				return nil, errorAt(position, `unexpected "!", did you mean "!="`)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: operator, position: position})
+			i += len(operator)
+		case r == '"':
+			var value strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				value.WriteRune(runes[i])
+			}
+			if i == len(runes) {
+				return nil, errorAt(position, "unterminated string")
+			}
+			tokens = append(tokens, token{kind: tokenString, text: value.String(), position: position})
+			i++
+		default:
+			start := i
+			for i < len(runes) && !isSpecial(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: string(runes[start:i]), position: position})
+		}
+	}
+	return append(tokens, token{kind: tokenEnd, position: len(runes) + 1}), nil
+}
+
+type parser struct {
+	tokens []token
+	next   int
+	depth  int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) take() token {
+	t := p.tokens[p.next]
+	if t.kind != tokenEnd {
+		p.next++
+	}
+	return t
+}
+
+// Parse reads an expression.
+func Parse(text string) (Node, error) {
+	tokens, err := tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+	p := parser{tokens: tokens}
+	if p.peek().kind == tokenEnd {
+		return nil, errorAt(p.peek().position, "empty query")
+	}
+	node, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	switch t := p.peek(); t.kind {
+	case tokenEnd:
+		return node, nil
+	case tokenClose:
+		return nil, errorAt(t.position, `unexpected ")" without a matching "("`)
+	default:
+		return nil, errorAt(t.position, "expected AND, OR or end of query, found %s", t.describe())
+	}
+}
+
+func (p *parser) or() (Node, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().keyword() == "OR" {
+		p.take()
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = &Or{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) and() (Node, error) {
+	left, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().keyword() == "AND" {
+		p.take()
+		right, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		left = &And{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) not() (Node, error) {
+	if p.peek().keyword() != "NOT" {
+		return p.primary()
+	}
+	t := p.take()
+	if p.depth++; p.depth > maxDepth {
+		return nil, errorAt(t.position, "query nests too deeply")
+	}
+	defer func() { p.depth-- }()
+
+	operand, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+	return &Not{Operand: operand}, nil
+}
+
+func (p *parser) primary() (Node, error) {
+	t := p.take()
+	switch {
+	case t.kind == tokenOpen:
+		if p.depth++; p.depth > maxDepth {
+			return nil, errorAt(t.position, "query nests too deeply")
+		}
+		defer func() { p.depth-- }()
+
+		node, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.take(); closing.kind != tokenClose {
+			return nil, errorAt(closing.position, `expected ")" to close the "(" at position %d, found %s`, t.position, closing.describe())
+		}
+		return node, nil
+	case t.kind == tokenWord && t.keyword() == "":
+		return p.comparison(t)
+	}
+	return nil, errorAt(t.position, "expected a comparison such as status:NEW, found %s", t.describe())
+}
+
+func (p *parser) comparison(field token) (Node, error) {
+	operator := p.take()
+	if operator.kind != tokenOperator {
+		return nil, errorAt(operator.position, "expected an operator after %q, found %s", field.text, operator.describe())
+	}
+	value := p.take()
+	if value.kind != tokenString && (value.kind != tokenWord || value.keyword() != "") {
+		return nil, errorAt(value.position, "expected a value after %q, found %s", field.text+operator.text, value.describe())
+	}
+	return &Comparison{
+		Field:         strings.ToLower(field.text),
+		Operator:      operator.text,
+		Value:         value.text,
+		FieldPosition: field.position,
+		OpPosition:    operator.position,
+		ValuePosition: value.position,
+	}, nil
+}
@@ -0,0 +1,23 @@
+package notifier
+
+import "github.com/sirupsen/logrus"
+
+type logNotifier struct {
+	log *logrus.Logger
+}
+
+// NewLogNotifier writes notifications to the log instead of delivering
+// them, which is useful during development.
+func NewLogNotifier(log *logrus.Logger) Notifier {
+	return &logNotifier{log: log}
+}
+
+func (notifier *logNotifier) Notify(notification Notification) error {
+	notifier.log.WithFields(logrus.Fields{
+		"to":      notification.To,
+		"kind":    notification.Kind,
+		"subject": notification.Subject,
+		"key":     notification.Key,
+	}).Info(notification.Body)
+	return nil
+}
@@ -0,0 +1,54 @@
+package notifier
+
+import (
+	"fmt"
+	"todolist-v1/config"
+
+	"github.com/sirupsen/logrus"
+)
+
+// IdempotencyHeader carries the key of a notification, in webhook
+// requests and emails alike.
+const IdempotencyHeader = "Idempotency-Key"
+
+// Notification is a message for a single user. HTMLBody is optional;
+// channels that cannot render HTML use Body.
+type Notification struct {
+	To       string
+	Subject  string
+	Body     string
+	HTMLBody string
+	// Kind identifies what the notification is about, such as "reminder".
+	Kind string
+	// Key stays the same when a notification is delivered again, so that
+	// receivers can drop duplicates. It is optional.
+	Key string
+}
+
+// Notifier delivers notifications. Implementations return an error when a
+// notification could not be handed over, so that the caller can retry it.
+type Notifier interface {
+	Notify(notification Notification) error
+}
+
+// NewNotifier creates the notifier selected in the notifications
+// configuration.
+func NewNotifier(cfg *config.Config, log *logrus.Logger) (Notifier, error) {
+	switch cfg.Notifications.Driver {
+	case "log":
+		return NewLogNotifier(log), nil
+	case "webhook":
+		return NewWebhookNotifier(cfg.Notifications.Webhook.URL, cfg.Notifications.Webhook.Secret), nil
+	case "smtp":
+		smtpConfig := cfg.Notifications.SMTP
+		return NewSMTPNotifier(SMTPOptions{
+			Host:     smtpConfig.Host,
+			Port:     smtpConfig.Port,
+			Username: smtpConfig.Username,
+			Password: smtpConfig.Password,
+			From:     smtpConfig.From,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown notification driver %q", cfg.Notifications.Driver)
+	}
+}
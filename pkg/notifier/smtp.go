@@ -0,0 +1,164 @@
+package notifier
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultSMTPTimeout is the SMTPOptions.Timeout used when none is set.
+const defaultSMTPTimeout = 30 * time.Second
+
+type SMTPOptions struct {
+	Host string
+	Port int
+	// Username and Password are optional. Credentials are only sent over
+	// TLS or to localhost, as enforced by net/smtp.
+	Username string
+	Password string
+	From     string
+	// Timeout bounds a whole delivery, from dialing the server to quitting.
+	// It defaults to 30 seconds.
+	Timeout time.Duration
+}
+
+type smtpNotifier struct {
+	options SMTPOptions
+}
+
+// NewSMTPNotifier sends notifications as email. The server's STARTTLS
+// extension is used when it offers it.
+func NewSMTPNotifier(options SMTPOptions) Notifier {
+	if options.Timeout <= 0 {
+		options.Timeout = defaultSMTPTimeout
+	}
+	return &smtpNotifier{options: options}
+}
+
+func (notifier *smtpNotifier) Notify(notification Notification) error {
+	message, err := notifier.message(notification)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if notifier.options.Username != "" {
+		auth = smtp.PlainAuth("", notifier.options.Username, notifier.options.Password, notifier.options.Host)
+	}
+	return notifier.send(auth, notification.To, message)
+}
+
+// send delivers message the way smtp.SendMail does, within the timeout.
+func (notifier *smtpNotifier) send(auth smtp.Auth, to string, message []byte) error {
+	address := net.JoinHostPort(notifier.options.Host, strconv.Itoa(notifier.options.Port))
+	dialer := net.Dialer{Timeout: notifier.options.Timeout}
+	conn, err := dialer.Dial("tcp", address)
+	if err != nil {
+		return err
+	}
+	if err := conn.SetDeadline(time.Now().Add(notifier.options.Timeout)); err != nil {
+		conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, notifier.options.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: notifier.options.Host}); err != nil {
+			return err
+		}
+	}
+	if auth != nil {
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(notifier.options.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(message); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// message renders notification as a MIME message, with a text and an HTML
+// alternative when an HTML body is present.
+func (notifier *smtpNotifier) message(notification Notification) ([]byte, error) {
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", notifier.options.From)
+	fmt.Fprintf(&message, "To: %s\r\n", notification.To)
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", notification.Subject))
+	fmt.Fprintf(&message, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	if notification.Key != "" {
+		// Mail clients also drop messages with a Message-ID already seen.
+		_, domain, _ := strings.Cut(notifier.options.From, "@")
+		fmt.Fprintf(&message, "Message-ID: <%s@%s>\r\n", notification.Key, strings.TrimRight(domain, ">"))
+		fmt.Fprintf(&message, "%s: %s\r\n", IdempotencyHeader, notification.Key)
+	}
+	message.WriteString("MIME-Version: 1.0\r\n")
+
+	if notification.HTMLBody == "" {
+		message.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+		message.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+		if err := writeQuotedPrintable(&message, notification.Body); err != nil {
+			return nil, err
+		}
+		return message.Bytes(), nil
+	}
+
+	parts := multipart.NewWriter(&message)
+	fmt.Fprintf(&message, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", parts.Boundary())
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", notification.Body},
+		{"text/html; charset=utf-8", notification.HTMLBody},
+	} {
+		writer, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeQuotedPrintable(writer, part.body); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+	return message.Bytes(), nil
+}
+
+func writeQuotedPrintable(target io.Writer, body string) error {
+	writer := quotedprintable.NewWriter(target)
+	if _, err := writer.Write([]byte(body)); err != nil {
+		return err
+	}
+	return writer.Close()
+}
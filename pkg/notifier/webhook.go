@@ -0,0 +1,77 @@
+package notifier
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of the request body, keyed
+// with the webhook secret, when a secret is configured.
+const SignatureHeader = "X-Todolist-Signature"
+
+type webhookNotifier struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+// NewWebhookNotifier posts every notification as JSON to url.
+func NewWebhookNotifier(url string, secret string) Notifier {
+	return &webhookNotifier{
+		url:    url,
+		secret: secret,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type webhookPayload struct {
+	Kind     string `json:"kind"`
+	To       string `json:"to"`
+	Subject  string `json:"subject"`
+	Body     string `json:"body"`
+	HTMLBody string `json:"html_body,omitempty"`
+}
+
+func (notifier *webhookNotifier) Notify(notification Notification) error {
+	body, err := json.Marshal(webhookPayload{
+		Kind:     notification.Kind,
+		To:       notification.To,
+		Subject:  notification.Subject,
+		Body:     notification.Body,
+		HTMLBody: notification.HTMLBody,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, notifier.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if notification.Key != "" {
+		req.Header.Set(IdempotencyHeader, notification.Key)
+	}
+	if notifier.secret != "" {
+		mac := hmac.New(sha256.New, []byte(notifier.secret))
+		mac.Write(body)
+		req.Header.Set(SignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := notifier.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
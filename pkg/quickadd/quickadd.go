@@ -0,0 +1,353 @@
+// Package quickadd reads an activity typed as a single line, such as
+// "Dentist appointment next Tuesday 3pm #health !p1".
+//
+// Besides the title, a line may hold:
+//
+//   - tags: #health
+//   - a priority: !p1 to !p4
+//   - a category: @task or @event. Without one, a line giving a time of day
+//     is an event and any other a task.
+//   - a day: today, tomorrow, a weekday name (the first one from today on),
+//     next <weekday> (the first one after today), this <weekday>, next week
+//     (its Monday), next month (its first day), in <n> days/weeks/months,
+//     2026-03-04, March 4 or 4 March with an optional year (the next such
+//     day from today on without one).
+//   - a time of day: 3pm, 3:30 pm, 15:00, noon or midnight. A time that
+//     already passed today, given without a day, falls on tomorrow.
+//
+// Days and times may be preceded by on, at or by. Only the first day and
+// the first time are read; everything else, in its original order, makes
+// up the title. Parsing depends on nothing but the line and the current
+// time, so the same input always gives the same activity.
+package quickadd
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"todolist-v1/pkg/date"
+)
+
+// Categories match those of activities.
+const (
+	CategoryTask  = "TASK"
+	CategoryEvent = "EVENT"
+)
+
+var ErrNoTitle = errors.New("quick-add text has no title left once parsed")
+
+// Activity is what a line describes. At is in the location of the time the
+// line was parsed at; for all-day activities only its day counts.
+type Activity struct {
+	Title    string
+	Category string
+	Priority string
+	Tags     []string
+	At       time.Time
+	AllDay   bool
+}
+
+var (
+	priorityPattern = regexp.MustCompile(`^!p([1-4])$`)
+	clockPattern    = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)?$`)
+	ordinalPattern  = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)?$`)
+	yearPattern     = regexp.MustCompile(`^\d{4}$`)
+)
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+var months = map[string]time.Month{
+	"jan": time.January, "january": time.January,
+	"feb": time.February, "february": time.February,
+	"mar": time.March, "march": time.March,
+	"apr": time.April, "april": time.April,
+	"may": time.May,
+	"jun": time.June, "june": time.June,
+	"jul": time.July, "july": time.July,
+	"aug": time.August, "august": time.August,
+	"sep": time.September, "sept": time.September, "september": time.September,
+	"oct": time.October, "october": time.October,
+	"nov": time.November, "november": time.November,
+	"dec": time.December, "december": time.December,
+}
+
+// clock is a time of day.
+type clock struct {
+	hour   int
+	minute int
+}
+
+// parser walks the words of a line. words holds them lowercased and
+// stripped of surrounding punctuation, for matching; fields keeps them as
+// typed, for the title.
+type parser struct {
+	today  date.Date
+	fields []string
+	words  []string
+}
+
+// Parse reads text as typed at now, whose location days and times are
+// taken in.
+func Parse(text string, now time.Time) (Activity, error) {
+	fields := strings.Fields(text)
+	p := parser{today: date.Of(now), fields: fields, words: make([]string, len(fields))}
+	for i, field := range fields {
+		p.words[i] = strings.Trim(strings.ToLower(field), ",.;")
+	}
+
+	var activity Activity
+	var day *date.Date
+	var at *clock
+	var title []string
+	for i := 0; i < len(fields); i++ {
+		word := p.words[i]
+		switch {
+		case len(word) > 1 && word[0] == '#':
+			activity.Tags = append(activity.Tags, word[1:])
+			continue
+		case priorityPattern.MatchString(word):
+			activity.Priority = "P" + priorityPattern.FindStringSubmatch(word)[1]
+			continue
+		case word == "@task":
+			activity.Category = CategoryTask
+			continue
+		case word == "@event":
+			activity.Category = CategoryEvent
+			continue
+		}
+
+		// A connector is only dropped along with the day or time it
+		// introduces.
+		start := i
+		if word == "on" || word == "at" || word == "by" {
+			start = i + 1
+		}
+		if day == nil {
+			if found, n := p.day(start); n > 0 {
+				day = &found
+				i = start + n - 1
+				continue
+			}
+		}
+		if at == nil {
+			if found, n := p.clock(start); n > 0 {
+				at = &found
+				i = start + n - 1
+				continue
+			}
+		}
+		title = append(title, fields[i])
+	}
+
+	activity.Title = strings.TrimRight(strings.Join(title, " "), ",;")
+	if activity.Title == "" {
+		return Activity{}, ErrNoTitle
+	}
+	if activity.Category == "" {
+		activity.Category = CategoryTask
+		if at != nil {
+			activity.Category = CategoryEvent
+		}
+	}
+
+	location := now.Location()
+	switch {
+	case at == nil:
+		if day == nil {
+			day = &p.today
+		}
+		activity.At = day.In(location)
+		activity.AllDay = true
+	case day == nil:
+		activity.At = at.on(p.today, location)
+		if activity.At.Before(now) {
+			activity.At = at.on(p.today.AddDays(1), location)
+		}
+	default:
+		activity.At = at.on(*day, location)
+	}
+	return activity, nil
+}
+
+// word returns the word at i, or an empty string past the end.
+func (p *parser) word(i int) string {
+	if i < len(p.words) {
+		return p.words[i]
+	}
+	return ""
+}
+
+// day reads a day starting at the word at i and returns it along with the
+// number of words it spans, zero when there is none.
+func (p *parser) day(i int) (date.Date, int) {
+	word := p.word(i)
+	switch word {
+	case "today":
+		return p.today, 1
+	case "tomorrow":
+		return p.today.AddDays(1), 1
+	case "this":
+		if weekday, ok := weekdays[p.word(i+1)]; ok {
+			return p.weekday(weekday, 0), 2
+		}
+	case "next":
+		next := p.word(i + 1)
+		if weekday, ok := weekdays[next]; ok {
+			return p.weekday(weekday, 1), 2
+		}
+		switch next {
+		case "week":
+			return p.weekday(time.Monday, 1), 2
+		case "month":
+			t := p.today.In(time.UTC)
+			return date.Of(time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)), 2
+		}
+	case "in":
+		return p.offset(i + 1)
+	}
+	if weekday, ok := weekdays[word]; ok {
+		return p.weekday(weekday, 0), 1
+	}
+	if len(word) == len("2006-01-02") {
+		if day, err := date.Parse(word); err == nil {
+			return day, 1
+		}
+	}
+	if month, ok := months[word]; ok {
+		if day := ordinalPattern.FindStringSubmatch(p.word(i + 1)); day != nil {
+			return p.calendarDay(month, day[1], i+2, 2)
+		}
+	}
+	if day := ordinalPattern.FindStringSubmatch(word); day != nil {
+		if month, ok := months[p.word(i+1)]; ok {
+			return p.calendarDay(month, day[1], i+2, 2)
+		}
+	}
+	return "", 0
+}
+
+// weekday returns the first day falling on weekday, from today on, or
+// after today when skip is 1.
+func (p *parser) weekday(weekday time.Weekday, skip int) date.Date {
+	from := p.today.AddDays(skip)
+	days := (int(weekday) - int(from.In(time.UTC).Weekday()) + 7) % 7
+	return from.AddDays(days)
+}
+
+// offset reads "<n> days", "<n> weeks" or "<n> months" at i.
+func (p *parser) offset(i int) (date.Date, int) {
+	n, err := strconv.Atoi(p.word(i))
+	if err != nil || n < 1 || n > 999 {
+		return "", 0
+	}
+	switch strings.TrimSuffix(p.word(i+1), "s") {
+	case "day":
+		return p.today.AddDays(n), 3
+	case "week":
+		return p.today.AddDays(7 * n), 3
+	case "month":
+		t := p.today.In(time.UTC)
+		target := time.Date(t.Year(), t.Month()+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+		// Days missing from the target month fall on its last day.
+		day := min(t.Day(), daysIn(target.Year(), target.Month()))
+		return date.Of(time.Date(target.Year(), target.Month(), day, 0, 0, 0, 0, time.UTC)), 3
+	}
+	return "", 0
+}
+
+// calendarDay builds the day of month, spanning n words, taking a year
+// from the word at i when there is one. Days that do not exist are not
+// read as days.
+func (p *parser) calendarDay(month time.Month, dayOfMonth string, i int, n int) (date.Date, int) {
+	day, _ := strconv.Atoi(dayOfMonth)
+	if yearPattern.MatchString(p.word(i)) {
+		year, _ := strconv.Atoi(p.word(i))
+		if day < 1 || day > daysIn(year, month) {
+			return "", 0
+		}
+		return date.Of(time.Date(year, month, day, 0, 0, 0, 0, time.UTC)), n + 1
+	}
+
+	year := p.today.In(time.UTC).Year()
+	for {
+		if day >= 1 && day <= daysIn(year, month) {
+			found := date.Of(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+			if found >= p.today {
+				return found, n
+			}
+		} else if month != time.February || day != 29 {
+			return "", 0
+		}
+		// The day passed this year, or it is February 29 of a
+		// non-leap year: look further ahead.
+		year++
+	}
+}
+
+// clock reads a time of day starting at the word at i and returns it along
+// with the number of words it spans, zero when there is none.
+func (p *parser) clock(i int) (clock, int) {
+	word := p.word(i)
+	switch word {
+	case "noon":
+		return clock{hour: 12}, 1
+	case "midnight":
+		return clock{}, 1
+	}
+
+	match := clockPattern.FindStringSubmatch(word)
+	if match == nil {
+		return clock{}, 0
+	}
+	n := 1
+	meridiem := match[3]
+	if next := p.word(i + 1); meridiem == "" && (next == "am" || next == "pm") {
+		meridiem = next
+		n = 2
+	}
+	// A bare number is not a time of day.
+	if meridiem == "" && match[2] == "" {
+		return clock{}, 0
+	}
+
+	hour, _ := strconv.Atoi(match[1])
+	minute, _ := strconv.Atoi(match[2])
+	if minute > 59 {
+		return clock{}, 0
+	}
+	switch meridiem {
+	case "":
+		if hour > 23 {
+			return clock{}, 0
+		}
+	default:
+		if hour < 1 || hour > 12 {
+			return clock{}, 0
+		}
+		hour %= 12
+		if meridiem == "pm" {
+			hour += 12
+		}
+	}
+	return clock{hour: hour, minute: minute}, n
+}
+
+// on returns the time of day on day in location.
+func (c clock) on(day date.Date, location *time.Location) time.Time {
+	t := day.In(time.UTC)
+	return time.Date(t.Year(), t.Month(), t.Day(), c.hour, c.minute, 0, 0, location)
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
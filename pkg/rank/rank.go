@@ -0,0 +1,184 @@
+// Package rank generates lexicographic ranks for manually ordered lists.
+// There is always room for a new rank between two others, so moving an item
+// only rewrites that item's rank.
+//
+// Ranks use the digits 0-9 and a-z, compare bytewise and never end in 0, so
+// that a rank can also be found before any other. Columns holding ranks must
+// use a bytewise collation such as "C".
+//
+// Ranks still grow as items keep landing at the same spot. Once a new rank
+// would be longer than MaxLength, the list should be renumbered with Spread.
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// MaxLength is the longest rank lists should keep before being renumbered.
+const MaxLength = 64
+
+// step is how many digits ranks at either end of the list count in: each
+// new one moves by one at the step-th digit, so that appending or
+// prepending only grows ranks by a digit every few hundred times.
+const step = 2
+
+var (
+	ErrInvalidRank = errors.New("invalid rank")
+	ErrOutOfOrder  = errors.New("ranks are out of order")
+)
+
+// Between returns a rank sorting after a and before b. An empty a stands for
+// the start of the list and an empty b for its end.
+func Between(a string, b string) (string, error) {
+	if !valid(a) || !valid(b) {
+		return "", ErrInvalidRank
+	}
+	if b != "" && a >= b {
+		return "", ErrOutOfOrder
+	}
+	switch {
+	case a != "" && b == "":
+		return after(a), nil
+	case a == "" && b != "":
+		return before(b), nil
+	}
+	return midpoint(a, b), nil
+}
+
+// after finds a rank at the end of the list, after a. Rather than halving
+// the room left, it steps by one, leaving room for many more ranks.
+func after(a string) string {
+	if a == "" {
+		return midpoint("", "")
+	}
+	if next, ok := increment(a); ok {
+		return next
+	}
+	// a starts with step z's: continue after them.
+	return a[:step] + after(a[step:])
+}
+
+// before finds a rank at the start of the list, before b, stepping the
+// way after does.
+func before(b string) string {
+	if previous, ok := decrement(b); ok {
+		return previous
+	}
+	// b starts with a 0: continue after it.
+	return b[:1] + before(b[1:])
+}
+
+// midpoint finds the shortest rank between a and b, which are valid and in
+// order.
+func midpoint(a string, b string) string {
+	if b != "" {
+		// Keep the common prefix, reading a missing digit of a as 0.
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			return b[:n] + midpoint(a[min(n, len(a)):], b[n:])
+		}
+	}
+
+	low := strings.IndexByte(digits, digitAt(a, 0))
+	high := len(digits)
+	if b != "" {
+		high = strings.IndexByte(digits, b[0])
+	}
+	if high-low > 1 {
+		return string(digits[(low+high)/2])
+	}
+	// The first digits are adjacent: a longer b can be cut after its first
+	// digit, otherwise the rank continues after a's first digit.
+	if len(b) > 1 {
+		return b[:1]
+	}
+	return string(digits[low]) + midpoint(a[min(1, len(a)):], "")
+}
+
+// increment adds one to the first step digits of rank, and reports false
+// when they are all z.
+func increment(rank string) (string, bool) {
+	value := prefixValue(rank)
+	if value+1 == pow(step) {
+		return "", false
+	}
+	return format(value+1, step), true
+}
+
+// decrement subtracts one from the first step digits of rank, and reports
+// false when that leaves no rank above the start of the list.
+func decrement(rank string) (string, bool) {
+	value := prefixValue(rank)
+	if value <= 1 {
+		return "", false
+	}
+	return format(value-1, step), true
+}
+
+// prefixValue reads the first step digits of rank as a number, missing
+// digits being 0.
+func prefixValue(rank string) uint64 {
+	var value uint64
+	for i := 0; i < step; i++ {
+		value = value*uint64(len(digits)) + uint64(strings.IndexByte(digits, digitAt(rank, i)))
+	}
+	return value
+}
+
+// Spread returns n ranks spaced evenly over the list, the way to renumber a
+// list whose ranks grew too long.
+func Spread(n int) []string {
+	width := 1
+	for width < maxWidth && pow(width) < uint64(n+1)*uint64(len(digits)) {
+		width++
+	}
+	gap := pow(width) / uint64(n+1)
+	ranks := make([]string, n)
+	for i := range ranks {
+		ranks[i] = format(gap*uint64(i+1), width)
+	}
+	return ranks
+}
+
+// maxWidth keeps the ranks Spread computes within a uint64.
+const maxWidth = 12
+
+func pow(width int) uint64 {
+	value := uint64(1)
+	for i := 0; i < width; i++ {
+		value *= uint64(len(digits))
+	}
+	return value
+}
+
+// format writes value with width digits, dropping trailing zeros.
+func format(value uint64, width int) string {
+	rank := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		rank[i] = digits[value%uint64(len(digits))]
+		value /= uint64(len(digits))
+	}
+	return strings.TrimRight(string(rank), digits[:1])
+}
+
+func digitAt(rank string, i int) byte {
+	if i < len(rank) {
+		return rank[i]
+	}
+	return digits[0]
+}
+
+func valid(rank string) bool {
+	for i := 0; i < len(rank); i++ {
+		if strings.IndexByte(digits, rank[i]) < 0 {
+			return false
+		}
+	}
+	return rank == "" || rank[len(rank)-1] != digits[0]
+}
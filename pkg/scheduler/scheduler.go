@@ -0,0 +1,72 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Job is one run of a periodic task.
+type Job func() error
+
+// Scheduler runs jobs at fixed intervals in the background. A job never
+// overlaps with itself; a run that takes longer than the interval delays
+// the next one.
+type Scheduler interface {
+	Every(name string, interval time.Duration, job Job)
+	Start()
+	// Stop stops scheduling runs and waits for running jobs to finish.
+	Stop()
+}
+
+type entry struct {
+	name     string
+	interval time.Duration
+	job      Job
+}
+
+type tickerScheduler struct {
+	log     *logrus.Logger
+	entries []entry
+	stop    chan struct{}
+	wait    sync.WaitGroup
+}
+
+func NewScheduler(log *logrus.Logger) Scheduler {
+	return &tickerScheduler{log: log, stop: make(chan struct{})}
+}
+
+func (scheduler *tickerScheduler) Every(name string, interval time.Duration, job Job) {
+	scheduler.entries = append(scheduler.entries, entry{name: name, interval: interval, job: job})
+}
+
+func (scheduler *tickerScheduler) Start() {
+	for _, e := range scheduler.entries {
+		scheduler.wait.Add(1)
+		go scheduler.run(e)
+	}
+}
+
+func (scheduler *tickerScheduler) Stop() {
+	close(scheduler.stop)
+	scheduler.wait.Wait()
+}
+
+func (scheduler *tickerScheduler) run(e entry) {
+	defer scheduler.wait.Done()
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-scheduler.stop:
+			return
+		case <-ticker.C:
+			if err := e.job(); err != nil {
+				scheduler.log.WithError(err).WithField("job", e.name).Error("Scheduled job failed")
+			}
+		}
+	}
+}
@@ -13,8 +13,15 @@ type fiberServer struct {
 }
 
 func NewFiberServer(cfg *config.Config) Server {
+	// Attachments may be larger than Fiber's default body limit; the extra
+	// megabyte leaves room for the multipart envelope.
+	bodyLimit := fiber.DefaultBodyLimit
+	if limit := int(cfg.Storage.MaxFileSize) + 1<<20; limit > bodyLimit {
+		bodyLimit = limit
+	}
+
 	return &fiberServer{
-		app: fiber.New(),
+		app: fiber.New(fiber.Config{BodyLimit: bodyLimit}),
 		cfg: cfg,
 	}
 }
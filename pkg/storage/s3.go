@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"context"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+type S3Options struct {
+	// Endpoint is the host and optional port of the S3-compatible service,
+	// without a scheme.
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	UseSSL    bool
+}
+
+type s3BlobStore struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewS3BlobStore stores objects in an existing bucket of an S3-compatible
+// service such as AWS S3 or MinIO.
+func NewS3BlobStore(options S3Options) (BlobStore, error) {
+	client, err := minio.New(options.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(options.AccessKey, options.SecretKey, ""),
+		Secure: options.UseSSL,
+		Region: options.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &s3BlobStore{client: client, bucket: options.Bucket}, nil
+}
+
+func (store *s3BlobStore) Put(key string, body io.Reader, size int64, contentType string) error {
+	_, err := store.client.PutObject(context.Background(), store.bucket, key, body, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	return err
+}
+
+func (store *s3BlobStore) Get(key string) (io.ReadCloser, error) {
+	object, err := store.client.GetObject(context.Background(), store.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// GetObject is lazy; Stat performs the request so that a missing key is
+	// reported here instead of on the first read.
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, ErrBlobNotFound
+		}
+		return nil, err
+	}
+	return object, nil
+}
+
+func (store *s3BlobStore) Delete(key string) error {
+	return store.client.RemoveObject(context.Background(), store.bucket, key, minio.RemoveObjectOptions{})
+}
@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"todolist-v1/config"
+)
+
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore keeps binary objects under slash-separated keys.
+type BlobStore interface {
+	// Put stores size bytes read from body under key, replacing any object
+	// stored there before.
+	Put(key string, body io.Reader, size int64, contentType string) error
+	// Get opens the object stored under key. The caller must close it.
+	Get(key string) (io.ReadCloser, error)
+	// Delete removes the object under key. Deleting a missing object is not
+	// an error.
+	Delete(key string) error
+}
+
+// NewBlobStore creates the blob store selected in the storage configuration.
+func NewBlobStore(cfg *config.Config) (BlobStore, error) {
+	switch cfg.Storage.Driver {
+	case "local":
+		return NewLocalBlobStore(cfg.Storage.LocalPath)
+	case "s3":
+		return NewS3BlobStore(S3Options{
+			Endpoint:  cfg.Storage.S3.Endpoint,
+			Region:    cfg.Storage.S3.Region,
+			Bucket:    cfg.Storage.S3.Bucket,
+			AccessKey: cfg.Storage.S3.AccessKey,
+			SecretKey: cfg.Storage.S3.SecretKey,
+			UseSSL:    cfg.Storage.S3.UseSSL,
+		})
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Storage.Driver)
+	}
+}
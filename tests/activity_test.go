@@ -4,17 +4,16 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"testing"
 	"time"
-	"todolist-v1/config"
 	"todolist-v1/modules/activity/entities"
-	activityHandler "todolist-v1/modules/activity/handler"
 	"todolist-v1/modules/activity/models"
-	activityRepo "todolist-v1/modules/activity/repository"
-	activityUsecase "todolist-v1/modules/activity/usecase"
-	"todolist-v1/pkg/database"
+	"todolist-v1/modules/activity/usecase"
+	authEntities "todolist-v1/modules/auth/entities"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
@@ -23,30 +22,30 @@ import (
 
 type ActivityTestSuite struct {
 	suite.Suite
-	app *fiber.App
-	db  *database.PostgresDB
+	*testApp
+	userId int
+	token  string
 }
 
 func (suite *ActivityTestSuite) SetupSuite() {
-	cfg, err := config.LoadConfig()
-	if err != nil {
-		suite.T().Fatalf("Failed to load config: %v", err)
-	}
+	suite.testApp = newTestApp(suite.T())
+	suite.db.GetDB().Exec("TRUNCATE TABLE users RESTART IDENTITY CASCADE")
+	suite.userId, suite.token = suite.registerUser("activity@test.local")
+}
 
-	suite.db = database.NewPostgresDatabase()
-	if err := suite.db.Connect(cfg.Database.URL); err != nil {
-		suite.T().Fatalf("Failed to connect to test database: %v", err)
-	}
+func (suite *ActivityTestSuite) TearDownTest() {
+	suite.db.GetDB().Exec("TRUNCATE TABLE activities RESTART IDENTITY CASCADE")
+}
 
-	suite.app = fiber.New()
-	repo := activityRepo.NewActivityRepository(suite.db.GetDB())
-	usecase := activityUsecase.NewActivityUsecase(repo)
-	handler := activityHandler.NewActivityHttpHandler(suite.app, usecase)
-	handler.RegisterRoutes()
+func (suite *ActivityTestSuite) registerUser(email string) (int, string) {
+	return suite.testApp.registerUser(suite.T(), email)
 }
 
-func (suite *ActivityTestSuite) TearDownTest() {
-	suite.db.GetDB().Exec("TRUNCATE TABLE activities RESTART IDENTITY")
+func (suite *ActivityTestSuite) newRequest(method string, url string, body io.Reader) *http.Request {
+	req, _ := http.NewRequest(method, url, body)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	return req
 }
 
 func TestActivityAPI(t *testing.T) {
@@ -54,18 +53,16 @@ func TestActivityAPI(t *testing.T) {
 }
 
 func (suite *ActivityTestSuite) createSeedActivity() models.ActivityResponse {
-	repo := activityRepo.NewActivityRepository(suite.db.GetDB())
-	usecase := activityUsecase.NewActivityUsecase(repo)
-
-	seed, _ := usecase.Create(entities.Activity{
+	seed, _, _ := suite.activities.Create(suite.userId, entities.Activity{
 		Title:        "Seed Task",
 		Category:     "TASK",
 		Description:  "A pre-existing task",
 		ActivityDate: time.Now(),
-	})
+	}, entities.ConflictsWarn)
 
 	return models.ActivityResponse{
 		Id:           seed.Id,
+		OwnerId:      seed.OwnerId,
 		Title:        seed.Title,
 		Category:     seed.Category,
 		Description:  seed.Description,
@@ -82,8 +79,7 @@ func (suite *ActivityTestSuite) TestCreateActivity_Success() {
 		"activity_date": "2025-10-10T10:00:00Z"
 	}`)
 
-	req, _ := http.NewRequest("POST", "/api/activities", body)
-	req.Header.Set("Content-Type", "application/json")
+	req := suite.newRequest("POST", "/api/activities", body)
 	resp, err := suite.app.Test(req)
 
 	assert.NoError(suite.T(), err)
@@ -102,8 +98,7 @@ func (suite *ActivityTestSuite) TestCreateActivity_Success() {
 func (suite *ActivityTestSuite) TestCreateActivity_ValidationError() {
 	body := bytes.NewBufferString(`{"title": ""}`)
 
-	req, _ := http.NewRequest("POST", "/api/activities", body)
-	req.Header.Set("Content-Type", "application/json")
+	req := suite.newRequest("POST", "/api/activities", body)
 	resp, err := suite.app.Test(req)
 
 	assert.NoError(suite.T(), err)
@@ -113,7 +108,7 @@ func (suite *ActivityTestSuite) TestCreateActivity_ValidationError() {
 func (suite *ActivityTestSuite) TestGetAllActivities_Success() {
 	suite.createSeedActivity()
 
-	req, _ := http.NewRequest("GET", "/api/activities", nil)
+	req := suite.newRequest("GET", "/api/activities", nil)
 	resp, err := suite.app.Test(req)
 
 	assert.NoError(suite.T(), err)
@@ -128,7 +123,7 @@ func (suite *ActivityTestSuite) TestGetAllActivities_Success() {
 }
 
 func (suite *ActivityTestSuite) TestGetAllActivities_Empty() {
-	req, _ := http.NewRequest("GET", "/api/activities", nil)
+	req := suite.newRequest("GET", "/api/activities", nil)
 	resp, err := suite.app.Test(req)
 
 	assert.NoError(suite.T(), err)
@@ -154,8 +149,7 @@ func (suite *ActivityTestSuite) TestUpdateActivity_Success() {
 	}`
 	updateBody := bytes.NewBufferString(jsonBody)
 
-	req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/activities/%d", seed.Id), updateBody)
-	req.Header.Set("Content-Type", "application/json")
+	req := suite.newRequest("PUT", fmt.Sprintf("/api/activities/%d", seed.Id), updateBody)
 	resp, err := suite.app.Test(req)
 
 	assert.NoError(suite.T(), err)
@@ -182,8 +176,7 @@ func (suite *ActivityTestSuite) TestUpdateActivity_NotFound() {
 		"status": "ON PROGRESS"
 	}`)
 
-	req, _ := http.NewRequest("PUT", "/api/activities/9999", updateBody)
-	req.Header.Set("Content-Type", "application/json")
+	req := suite.newRequest("PUT", "/api/activities/9999", updateBody)
 	resp, err := suite.app.Test(req)
 
 	assert.NoError(suite.T(), err)
@@ -193,13 +186,13 @@ func (suite *ActivityTestSuite) TestUpdateActivity_NotFound() {
 func (suite *ActivityTestSuite) TestDeleteActivity_Success() {
 	seed := suite.createSeedActivity()
 
-	reqDelete, _ := http.NewRequest("DELETE", fmt.Sprintf("/api/activities/%d", seed.Id), nil)
+	reqDelete := suite.newRequest("DELETE", fmt.Sprintf("/api/activities/%d", seed.Id), nil)
 	respDelete, err := suite.app.Test(reqDelete)
 
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), fiber.StatusOK, respDelete.StatusCode)
 
-	reqGet, _ := http.NewRequest("GET", fmt.Sprintf("/api/activities/%d", seed.Id), nil)
+	reqGet := suite.newRequest("GET", fmt.Sprintf("/api/activities/%d", seed.Id), nil)
 	_, err = suite.app.Test(reqGet)
 	assert.NoError(suite.T(), err)
 }
@@ -207,9 +200,660 @@ func (suite *ActivityTestSuite) TestDeleteActivity_Success() {
 func (suite *ActivityTestSuite) TestDeleteActivity_NotFound() {
 	nonExistentID := "9999"
 
-	req, _ := http.NewRequest("DELETE", "/api/activities/"+nonExistentID, nil)
+	req := suite.newRequest("DELETE", "/api/activities/"+nonExistentID, nil)
+	resp, err := suite.app.Test(req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusNotFound, resp.StatusCode)
+}
+
+func (suite *ActivityTestSuite) TestGetAllActivities_Unauthorized() {
+	req, _ := http.NewRequest("GET", "/api/activities", nil)
 	resp, err := suite.app.Test(req)
 
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusUnauthorized, resp.StatusCode)
+}
+
+func (suite *ActivityTestSuite) TestActivities_HiddenFromOtherUsers() {
+	seed := suite.createSeedActivity()
+	_, otherToken := suite.registerUser("other@test.local")
+
+	reqGet := suite.newRequest("GET", "/api/activities", nil)
+	reqGet.Header.Set("Authorization", "Bearer "+otherToken)
+	resp, err := suite.app.Test(reqGet)
+	assert.NoError(suite.T(), err)
+
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	var result map[string]interface{}
+	json.Unmarshal(respBody, &result)
+	assert.Nil(suite.T(), result["data"])
+
+	reqUpdate := suite.newRequest("PUT", fmt.Sprintf("/api/activities/%d", seed.Id), bytes.NewBufferString(`{
+		"title": "Hijacked",
+		"category": "TASK",
+		"description": "Should not be possible",
+		"activity_date": "2026-11-11T11:00:00Z",
+		"status": "NEW"
+	}`))
+	reqUpdate.Header.Set("Authorization", "Bearer "+otherToken)
+	resp, err = suite.app.Test(reqUpdate)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), fiber.StatusNotFound, resp.StatusCode)
+
+	reqDelete := suite.newRequest("DELETE", fmt.Sprintf("/api/activities/%d", seed.Id), nil)
+	reqDelete.Header.Set("Authorization", "Bearer "+otherToken)
+	resp, err = suite.app.Test(reqDelete)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusNotFound, resp.StatusCode)
+}
+
+func (suite *ActivityTestSuite) TestApiKey_ScopesAreEnforced() {
+	_, rawKey, err := suite.apiKeys.Create(suite.userId, "ci", []string{authEntities.ScopeActivitiesRead})
+	assert.NoError(suite.T(), err)
+
+	reqGet := suite.newRequest("GET", "/api/activities", nil)
+	reqGet.Header.Del("Authorization")
+	reqGet.Header.Set("X-API-Key", rawKey)
+	resp, err := suite.app.Test(reqGet)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+
+	reqCreate := suite.newRequest("POST", "/api/activities", bytes.NewBufferString(`{
+		"title": "Created by CI",
+		"category": "TASK",
+		"description": "Read-only keys cannot write",
+		"activity_date": "2025-10-10T10:00:00Z"
+	}`))
+	reqCreate.Header.Set("Authorization", "Bearer "+rawKey)
+	resp, err = suite.app.Test(reqCreate)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusForbidden, resp.StatusCode)
+}
+
+func (suite *ActivityTestSuite) TestHistory_RecordsChangesAndRestores() {
+	seed := suite.createSeedActivity()
+
+	updateBody := bytes.NewBufferString(`{
+		"title": "Seed Task",
+		"category": "TASK",
+		"description": "A pre-existing task",
+		"activity_date": "2026-11-11T11:00:00Z",
+		"status": "ON PROGRESS"
+	}`)
+	resp, err := suite.app.Test(suite.newRequest("PUT", fmt.Sprintf("/api/activities/%d", seed.Id), updateBody))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+
+	resp, err = suite.app.Test(suite.newRequest("DELETE", fmt.Sprintf("/api/activities/%d", seed.Id), nil))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+
+	resp, err = suite.app.Test(suite.newRequest("GET", fmt.Sprintf("/api/activities/%d/history", seed.Id), nil))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	var result struct {
+		Data []models.HistoryResponse `json:"data"`
+	}
+	json.Unmarshal(respBody, &result)
+	if assert.Len(suite.T(), result.Data, 3) {
+		assert.Equal(suite.T(), "delete", result.Data[0].Action)
+		assert.Equal(suite.T(), "update", result.Data[1].Action)
+		assert.Equal(suite.T(), "ON PROGRESS", result.Data[1].Changes["status"].New)
+		assert.NotContains(suite.T(), result.Data[1].Changes, "title")
+		assert.Equal(suite.T(), "create", result.Data[2].Action)
+	}
+
+	resp, err = suite.app.Test(suite.newRequest("POST", fmt.Sprintf("/api/activities/%d/history/1/restore", seed.Id), nil))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+
+	activities, err := suite.activities.GetAll(suite.userId, entities.ActivityFilter{})
+	assert.NoError(suite.T(), err)
+	if assert.Len(suite.T(), activities, 1) {
+		assert.Equal(suite.T(), "NEW", activities[0].Status)
+	}
+}
+
+func (suite *ActivityTestSuite) TestSnooze_TomorrowMorningKeepsOriginalDate() {
+	seed := suite.createSeedActivity()
+
+	body := bytes.NewBufferString(`{"preset": "tomorrow_morning", "timezone": "Europe/Berlin"}`)
+	resp, err := suite.app.Test(suite.newRequest("POST", fmt.Sprintf("/api/activities/%d/snooze", seed.Id), body))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	var result struct {
+		Data models.ActivityResponse `json:"data"`
+	}
+	json.Unmarshal(respBody, &result)
+
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	snoozed := result.Data.ActivityDate.In(berlin)
+	assert.Equal(suite.T(), 9, snoozed.Hour())
+	assert.Equal(suite.T(), time.Now().In(berlin).AddDate(0, 0, 1).Day(), snoozed.Day())
+	assert.Equal(suite.T(), 1, result.Data.RescheduleCount)
+	if assert.NotNil(suite.T(), result.Data.OriginalActivityDate) {
+		assert.WithinDuration(suite.T(), seed.ActivityDate, *result.Data.OriginalActivityDate, time.Second)
+	}
+}
+
+func (suite *ActivityTestSuite) TestSnooze_RejectsPresetWithDuration() {
+	seed := suite.createSeedActivity()
+
+	body := bytes.NewBufferString(`{"preset": "next_week", "minutes": 30}`)
+	resp, err := suite.app.Test(suite.newRequest("POST", fmt.Sprintf("/api/activities/%d/snooze", seed.Id), body))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func (suite *ActivityTestSuite) TestRescheduleOverdue_ShiftsOnlyOverdueActivities() {
+	overdue, _, _ := suite.activities.Create(suite.userId, entities.Activity{
+		Title: "Overdue", Category: "TASK", Description: "Late", ActivityDate: time.Now().AddDate(0, 0, -2),
+	}, entities.ConflictsWarn)
+	upcoming, _, _ := suite.activities.Create(suite.userId, entities.Activity{
+		Title: "Upcoming", Category: "TASK", Description: "On time", ActivityDate: time.Now().AddDate(0, 0, 2),
+	}, entities.ConflictsWarn)
+
+	resp, err := suite.app.Test(suite.newRequest("POST", "/api/activities/reschedule", bytes.NewBufferString(`{"days": 3}`)))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	var result struct {
+		Data []models.ActivityResponse `json:"data"`
+	}
+	json.Unmarshal(respBody, &result)
+	if assert.Len(suite.T(), result.Data, 1) {
+		assert.Equal(suite.T(), overdue.Id, result.Data[0].Id)
+		assert.WithinDuration(suite.T(), overdue.ActivityDate.AddDate(0, 0, 3), result.Data[0].ActivityDate, time.Second)
+		assert.Equal(suite.T(), 1, result.Data[0].RescheduleCount)
+	}
+
+	activities, err := suite.activities.GetAll(suite.userId, entities.ActivityFilter{})
+	assert.NoError(suite.T(), err)
+	for _, activity := range activities {
+		if activity.Id == upcoming.Id {
+			assert.Zero(suite.T(), activity.RescheduleCount)
+		}
+	}
+}
+
+func (suite *ActivityTestSuite) TestRescheduleOverdue_LeavesArchivedActivities() {
+	archived, _, _ := suite.activities.Create(suite.userId, entities.Activity{
+		Title: "Shelved", Category: "TASK", Description: "Late", ActivityDate: time.Now().AddDate(0, 0, -2),
+	}, entities.ConflictsWarn)
+	_, err := suite.activities.Archive(suite.userId, archived.Id, true)
+	assert.NoError(suite.T(), err)
+
+	rescheduled, _, err := suite.activities.RescheduleOverdue(suite.userId, 3, nil)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), rescheduled)
+
+	activities, err := suite.activities.GetAll(suite.userId, entities.ActivityFilter{Archived: true})
+	assert.NoError(suite.T(), err)
+	if assert.Len(suite.T(), activities, 1) {
+		assert.Zero(suite.T(), activities[0].RescheduleCount)
+		assert.WithinDuration(suite.T(), archived.ActivityDate, activities[0].ActivityDate, time.Second)
+	}
+}
+
+func (suite *ActivityTestSuite) TestCreateActivity_ValidatesDatesPerCategory() {
+	cases := map[string]string{
+		"task with time range": `{"title": "Report", "category": "TASK", "description": "Q3",
+			"start_at": "2026-11-11T09:00:00Z", "end_at": "2026-11-11T10:00:00Z"}`,
+		"event with due date": `{"title": "Standup", "category": "EVENT", "description": "Daily",
+			"start_at": "2026-11-11T09:00:00Z", "due_at": "2026-11-11T10:00:00Z"}`,
+		"event ending before it starts": `{"title": "Standup", "category": "EVENT", "description": "Daily",
+			"start_at": "2026-11-11T09:00:00Z", "end_at": "2026-11-11T08:00:00Z"}`,
+		"unknown priority": `{"title": "Report", "category": "TASK", "description": "Q3",
+			"due_at": "2026-11-11T09:00:00Z", "priority": "P0"}`,
+	}
+	for name, body := range cases {
+		resp, err := suite.app.Test(suite.newRequest("POST", "/api/activities", bytes.NewBufferString(body)))
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), fiber.StatusBadRequest, resp.StatusCode, name)
+	}
+
+	resp, err := suite.app.Test(suite.newRequest("POST", "/api/activities", bytes.NewBufferString(`{
+		"title": "Standup", "category": "EVENT", "description": "Daily",
+		"start_at": "2026-11-11T09:00:00Z", "end_at": "2026-11-11T09:15:00Z"
+	}`)))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusCreated, resp.StatusCode)
+
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	var result struct {
+		Data models.ActivityResponse `json:"data"`
+	}
+	json.Unmarshal(respBody, &result)
+	assert.Equal(suite.T(), "P4", result.Data.Priority)
+	assert.Equal(suite.T(), time.Date(2026, 11, 11, 9, 0, 0, 0, time.UTC), result.Data.ActivityDate.UTC())
+	assert.Nil(suite.T(), result.Data.DueAt)
+}
+
+func (suite *ActivityTestSuite) TestGetAllActivities_SortsByPriorityThenDueDate() {
+	create := func(title string, priority string, due string) {
+		body := fmt.Sprintf(`{"title": %q, "category": "TASK", "description": "Sorting", "priority": %q, "due_at": %q}`,
+			title, priority, due)
+		resp, err := suite.app.Test(suite.newRequest("POST", "/api/activities", bytes.NewBufferString(body)))
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), fiber.StatusCreated, resp.StatusCode)
+	}
+	create("Later low", "P3", "2026-11-20T09:00:00Z")
+	create("Later urgent", "P1", "2026-11-20T09:00:00Z")
+	create("Soon urgent", "P1", "2026-11-12T09:00:00Z")
+	create("Soon low", "P3", "2026-11-12T09:00:00Z")
+
+	resp, err := suite.app.Test(suite.newRequest("GET", "/api/activities?sort=priority", nil))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	var result struct {
+		Data []models.ActivityResponse `json:"data"`
+	}
+	json.Unmarshal(respBody, &result)
+	var titles []string
+	for _, activity := range result.Data {
+		titles = append(titles, activity.Title)
+	}
+	assert.Equal(suite.T(), []string{"Soon urgent", "Later urgent", "Soon low", "Later low"}, titles)
+
+	resp, err = suite.app.Test(suite.newRequest("GET", "/api/activities?sort=title", nil))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func (suite *ActivityTestSuite) TestAllDayActivity_KeepsLocalDateAndRendersInCallerZone() {
+	body := bytes.NewBufferString(`{
+		"title": "Public holiday", "category": "EVENT", "description": "Day off",
+		"activity_date": "2026-11-10T20:00:00Z", "all_day": true
+	}`)
+	req := suite.newRequest("POST", "/api/activities", body)
+	req.Header.Set("X-Timezone", "Asia/Tokyo")
+	resp, err := suite.app.Test(req)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusCreated, resp.StatusCode)
+
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	var created struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	json.Unmarshal(respBody, &created)
+	assert.Equal(suite.T(), "2026-11-11", created.Data["local_date"])
+	assert.Equal(suite.T(), "Asia/Tokyo", created.Data["timezone"])
+	assert.Equal(suite.T(), "2026-11-11T00:00:00+09:00", created.Data["activity_date"])
+
+	req = suite.newRequest("GET", "/api/activities", nil)
+	req.Header.Set("X-Timezone", "Europe/London")
+	resp, err = suite.app.Test(req)
+	assert.NoError(suite.T(), err)
+	respBody, _ = ioutil.ReadAll(resp.Body)
+	var listed struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	json.Unmarshal(respBody, &listed)
+	if assert.Len(suite.T(), listed.Data, 1) {
+		assert.Equal(suite.T(), "2026-11-10T15:00:00Z", listed.Data[0]["activity_date"])
+		assert.Equal(suite.T(), "2026-11-11", listed.Data[0]["local_date"])
+	}
+
+	req = suite.newRequest("GET", "/api/activities", nil)
+	req.Header.Set("X-Timezone", "Not/AZone")
+	resp, err = suite.app.Test(req)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, resp.StatusCode)
+
+	// The timezone of the server process is not a timezone of the user.
+	req = suite.newRequest("GET", "/api/activities", nil)
+	req.Header.Set("X-Timezone", "Local")
+	resp, err = suite.app.Test(req)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, resp.StatusCode)
+
+	resp, err = suite.app.Test(suite.newRequest("POST", "/api/activities", bytes.NewBufferString(`{
+		"title": "Standup", "category": "TASK", "description": "Daily",
+		"activity_date": "2026-11-10T09:00:00Z", "timezone": "Local"
+	}`)))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func (suite *ActivityTestSuite) TestUpdate_WithoutScheduleKeepsAllDayAndTimezone() {
+	req := suite.newRequest("POST", "/api/activities", bytes.NewBufferString(`{
+		"title": "Public holiday", "category": "EVENT", "description": "Day off",
+		"activity_date": "2026-11-10T20:00:00Z", "all_day": true, "timezone": "Asia/Tokyo"
+	}`))
+	resp, err := suite.app.Test(req)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusCreated, resp.StatusCode)
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	var created struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	json.Unmarshal(respBody, &created)
+
+	// A client unaware of all-day activities sends the date back as it got it.
+	req = suite.newRequest("PUT", fmt.Sprintf("/api/activities/%v", created.Data["id"]), bytes.NewBufferString(`{
+		"title": "Public holiday", "category": "EVENT", "description": "Day off, office closed",
+		"activity_date": "2026-11-10T15:00:00Z", "status": "NEW"
+	}`))
+	resp, err = suite.app.Test(req)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+	respBody, _ = ioutil.ReadAll(resp.Body)
+	var updated struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	json.Unmarshal(respBody, &updated)
+	assert.Equal(suite.T(), true, updated.Data["all_day"])
+	assert.Equal(suite.T(), "Asia/Tokyo", updated.Data["timezone"])
+	assert.Equal(suite.T(), "2026-11-11", updated.Data["local_date"])
+}
+
+func (suite *ActivityTestSuite) TestQuickAdd_CreatesParsedActivity() {
+	body := bytes.NewBufferString(`{"text": "Dentist appointment 2026-11-10 3pm #health !p1"}`)
+	req := suite.newRequest("POST", "/api/activities/quick", body)
+	req.Header.Set("X-Timezone", "Asia/Tokyo")
+	resp, err := suite.app.Test(req)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusCreated, resp.StatusCode)
+
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	var created struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	json.Unmarshal(respBody, &created)
+	assert.Equal(suite.T(), "Dentist appointment", created.Data["title"])
+	assert.Equal(suite.T(), "EVENT", created.Data["category"])
+	assert.Equal(suite.T(), "P1", created.Data["priority"])
+	assert.Equal(suite.T(), []interface{}{"health"}, created.Data["tags"])
+	assert.Equal(suite.T(), "2026-11-10T15:00:00+09:00", created.Data["start_at"])
+
+	resp, err = suite.app.Test(suite.newRequest("POST", "/api/activities/quick", bytes.NewBufferString(`{"text": "#health tomorrow"}`)))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func (suite *ActivityTestSuite) TestGetAllActivities_FiltersByQuery() {
+	for _, body := range []string{
+		`{"title": "Write report", "category": "TASK", "description": "Query", "due_at": "2026-10-20T09:00:00Z"}`,
+		`{"title": "Fix outage", "category": "TASK", "description": "Query", "due_at": "2026-11-20T09:00:00Z", "tags": ["urgent"]}`,
+		`{"title": "Offsite", "category": "EVENT", "description": "Query", "activity_date": "2026-10-21T09:00:00Z"}`,
+		`{"title": "Plan roadmap", "category": "TASK", "description": "Query", "due_at": "2026-12-20T09:00:00Z"}`,
+	} {
+		resp, err := suite.app.Test(suite.newRequest("POST", "/api/activities", bytes.NewBufferString(body)))
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), fiber.StatusCreated, resp.StatusCode)
+	}
+
+	query := url.QueryEscape("status:NEW AND (category:TASK OR tag:urgent) AND date<2026-11-01 OR tag:urgent")
+	resp, err := suite.app.Test(suite.newRequest("GET", "/api/activities?sort=due_at&q="+query, nil))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	var result struct {
+		Data []models.ActivityResponse `json:"data"`
+	}
+	json.Unmarshal(respBody, &result)
+	var titles []string
+	for _, activity := range result.Data {
+		titles = append(titles, activity.Title)
+	}
+	assert.Equal(suite.T(), []string{"Write report", "Fix outage"}, titles)
+
+	resp, err = suite.app.Test(suite.newRequest("GET", "/api/activities?q="+url.QueryEscape("status:NEW AND (tag:urgent"), nil))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, resp.StatusCode)
+
+	respBody, _ = ioutil.ReadAll(resp.Body)
+	var failed struct {
+		Data    map[string]interface{} `json:"data"`
+		Message string                 `json:"message"`
+	}
+	json.Unmarshal(respBody, &failed)
+	assert.Equal(suite.T(), float64(27), failed.Data["position"])
+	assert.Contains(suite.T(), failed.Message, `expected ")"`)
+}
+
+func (suite *ActivityTestSuite) TestArchiveAndPin() {
+	create := func(body string) int {
+		resp, err := suite.app.Test(suite.newRequest("POST", "/api/activities", bytes.NewBufferString(body)))
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), fiber.StatusCreated, resp.StatusCode)
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		var created struct {
+			Data models.ActivityResponse `json:"data"`
+		}
+		json.Unmarshal(respBody, &created)
+		return created.Data.Id
+	}
+	list := func(url string) []string {
+		resp, err := suite.app.Test(suite.newRequest("GET", url, nil))
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		var result struct {
+			Data []models.ActivityResponse `json:"data"`
+		}
+		json.Unmarshal(respBody, &result)
+		titles := []string{}
+		for _, activity := range result.Data {
+			titles = append(titles, activity.Title)
+		}
+		return titles
+	}
+	send := func(method string, url string, body string) int {
+		resp, err := suite.app.Test(suite.newRequest(method, url, bytes.NewBufferString(body)))
+		assert.NoError(suite.T(), err)
+		return resp.StatusCode
+	}
+
+	finished := func(title string, due string) int {
+		body := fmt.Sprintf(`{"title": %q, "category": "TASK", "description": "Done", "due_at": %q}`, title, due)
+		id := create(body)
+		body = fmt.Sprintf(`{"title": %q, "category": "TASK", "description": "Done", "status": "DONE", "due_at": %q}`, title, due)
+		assert.Equal(suite.T(), fiber.StatusOK, send("PUT", fmt.Sprintf("/api/activities/%d", id), body))
+		return id
+	}
+	oldDone := finished("Old report", "2026-09-01T09:00:00Z")
+	finished("New report", "2026-10-15T09:00:00Z")
+	open := create(`{"title": "Open report", "category": "TASK", "description": "Open", "due_at": "2026-09-02T09:00:00Z"}`)
+
+	assert.Equal(suite.T(), fiber.StatusOK, send("POST", fmt.Sprintf("/api/activities/%d/pin", open), ""))
+	assert.Equal(suite.T(), []string{"Open report", "Old report", "New report"}, list("/api/activities"))
+
+	assert.Equal(suite.T(), fiber.StatusOK, send("POST", "/api/activities/archive", `{"q": "status:DONE AND date<2026-10-01"}`))
+	assert.Equal(suite.T(), []string{"Open report", "New report"}, list("/api/activities"))
+	assert.Equal(suite.T(), []string{"Old report"}, list("/api/activities?archived=true"))
+
+	assert.Equal(suite.T(), fiber.StatusOK, send("POST", fmt.Sprintf("/api/activities/%d/unarchive", oldDone), ""))
+	assert.Equal(suite.T(), fiber.StatusOK, send("POST", fmt.Sprintf("/api/activities/%d/unpin", open), ""))
+	assert.Equal(suite.T(), []string{"Old report", "New report", "Open report"}, list("/api/activities"))
+
+	assert.Equal(suite.T(), fiber.StatusBadRequest, send("POST", "/api/activities/archive", `{"q": ""}`))
+}
+
+func (suite *ActivityTestSuite) TestUndo_RestoresDeletedActivity() {
+	seed := suite.createSeedActivity()
+
+	resp, err := suite.app.Test(suite.newRequest("DELETE", fmt.Sprintf("/api/activities/%d", seed.Id), nil))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+
+	resp, err = suite.app.Test(suite.newRequest("POST", "/api/undo", nil))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	var result struct {
+		Data []models.ActivityResponse `json:"data"`
+	}
+	json.Unmarshal(respBody, &result)
+	if assert.Len(suite.T(), result.Data, 1) {
+		assert.Equal(suite.T(), seed.Id, result.Data[0].Id)
+	}
+
+	activities, err := suite.activities.GetAll(suite.userId, entities.ActivityFilter{})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), activities, 1)
+}
+
+func (suite *ActivityTestSuite) TestUndo_ThroughAnotherInstance() {
+	seed := suite.createSeedActivity()
+
+	resp, err := suite.app.Test(suite.newRequest("DELETE", fmt.Sprintf("/api/activities/%d", seed.Id), nil))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+
+	other := newTestApp(suite.T())
+	resp, err = other.app.Test(suite.newRequest("POST", "/api/undo", nil))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+
+	activities, err := suite.activities.GetAll(suite.userId, entities.ActivityFilter{})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), activities, 1)
+}
+
+func (suite *ActivityTestSuite) TestCreateEvent_DetectsOverlaps() {
+	_, _, err := suite.activities.Create(suite.userId, entities.Activity{
+		Title: "Planning", Category: "EVENT", Description: "Sprint planning",
+		StartAt: timePtr("2026-05-04T09:00:00Z"), EndAt: timePtr("2026-05-04T10:00:00Z"),
+	}, entities.ConflictsWarn)
+	assert.NoError(suite.T(), err)
+
+	overlapping := `{"title": "Dentist", "category": "EVENT", "description": "Checkup",
+		"start_at": "2026-05-04T09:30:00Z", "end_at": "2026-05-04T10:30:00Z", "reject_conflicts": %t}`
+
+	resp, err := suite.app.Test(suite.newRequest("POST", "/api/activities", bytes.NewBufferString(fmt.Sprintf(overlapping, true))))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusConflict, resp.StatusCode)
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	var rejected struct {
+		Data struct {
+			Conflicts []models.ConflictResponse `json:"conflicts"`
+		} `json:"data"`
+	}
+	json.Unmarshal(respBody, &rejected)
+	if assert.Len(suite.T(), rejected.Data.Conflicts, 1) {
+		assert.Equal(suite.T(), "Planning", rejected.Data.Conflicts[0].Title)
+	}
+
+	resp, err = suite.app.Test(suite.newRequest("POST", "/api/activities", bytes.NewBufferString(fmt.Sprintf(overlapping, false))))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusCreated, resp.StatusCode)
+	respBody, _ = ioutil.ReadAll(resp.Body)
+	var created struct {
+		Data models.ActivityResponse `json:"data"`
+	}
+	json.Unmarshal(respBody, &created)
+	assert.Len(suite.T(), created.Data.Conflicts, 1)
+
+	// Back-to-back events do not overlap.
+	resp, err = suite.app.Test(suite.newRequest("POST", "/api/activities", bytes.NewBufferString(`{
+		"title": "Retro", "category": "EVENT", "description": "Sprint retro",
+		"start_at": "2026-05-04T10:30:00Z", "end_at": "2026-05-04T11:00:00Z", "reject_conflicts": true
+	}`)))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusCreated, resp.StatusCode)
+}
+
+func (suite *ActivityTestSuite) TestCreateRecurringEvent_DetectsLaterOverlaps() {
+	_, _, err := suite.activities.Create(suite.userId, entities.Activity{
+		Title: "Offsite", Category: "EVENT", Description: "Team offsite",
+		StartAt: timePtr("2026-05-18T09:30:00Z"), EndAt: timePtr("2026-05-18T12:00:00Z"),
+	}, entities.ConflictsWarn)
+	assert.NoError(suite.T(), err)
+
+	// Only the third weekly standup runs into the offsite.
+	weekly := entities.RecurrenceWeekly
+	_, conflicts, err := suite.activities.Create(suite.userId, entities.Activity{
+		Title: "Standup", Category: "EVENT", Description: "Weekly standup",
+		StartAt: timePtr("2026-05-04T09:00:00Z"), EndAt: timePtr("2026-05-04T10:00:00Z"),
+		RecurrenceFrequency: &weekly, RecurrenceInterval: 1,
+	}, entities.ConflictsWarn)
+	assert.NoError(suite.T(), err)
+	if assert.Len(suite.T(), conflicts, 1) {
+		assert.Equal(suite.T(), "Offsite", conflicts[0].Title)
+	}
+}
+
+func (suite *ActivityTestSuite) TestSnooze_ReportsOverlappingEvents() {
+	soon := time.Now().Add(30 * time.Minute)
+	later := soon.Add(2 * time.Hour)
+	_, _, err := suite.activities.Create(suite.userId, entities.Activity{
+		Title: "Planning", Category: "EVENT", Description: "Sprint planning",
+		StartAt: &soon, EndAt: &later,
+	}, entities.ConflictsWarn)
+	assert.NoError(suite.T(), err)
+
+	start := time.Now().Add(-3 * time.Hour)
+	end := start.Add(time.Hour)
+	dentist, _, err := suite.activities.Create(suite.userId, entities.Activity{
+		Title: "Dentist", Category: "EVENT", Description: "Checkup",
+		StartAt: &start, EndAt: &end,
+	}, entities.ConflictsWarn)
+	assert.NoError(suite.T(), err)
+
+	resp, err := suite.app.Test(suite.newRequest("POST", fmt.Sprintf("/api/activities/%d/snooze", dentist.Id), bytes.NewBufferString(`{"minutes": 60}`)))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	var result struct {
+		Data models.ActivityResponse `json:"data"`
+	}
+	json.Unmarshal(respBody, &result)
+	if assert.Len(suite.T(), result.Data.Conflicts, 1) {
+		assert.Equal(suite.T(), "Planning", result.Data.Conflicts[0].Title)
+	}
+}
+
+func timePtr(value string) *time.Time {
+	t, _ := time.Parse(time.RFC3339, value)
+	return &t
+}
+
+func (suite *ActivityTestSuite) TestDependencies_BlockStartAndOrderNextActivities() {
+	create := func(title string) entities.Activity {
+		activity, _, err := suite.activities.Create(suite.userId, entities.Activity{
+			Title: title, Category: "TASK", Description: "Dependency test", ActivityDate: time.Now().AddDate(0, 0, 1),
+		}, entities.ConflictsWarn)
+		assert.NoError(suite.T(), err)
+		return activity
+	}
+	design, build, ship := create("Design"), create("Build"), create("Ship")
+
+	addBlocker := func(activityId int, blockerId int) int {
+		resp, err := suite.app.Test(suite.newRequest("POST", fmt.Sprintf("/api/activities/%d/blockers", activityId),
+			bytes.NewBufferString(fmt.Sprintf(`{"blocker_id": %d}`, blockerId))))
+		assert.NoError(suite.T(), err)
+		return resp.StatusCode
+	}
+	assert.Equal(suite.T(), fiber.StatusCreated, addBlocker(build.Id, design.Id))
+	assert.Equal(suite.T(), fiber.StatusCreated, addBlocker(ship.Id, build.Id))
+	assert.Equal(suite.T(), fiber.StatusConflict, addBlocker(design.Id, ship.Id))
+	assert.Equal(suite.T(), fiber.StatusBadRequest, addBlocker(design.Id, design.Id))
+
+	next, err := suite.dependencies.Next(suite.userId)
+	assert.NoError(suite.T(), err)
+	if assert.Len(suite.T(), next, 3) {
+		assert.Equal(suite.T(), []int{design.Id, build.Id, ship.Id}, []int{next[0].Activity.Id, next[1].Activity.Id, next[2].Activity.Id})
+		assert.Equal(suite.T(), []int{0, 1, 2}, []int{next[0].Depth, next[1].Depth, next[2].Depth})
+	}
+
+	start := func(activity entities.Activity, status string) error {
+		activity.Status = status
+		_, _, err := suite.activities.Update(suite.userId, activity.Id, entities.ActivityUpdate{Activity: activity}, entities.ConflictsWarn)
+		return err
+	}
+	assert.ErrorIs(suite.T(), start(build, entities.StatusInProgress), usecase.ErrBlocked)
+	assert.NoError(suite.T(), start(design, entities.StatusDone))
+	assert.NoError(suite.T(), start(build, entities.StatusInProgress))
 }
@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"testing"
+	"time"
+	"todolist-v1/modules/activity/entities"
+	"todolist-v1/pkg/storage"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// pngHeader is enough of a PNG file for content type detection.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x06\x00\x00\x00")
+
+type AttachmentTestSuite struct {
+	suite.Suite
+	*testApp
+	token      string
+	activityId int
+}
+
+func (suite *AttachmentTestSuite) SetupSuite() {
+	suite.testApp = newTestApp(suite.T())
+}
+
+func (suite *AttachmentTestSuite) SetupTest() {
+	suite.db.GetDB().Exec("TRUNCATE TABLE users RESTART IDENTITY CASCADE")
+	userId, token := suite.registerUser(suite.T(), "uploader@test.local")
+	suite.token = token
+
+	activity, _, err := suite.activities.Create(userId, entities.Activity{
+		Title:        "Task with files",
+		Category:     "TASK",
+		Description:  "Has a screenshot",
+		ActivityDate: time.Now(),
+	}, entities.ConflictsWarn)
+	assert.NoError(suite.T(), err)
+	suite.activityId = activity.Id
+}
+
+func TestAttachmentAPI(t *testing.T) {
+	suite.Run(t, new(AttachmentTestSuite))
+}
+
+func (suite *AttachmentTestSuite) upload(fileName string, content []byte) (int, map[string]interface{}) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile("file", fileName)
+	part.Write(content)
+	writer.Close()
+
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/api/activities/%d/attachments", suite.activityId), &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	resp, err := suite.app.Test(req)
+	assert.NoError(suite.T(), err)
+
+	respBody, _ := io.ReadAll(resp.Body)
+	var result map[string]interface{}
+	json.Unmarshal(respBody, &result)
+	return resp.StatusCode, result
+}
+
+func (suite *AttachmentTestSuite) TestUploadAndDownload() {
+	status, result := suite.upload("../screenshot.png", pngHeader)
+	assert.Equal(suite.T(), fiber.StatusCreated, status)
+
+	sum := sha256.Sum256(pngHeader)
+	attachment := result["data"].(map[string]interface{})
+	assert.Equal(suite.T(), "screenshot.png", attachment["file_name"])
+	assert.Equal(suite.T(), "image/png", attachment["content_type"])
+	assert.Equal(suite.T(), hex.EncodeToString(sum[:]), attachment["checksum_sha256"])
+
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/activities/%d/attachments/%v", suite.activityId, attachment["id"]), nil)
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	resp, err := suite.app.Test(req)
+	if assert.NoError(suite.T(), err) {
+		assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+		assert.Equal(suite.T(), "image/png", resp.Header.Get("Content-Type"))
+		downloaded, _ := io.ReadAll(resp.Body)
+		assert.Equal(suite.T(), pngHeader, downloaded)
+	}
+}
+
+func (suite *AttachmentTestSuite) TestRejectsDisallowedType() {
+	// The detected type counts, not the file name.
+	status, _ := suite.upload("notes.png", []byte("<html><body>not an image</body></html>"))
+	assert.Equal(suite.T(), fiber.StatusUnsupportedMediaType, status)
+}
+
+func (suite *AttachmentTestSuite) TestPurgeRemovesBlobs() {
+	status, _ := suite.upload("screenshot.png", pngHeader)
+	assert.Equal(suite.T(), fiber.StatusCreated, status)
+
+	var key string
+	suite.db.GetDB().Raw("SELECT storage_key FROM activity_attachments").Scan(&key)
+
+	status, _ = suite.send(suite.T(), "POST", fmt.Sprintf("/api/activities/%d/purge", suite.activityId), "", suite.token)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+
+	_, err := suite.blobs.Get(key)
+	assert.ErrorIs(suite.T(), err, storage.ErrBlobNotFound)
+
+	var remaining int64
+	suite.db.GetDB().Table("activity_attachments").Count(&remaining)
+	assert.Zero(suite.T(), remaining)
+}
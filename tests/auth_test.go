@@ -0,0 +1,183 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuthTestSuite struct {
+	suite.Suite
+	*testApp
+}
+
+func (suite *AuthTestSuite) SetupSuite() {
+	suite.testApp = newTestApp(suite.T())
+}
+
+func (suite *AuthTestSuite) TearDownTest() {
+	suite.db.GetDB().Exec("TRUNCATE TABLE users RESTART IDENTITY CASCADE")
+}
+
+func TestAuthAPI(t *testing.T) {
+	suite.Run(t, new(AuthTestSuite))
+}
+
+func (suite *AuthTestSuite) post(url string, body string) (int, map[string]interface{}) {
+	return suite.send("POST", url, body, "")
+}
+
+func (suite *AuthTestSuite) send(method string, url string, body string, credential string) (int, map[string]interface{}) {
+	req, _ := http.NewRequest(method, url, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if credential != "" {
+		req.Header.Set("Authorization", "Bearer "+credential)
+	}
+	resp, err := suite.app.Test(req)
+	assert.NoError(suite.T(), err)
+
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	var result map[string]interface{}
+	json.Unmarshal(respBody, &result)
+	return resp.StatusCode, result
+}
+
+func (suite *AuthTestSuite) registerAndLogin() map[string]interface{} {
+	status, _ := suite.post("/api/auth/register", `{"email": "jane@test.local", "name": "Jane", "password": "password123"}`)
+	assert.Equal(suite.T(), fiber.StatusCreated, status)
+
+	status, result := suite.post("/api/auth/login", `{"email": "jane@test.local", "password": "password123"}`)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+	return result["data"].(map[string]interface{})
+}
+
+func (suite *AuthTestSuite) TestRegister_DuplicateEmail() {
+	suite.registerAndLogin()
+
+	status, _ := suite.post("/api/auth/register", `{"email": "JANE@test.local", "name": "Jane", "password": "password123"}`)
+	assert.Equal(suite.T(), fiber.StatusConflict, status)
+}
+
+func (suite *AuthTestSuite) TestLogin_WrongPassword() {
+	suite.registerAndLogin()
+
+	status, _ := suite.post("/api/auth/login", `{"email": "jane@test.local", "password": "wrong-password"}`)
+	assert.Equal(suite.T(), fiber.StatusUnauthorized, status)
+}
+
+func (suite *AuthTestSuite) TestMe_WithAccessToken() {
+	tokens := suite.registerAndLogin()
+
+	req, _ := http.NewRequest("GET", "/api/auth/me", nil)
+	req.Header.Set("Authorization", "Bearer "+tokens["access_token"].(string))
+	resp, err := suite.app.Test(req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, resp.StatusCode)
+}
+
+func (suite *AuthTestSuite) TestMe_RejectsRefreshToken() {
+	tokens := suite.registerAndLogin()
+
+	req, _ := http.NewRequest("GET", "/api/auth/me", nil)
+	req.Header.Set("Authorization", "Bearer "+tokens["refresh_token"].(string))
+	resp, err := suite.app.Test(req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusUnauthorized, resp.StatusCode)
+}
+
+func (suite *AuthTestSuite) TestRefresh_RotatesToken() {
+	tokens := suite.registerAndLogin()
+	body := fmt.Sprintf(`{"refresh_token": %q}`, tokens["refresh_token"])
+
+	status, result := suite.post("/api/auth/refresh", body)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+	rotated := result["data"].(map[string]interface{})
+	assert.NotEqual(suite.T(), tokens["refresh_token"], rotated["refresh_token"])
+
+	// Replaying the old token is rejected and revokes the rotated one as well.
+	status, _ = suite.post("/api/auth/refresh", body)
+	assert.Equal(suite.T(), fiber.StatusUnauthorized, status)
+
+	status, _ = suite.post("/api/auth/refresh", fmt.Sprintf(`{"refresh_token": %q}`, rotated["refresh_token"]))
+	assert.Equal(suite.T(), fiber.StatusUnauthorized, status)
+}
+
+func (suite *AuthTestSuite) TestLogout_RevokesRefreshToken() {
+	tokens := suite.registerAndLogin()
+	body := fmt.Sprintf(`{"refresh_token": %q}`, tokens["refresh_token"])
+
+	status, _ := suite.post("/api/auth/logout", body)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+
+	status, _ = suite.post("/api/auth/refresh", body)
+	assert.Equal(suite.T(), fiber.StatusUnauthorized, status)
+}
+
+func (suite *AuthTestSuite) TestApiKey_Lifecycle() {
+	tokens := suite.registerAndLogin()
+	accessToken := tokens["access_token"].(string)
+
+	status, result := suite.send("POST", "/api/auth/api-keys", `{"name": "ci", "scopes": ["activities:read"]}`, accessToken)
+	assert.Equal(suite.T(), fiber.StatusCreated, status)
+	created := result["data"].(map[string]interface{})
+	rawKey := created["key"].(string)
+
+	status, _ = suite.send("GET", "/api/auth/me", "", rawKey)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+
+	status, result = suite.send("GET", "/api/auth/api-keys", "", accessToken)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+	listed := result["data"].([]interface{})[0].(map[string]interface{})
+	assert.NotNil(suite.T(), listed["last_used_at"])
+	assert.Nil(suite.T(), listed["key"])
+
+	// Keys cannot be used to mint or revoke other keys.
+	status, _ = suite.send("POST", "/api/auth/api-keys", `{"name": "escalate", "scopes": ["activities:write"]}`, rawKey)
+	assert.Equal(suite.T(), fiber.StatusForbidden, status)
+
+	status, _ = suite.send("DELETE", fmt.Sprintf("/api/auth/api-keys/%v", created["id"]), "", accessToken)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+
+	status, _ = suite.send("GET", "/api/auth/me", "", rawKey)
+	assert.Equal(suite.T(), fiber.StatusUnauthorized, status)
+}
+
+func (suite *AuthTestSuite) TestApiKey_UnknownScope() {
+	tokens := suite.registerAndLogin()
+
+	status, _ := suite.send("POST", "/api/auth/api-keys", `{"name": "ci", "scopes": ["admin"]}`, tokens["access_token"].(string))
+	assert.Equal(suite.T(), fiber.StatusBadRequest, status)
+}
+
+func (suite *AuthTestSuite) TestUpdateProfile_Timezone() {
+	tokens := suite.registerAndLogin()
+	accessToken := tokens["access_token"].(string)
+
+	status, _ := suite.send("PUT", "/api/auth/me", `{"name": "Jane", "timezone": "Mars/Olympus_Mons"}`, accessToken)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, status)
+
+	status, _ = suite.send("PUT", "/api/auth/me", `{"name": "Jane", "timezone": "Local"}`, accessToken)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, status)
+
+	status, result := suite.send("PUT", "/api/auth/me", `{"name": "Jane Doe", "timezone": "Europe/Berlin"}`, accessToken)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+	user := result["data"].(map[string]interface{})
+	assert.Equal(suite.T(), "Jane Doe", user["name"])
+	assert.Equal(suite.T(), "Europe/Berlin", user["timezone"])
+
+	// Tokens issued from now on carry the new timezone.
+	status, result = suite.post("/api/auth/refresh", fmt.Sprintf(`{"refresh_token": %q}`, tokens["refresh_token"]))
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+	principal, err := suite.auth.Authenticate(result["data"].(map[string]interface{})["access_token"].(string))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Europe/Berlin", principal.Timezone)
+}
@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	"todolist-v1/pkg/cache"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheLoadsOncePerPeriod(t *testing.T) {
+	values := cache.New[string, int](50 * time.Millisecond)
+	var loads atomic.Int32
+	load := func() (int, error) {
+		return int(loads.Add(1)), nil
+	}
+
+	first, _ := values.Get("stats", load)
+	second, _ := values.Get("stats", load)
+	assert.Equal(t, 1, first)
+	assert.Equal(t, 1, second)
+
+	time.Sleep(60 * time.Millisecond)
+	third, _ := values.Get("stats", load)
+	assert.Equal(t, 2, third)
+}
+
+func TestCacheSharesConcurrentLoads(t *testing.T) {
+	values := cache.New[string, int](time.Minute)
+	var loads atomic.Int32
+	release := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := values.Get("stats", func() (int, error) {
+				<-release
+				return int(loads.Add(1)), nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, 1, value)
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), loads.Load())
+}
+
+func TestCacheInvalidateAndErrors(t *testing.T) {
+	values := cache.New[int, string](time.Minute)
+	values.Get(1, func() (string, error) { return "one", nil })
+	values.Get(2, func() (string, error) { return "two", nil })
+
+	values.Invalidate(func(key int) bool { return key == 1 })
+
+	reloaded, _ := values.Get(1, func() (string, error) { return "uno", nil })
+	kept, _ := values.Get(2, func() (string, error) { return "dos", nil })
+	assert.Equal(t, "uno", reloaded)
+	assert.Equal(t, "two", kept)
+
+	failure := errors.New("database down")
+	_, err := values.Get(3, func() (string, error) { return "", failure })
+	assert.ErrorIs(t, err, failure)
+	retried, err := values.Get(3, func() (string, error) { return "three", nil })
+	assert.NoError(t, err)
+	assert.Equal(t, "three", retried)
+}
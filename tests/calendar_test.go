@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type CalendarTestSuite struct {
+	suite.Suite
+	*testApp
+	token string
+}
+
+func (suite *CalendarTestSuite) SetupSuite() {
+	suite.testApp = newTestApp(suite.T())
+}
+
+func (suite *CalendarTestSuite) SetupTest() {
+	suite.db.GetDB().Exec("TRUNCATE TABLE users RESTART IDENTITY CASCADE")
+	_, suite.token = suite.registerUser(suite.T(), "calendar@test.local")
+}
+
+func TestCalendarAPI(t *testing.T) {
+	suite.Run(t, new(CalendarTestSuite))
+}
+
+func (suite *CalendarTestSuite) createActivity(body string) {
+	status, result := suite.send(suite.T(), "POST", "/api/activities", body, suite.token)
+	assert.Equal(suite.T(), fiber.StatusCreated, status, result["message"])
+}
+
+func (suite *CalendarTestSuite) TestWeekBucketsActivitiesByDay() {
+	suite.createActivity(`{"title": "Standup", "category": "EVENT", "description": "Daily", "start_at": "2026-03-04T09:00:00Z"}`)
+	suite.createActivity(`{"title": "Report", "category": "TASK", "description": "Weekly", "due_at": "2026-03-04T17:00:00Z"}`)
+	suite.createActivity(`{"title": "Next week", "category": "TASK", "description": "Later", "due_at": "2026-03-10T17:00:00Z"}`)
+
+	status, result := suite.send(suite.T(), "GET", "/api/calendar?view=week&start=2026-03-05", "", suite.token)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+
+	calendar := result["data"].(map[string]interface{})
+	assert.Equal(suite.T(), "2026-03-02", calendar["start"])
+	assert.Equal(suite.T(), "2026-03-09", calendar["end"])
+	days := calendar["days"].([]interface{})
+	if assert.Len(suite.T(), days, 7) {
+		wednesday := days[2].(map[string]interface{})
+		assert.Equal(suite.T(), "2026-03-04", wednesday["date"])
+		assert.Equal(suite.T(), float64(2), wednesday["total"])
+		assert.Equal(suite.T(), float64(2), wednesday["counts"].(map[string]interface{})["NEW"])
+		assert.Len(suite.T(), wednesday["entries"], 2)
+		assert.Equal(suite.T(), float64(0), days[3].(map[string]interface{})["total"])
+	}
+}
+
+func (suite *CalendarTestSuite) TestMonthExpandsRecurringActivities() {
+	suite.createActivity(`{"title": "Yoga", "category": "EVENT", "description": "Weekly", "timezone": "Europe/Berlin",
+		"start_at": "2026-03-02T09:00:00+01:00", "end_at": "2026-03-02T10:00:00+01:00",
+		"recurrence": {"frequency": "weekly", "until": "2026-04-30T00:00:00Z"}}`)
+
+	status, result := suite.send(suite.T(), "GET", "/api/calendar?view=month&start=2026-03-15", "", suite.token)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+
+	var occursAt []string
+	for _, day := range result["data"].(map[string]interface{})["days"].([]interface{}) {
+		for _, entry := range day.(map[string]interface{})["entries"].([]interface{}) {
+			occursAt = append(occursAt, entry.(map[string]interface{})["occurs_at"].(string))
+			assert.True(suite.T(), entry.(map[string]interface{})["recurring"].(bool))
+		}
+	}
+	// Berlin switches to summer time on 29 March; the class stays at 9:00.
+	assert.Equal(suite.T(), []string{
+		"2026-03-02T08:00:00Z",
+		"2026-03-09T08:00:00Z",
+		"2026-03-16T08:00:00Z",
+		"2026-03-23T08:00:00Z",
+		"2026-03-30T07:00:00Z",
+	}, occursAt)
+}
+
+func (suite *CalendarTestSuite) TestRejectsUnknownView() {
+	status, _ := suite.send(suite.T(), "GET", "/api/calendar?view=year", "", suite.token)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, status)
+}
+
+func (suite *CalendarTestSuite) TestFreeBusyMergesOverlappingEvents() {
+	suite.createActivity(`{"title": "Planning", "category": "EVENT", "description": "Sprint",
+		"start_at": "2026-05-04T09:00:00Z", "end_at": "2026-05-04T10:00:00Z"}`)
+	suite.createActivity(`{"title": "Dentist", "category": "EVENT", "description": "Checkup",
+		"start_at": "2026-05-04T09:30:00Z", "end_at": "2026-05-04T11:00:00Z"}`)
+	suite.createActivity(`{"title": "Lunch", "category": "EVENT", "description": "Team",
+		"start_at": "2026-05-04T12:00:00Z", "end_at": "2026-05-04T13:00:00Z",
+		"recurrence": {"frequency": "daily"}}`)
+
+	status, result := suite.send(suite.T(), "GET",
+		"/api/freebusy?from=2026-05-04T00:00:00Z&to=2026-05-06T00:00:00Z", "", suite.token)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+
+	var busy []string
+	for _, period := range result["data"].(map[string]interface{})["busy"].([]interface{}) {
+		period := period.(map[string]interface{})
+		busy = append(busy, period["start"].(string)+"/"+period["end"].(string))
+	}
+	assert.Equal(suite.T(), []string{
+		"2026-05-04T09:00:00Z/2026-05-04T11:00:00Z",
+		"2026-05-04T12:00:00Z/2026-05-04T13:00:00Z",
+		"2026-05-05T12:00:00Z/2026-05-05T13:00:00Z",
+	}, busy)
+
+	_, stranger := suite.registerUser(suite.T(), "stranger@test.local")
+	status, _ = suite.send(suite.T(), "GET", "/api/freebusy?user_id=1", "", stranger)
+	assert.Equal(suite.T(), fiber.StatusForbidden, status)
+}
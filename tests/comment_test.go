@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+	"todolist-v1/modules/activity/entities"
+	"todolist-v1/pkg/events"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommentTestSuite struct {
+	suite.Suite
+	*testApp
+	ownerToken  string
+	friendToken string
+	activityId  int
+
+	mutex     sync.Mutex
+	published []string
+}
+
+func (suite *CommentTestSuite) SetupSuite() {
+	suite.testApp = newTestApp(suite.T())
+	suite.events.Subscribe(events.AllEvents, func(event events.Event) {
+		suite.mutex.Lock()
+		defer suite.mutex.Unlock()
+		suite.published = append(suite.published, event.Name)
+	})
+}
+
+func (suite *CommentTestSuite) SetupTest() {
+	suite.db.GetDB().Exec("TRUNCATE TABLE users RESTART IDENTITY CASCADE")
+	ownerId, ownerToken := suite.registerUser(suite.T(), "owner@test.local")
+	_, suite.friendToken = suite.registerUser(suite.T(), "friend@test.local")
+	suite.registerUser(suite.T(), "stranger@test.local")
+	suite.ownerToken = ownerToken
+
+	activity, _, err := suite.activities.Create(ownerId, entities.Activity{
+		Title:        "Discussed task",
+		Category:     "TASK",
+		Description:  "Needs a decision",
+		ActivityDate: time.Now(),
+	}, entities.ConflictsWarn)
+	assert.NoError(suite.T(), err)
+	suite.activityId = activity.Id
+
+	status, _ := suite.send(suite.T(), "POST", fmt.Sprintf("/api/activities/%d/shares", suite.activityId),
+		`{"email": "friend@test.local", "permission": "read"}`, suite.ownerToken)
+	assert.Equal(suite.T(), fiber.StatusCreated, status)
+
+	suite.mutex.Lock()
+	suite.published = nil
+	suite.mutex.Unlock()
+}
+
+func TestCommentAPI(t *testing.T) {
+	suite.Run(t, new(CommentTestSuite))
+}
+
+func (suite *CommentTestSuite) TestMentionsAndCount() {
+	status, result := suite.send(suite.T(), "POST", fmt.Sprintf("/api/activities/%d/comments", suite.activityId),
+		`{"body": "What do you think, @Friend@test.local? Not @stranger@test.local or `+"`@owner@test.local`"+`."}`, suite.ownerToken)
+	assert.Equal(suite.T(), fiber.StatusCreated, status)
+
+	mentions := result["data"].(map[string]interface{})["mentions"].([]interface{})
+	if assert.Len(suite.T(), mentions, 1) {
+		assert.Equal(suite.T(), "friend@test.local", mentions[0].(map[string]interface{})["email"])
+	}
+
+	status, result = suite.send(suite.T(), "GET", "/api/activities", "", suite.friendToken)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+	activities := result["data"].([]interface{})
+	if assert.Len(suite.T(), activities, 1) {
+		assert.Equal(suite.T(), float64(1), activities[0].(map[string]interface{})["comment_count"])
+	}
+
+	assert.Equal(suite.T(), []string{entities.EventCommentCreated}, suite.published)
+}
+
+func (suite *CommentTestSuite) TestOnlyAuthorEdits() {
+	status, result := suite.send(suite.T(), "POST", fmt.Sprintf("/api/activities/%d/comments", suite.activityId),
+		`{"body": "Looks good to me"}`, suite.friendToken)
+	assert.Equal(suite.T(), fiber.StatusCreated, status)
+	url := fmt.Sprintf("/api/activities/%d/comments/%v", suite.activityId, result["data"].(map[string]interface{})["id"])
+
+	status, _ = suite.send(suite.T(), "PUT", url, `{"body": "Rewritten by someone else"}`, suite.ownerToken)
+	assert.Equal(suite.T(), fiber.StatusForbidden, status)
+
+	status, _ = suite.send(suite.T(), "PUT", url, `{"body": "Looks great to me"}`, suite.friendToken)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+
+	status, _ = suite.send(suite.T(), "DELETE", url, "", suite.ownerToken)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+
+	assert.Equal(suite.T(), []string{
+		entities.EventCommentCreated,
+		entities.EventCommentUpdated,
+		entities.EventCommentDeleted,
+	}, suite.published)
+}
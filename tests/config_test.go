@@ -0,0 +1,31 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+	"todolist-v1/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidate_TokenSecrets(t *testing.T) {
+	valid := func() *config.Config {
+		var cfg config.Config
+		cfg.Auth.AccessTokenSecret = strings.Repeat("a", 32)
+		cfg.Auth.RefreshTokenSecret = strings.Repeat("r", 32)
+		return &cfg
+	}
+	assert.NoError(t, valid().Validate())
+
+	empty := valid()
+	empty.Auth.AccessTokenSecret = ""
+	assert.Error(t, empty.Validate())
+
+	short := valid()
+	short.Auth.RefreshTokenSecret = "change-me-too"
+	assert.Error(t, short.Validate())
+
+	same := valid()
+	same.Auth.RefreshTokenSecret = same.Auth.AccessTokenSecret
+	assert.Error(t, same.Validate())
+}
@@ -0,0 +1,19 @@
+package tests
+
+import (
+	"testing"
+	"todolist-v1/pkg/date"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadLocation(t *testing.T) {
+	location, err := date.LoadLocation("Europe/Paris")
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe/Paris", location.String())
+
+	for _, name := range []string{"", "Local", "Mars/Olympus_Mons"} {
+		_, err := date.LoadLocation(name)
+		assert.ErrorIs(t, err, date.ErrUnknownLocation, name)
+	}
+}
@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+	"time"
+	"todolist-v1/modules/activity/entities"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type DigestTestSuite struct {
+	suite.Suite
+	*testApp
+	userId int
+	token  string
+}
+
+func (suite *DigestTestSuite) SetupSuite() {
+	suite.testApp = newTestApp(suite.T())
+}
+
+func (suite *DigestTestSuite) SetupTest() {
+	suite.db.GetDB().Exec("TRUNCATE TABLE users RESTART IDENTITY CASCADE")
+	suite.userId, suite.token = suite.registerUser(suite.T(), "digest@test.local")
+	suite.notifications.take()
+}
+
+func TestDigestAPI(t *testing.T) {
+	suite.Run(t, new(DigestTestSuite))
+}
+
+func (suite *DigestTestSuite) createActivity(title string, date time.Time) {
+	_, _, err := suite.activities.Create(suite.userId, entities.Activity{
+		Title:        title,
+		Category:     "TASK",
+		Description:  "Digest test",
+		ActivityDate: date,
+	}, entities.ConflictsWarn)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *DigestTestSuite) TestDailyDigestIsSentOncePerDay() {
+	suite.createActivity("File taxes", time.Now().AddDate(0, 0, -3))
+	suite.createActivity("Team offsite", time.Now().AddDate(0, 0, 3))
+	suite.createActivity("Renew passport", time.Now().AddDate(0, 0, 30))
+
+	status, result := suite.send(suite.T(), "PUT", "/api/digest",
+		`{"frequency": "daily", "timezone": "Asia/Tokyo", "send_hour": 0}`, suite.token)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+	assert.Equal(suite.T(), "Asia/Tokyo", result["data"].(map[string]interface{})["timezone"])
+
+	sent, err := suite.digests.DispatchDue()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, sent)
+
+	notifications := suite.notifications.take()
+	if assert.Len(suite.T(), notifications, 1) {
+		assert.Equal(suite.T(), "digest@test.local", notifications[0].To)
+		assert.Contains(suite.T(), notifications[0].Body, "File taxes")
+		assert.Contains(suite.T(), notifications[0].Body, "Team offsite")
+		assert.NotContains(suite.T(), notifications[0].Body, "Renew passport")
+		assert.Contains(suite.T(), notifications[0].HTMLBody, "<h3 style=\"color: #b00020;\">Overdue</h3>")
+	}
+
+	sent, err = suite.digests.DispatchDue()
+	assert.NoError(suite.T(), err)
+	assert.Zero(suite.T(), sent)
+}
+
+func (suite *DigestTestSuite) TestDigestListsLaterOccurrencesOfRecurringActivities() {
+	// The fifth weekly occurrence falls in the coming week.
+	first := time.Now().UTC().AddDate(0, 0, 2-28)
+	weekly := entities.RecurrenceWeekly
+	_, _, err := suite.activities.Create(suite.userId, entities.Activity{
+		Title:               "Water the plants",
+		Category:            "TASK",
+		Description:         "Digest test",
+		ActivityDate:        first,
+		RecurrenceFrequency: &weekly,
+		RecurrenceInterval:  1,
+	}, entities.ConflictsWarn)
+	assert.NoError(suite.T(), err)
+
+	status, _ := suite.send(suite.T(), "PUT", "/api/digest",
+		`{"frequency": "daily", "timezone": "UTC", "send_hour": 0}`, suite.token)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+
+	sent, err := suite.digests.DispatchDue()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, sent)
+
+	notifications := suite.notifications.take()
+	if assert.Len(suite.T(), notifications, 1) {
+		next := first.AddDate(0, 0, 28).Format("Mon 02 Jan 15:04")
+		assert.Contains(suite.T(), notifications[0].Body, next+" Water the plants")
+	}
+}
+
+func (suite *DigestTestSuite) TestWeeklyDigestWaitsForItsWeekday() {
+	suite.createActivity("Team offsite", time.Now().AddDate(0, 0, 3))
+	tomorrow := (time.Now().UTC().Weekday() + 1) % 7
+
+	status, _ := suite.send(suite.T(), "PUT", "/api/digest",
+		fmt.Sprintf(`{"frequency": "weekly", "send_hour": 0, "weekday": %d}`, tomorrow), suite.token)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+
+	sent, err := suite.digests.DispatchDue()
+	assert.NoError(suite.T(), err)
+	assert.Zero(suite.T(), sent)
+}
+
+func (suite *DigestTestSuite) TestRejectsUnknownTimezone() {
+	status, _ := suite.send(suite.T(), "PUT", "/api/digest",
+		`{"frequency": "daily", "timezone": "Mars/Olympus_Mons"}`, suite.token)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, status)
+
+	status, _ = suite.send(suite.T(), "GET", "/api/digest", "", suite.token)
+	assert.Equal(suite.T(), fiber.StatusNotFound, status)
+}
+
+func (suite *DigestTestSuite) TestRejectsLocalTimezone() {
+	status, _ := suite.send(suite.T(), "PUT", "/api/digest",
+		`{"frequency": "daily", "timezone": "Local"}`, suite.token)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, status)
+
+	status, _ = suite.send(suite.T(), "GET", "/api/digest", "", suite.token)
+	assert.Equal(suite.T(), fiber.StatusNotFound, status)
+}
+
+func (suite *DigestTestSuite) TestUnsubscribe() {
+	status, _ := suite.send(suite.T(), "PUT", "/api/digest", `{"frequency": "daily"}`, suite.token)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+
+	status, _ = suite.send(suite.T(), "DELETE", "/api/digest", "", suite.token)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+
+	status, _ = suite.send(suite.T(), "GET", "/api/digest", "", suite.token)
+	assert.Equal(suite.T(), fiber.StatusNotFound, status)
+}
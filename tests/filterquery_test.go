@@ -0,0 +1,111 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"todolist-v1/pkg/filterquery"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var filterSchema = filterquery.Schema{
+	"status":   {Column: "status", Kind: filterquery.Enum, Values: []string{"NEW", "ON PROGRESS", "DONE"}},
+	"priority": {Column: "priority", Kind: filterquery.Enum, Values: []string{"P1", "P2", "P3", "P4"}, Ordered: true},
+	"tag":      {Column: "tags", Kind: filterquery.Tag},
+	"title":    {Column: "title", Kind: filterquery.Text},
+	"date":     {Column: "activity_date", Kind: filterquery.Date},
+	"project":  {Column: "project_id", Kind: filterquery.Number},
+}
+
+func compileFilter(text string, location *time.Location) (string, []any, error) {
+	node, err := filterquery.Parse(text)
+	if err != nil {
+		return "", nil, err
+	}
+	condition, err := filterquery.Compile(node, filterSchema, location)
+	return condition.SQL, condition.Vars, err
+}
+
+func TestFilterQueryCompile(t *testing.T) {
+	zone := time.FixedZone("WIB", 7*60*60)
+	day := func(d int) time.Time { return time.Date(2026, 11, d, 0, 0, 0, 0, zone) }
+
+	tests := []struct {
+		text string
+		sql  string
+		vars []any
+	}{
+		{
+			text: "status:NEW AND (tag:urgent OR priority<=P2) AND date<2026-11-01",
+			sql:  "(((status = ?) AND ((? = ANY(tags)) OR (priority <= ?))) AND (activity_date < ?))",
+			vars: []any{"NEW", "urgent", "P2", day(1)},
+		},
+		{
+			text: `status:"on progress" or not tag:#Urgent`,
+			sql:  "((status = ?) OR NOT (? = ANY(tags)))",
+			vars: []any{"ON PROGRESS", "urgent"},
+		},
+		{
+			text: "date:2026-11-02 AND date>2026-11-01",
+			sql:  "((activity_date >= ? AND activity_date < ?) AND (activity_date >= ?))",
+			vars: []any{day(2), day(3), day(2)},
+		},
+		{
+			text: `title:"50%_off" AND project!=3`,
+			sql:  "((title ILIKE ?) AND (project_id <> ?))",
+			vars: []any{`%50\%\_off%`, 3},
+		},
+		{
+			text: `title:"'; DROP TABLE activities; --"`,
+			sql:  "(title ILIKE ?)",
+			vars: []any{"%'; DROP TABLE activities; --%"},
+		},
+	}
+
+	for _, test := range tests {
+		sql, vars, err := compileFilter(test.text, zone)
+		if assert.NoError(t, err, test.text) {
+			assert.Equal(t, test.sql, sql, test.text)
+			assert.Equal(t, test.vars, vars, test.text)
+		}
+	}
+}
+
+func TestFilterQueryNotBindsTighterThanAnd(t *testing.T) {
+	sql, _, err := compileFilter("NOT status:NEW AND tag:x OR tag:y", time.UTC)
+	assert.NoError(t, err)
+	assert.Equal(t, "((NOT (status = ?) AND (? = ANY(tags))) OR (? = ANY(tags)))", sql)
+}
+
+func TestFilterQueryErrors(t *testing.T) {
+	tests := []struct {
+		text     string
+		position int
+		message  string
+	}{
+		{"", 1, "empty query"},
+		{"status:NEW AND", 15, "expected a comparison"},
+		{"status:NEW tag:x", 12, "expected AND, OR or end of query"},
+		{"(status:NEW OR tag:x", 21, `expected ")" to close the "(" at position 1`},
+		{"status:NEW)", 11, `unexpected ")"`},
+		{"status NEW", 8, `expected an operator after "status"`},
+		{"status:", 8, `expected a value after "status:"`},
+		{`title:"open`, 7, "unterminated string"},
+		{"tag!x", 4, `did you mean "!="`},
+		{"colour:red", 1, `unknown field "colour"`},
+		{"status:OPEN", 8, `invalid status "OPEN"`},
+		{"status<NEW", 7, `operator "<" does not apply to status`},
+		{"date<11/01/2026", 6, "invalid date"},
+		{"project:abc", 9, "expected a number"},
+	}
+
+	for _, test := range tests {
+		_, _, err := compileFilter(test.text, time.UTC)
+		var queryErr *filterquery.Error
+		if assert.True(t, errors.As(err, &queryErr), test.text) {
+			assert.Equal(t, test.position, queryErr.Position, test.text)
+			assert.Contains(t, queryErr.Message, test.message, test.text)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"todolist-v1/config"
+	activityEntities "todolist-v1/modules/activity/entities"
+	activityHandler "todolist-v1/modules/activity/handler"
+	activityRepo "todolist-v1/modules/activity/repository"
+	activityUsecase "todolist-v1/modules/activity/usecase"
+	authEntities "todolist-v1/modules/auth/entities"
+	authHandler "todolist-v1/modules/auth/handler"
+	authMiddleware "todolist-v1/modules/auth/middleware"
+	authRepo "todolist-v1/modules/auth/repository"
+	authUsecase "todolist-v1/modules/auth/usecase"
+	calendarHandler "todolist-v1/modules/calendar/handler"
+	calendarUsecase "todolist-v1/modules/calendar/usecase"
+	digestHandler "todolist-v1/modules/digest/handler"
+	digestRepo "todolist-v1/modules/digest/repository"
+	digestUsecase "todolist-v1/modules/digest/usecase"
+	projectHandler "todolist-v1/modules/project/handler"
+	projectRepo "todolist-v1/modules/project/repository"
+	projectUsecase "todolist-v1/modules/project/usecase"
+	reminderHandler "todolist-v1/modules/reminder/handler"
+	reminderRepo "todolist-v1/modules/reminder/repository"
+	reminderUsecase "todolist-v1/modules/reminder/usecase"
+	statsHandler "todolist-v1/modules/stats/handler"
+	statsUsecase "todolist-v1/modules/stats/usecase"
+	templateHandler "todolist-v1/modules/template/handler"
+	templateRepo "todolist-v1/modules/template/repository"
+	templateUsecase "todolist-v1/modules/template/usecase"
+	workspaceHandler "todolist-v1/modules/workspace/handler"
+	workspaceRepo "todolist-v1/modules/workspace/repository"
+	workspaceUsecase "todolist-v1/modules/workspace/usecase"
+	"todolist-v1/pkg/database"
+	"todolist-v1/pkg/events"
+	"todolist-v1/pkg/notifier"
+	"todolist-v1/pkg/storage"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// testApp wires every module against the test database the same way
+// main.go does, and exposes the usecases for seeding data.
+type testApp struct {
+	app          *fiber.App
+	db           *database.PostgresDB
+	cfg          *config.Config
+	auth         authUsecase.AuthUsecase
+	apiKeys      authUsecase.ApiKeyUsecase
+	activities   activityUsecase.ActivityUsecase
+	dependencies activityUsecase.ActivityDependencyUsecase
+	events       events.Bus
+	blobs        storage.BlobStore
+	reminders    reminderUsecase.ReminderUsecase
+	digests      digestUsecase.DigestUsecase
+	calendar     calendarUsecase.CalendarUsecase
+	projects     projectUsecase.ProjectUsecase
+	// notifications records what the usecases would have delivered.
+	notifications *recordingNotifier
+}
+
+func newTestApp(t *testing.T) *testApp {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	db := database.NewPostgresDatabase()
+	if err := db.Connect(cfg.Database.URL); err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+
+	app := fiber.New()
+
+	userRepository := authRepo.NewUserRepository(db.GetDB())
+	auth := authUsecase.NewAuthUsecase(userRepository, authRepo.NewRefreshTokenRepository(db.GetDB()), cfg)
+	apiKeys := authUsecase.NewApiKeyUsecase(authRepo.NewApiKeyRepository(db.GetDB()), userRepository)
+	requireAuth := authMiddleware.NewAuthMiddleware(auth, apiKeys)
+	authHandler.NewAuthHttpHandler(app, auth, requireAuth).RegisterRoutes()
+	authHandler.NewApiKeyHttpHandler(app, apiKeys, requireAuth).RegisterRoutes()
+
+	workspaceRepository := workspaceRepo.NewWorkspaceRepository(db.GetDB())
+	workspaces := workspaceUsecase.NewWorkspaceUsecase(workspaceRepository, workspaceRepo.NewInvitationRepository(db.GetDB()))
+	workspaceHandler.NewWorkspaceHttpHandler(app, workspaces, requireAuth).RegisterRoutes()
+
+	bus := events.NewBus()
+	activityRepository := activityRepo.NewActivityRepository(db.GetDB())
+	historyRepository := activityRepo.NewActivityHistoryRepository(db.GetDB())
+	shareRepository := activityRepo.NewActivityShareRepository(db.GetDB())
+	projectRepository := projectRepo.NewProjectRepository(db.GetDB())
+	activities := activityUsecase.NewActivityUsecase(activityRepository, historyRepository, activityRepo.NewUndoRepository(db.GetDB()), shareRepository, workspaceRepository, projectRepository, bus, cfg)
+	activityHandler.NewActivityHttpHandler(app, activities, requireAuth).RegisterRoutes()
+	shares := activityUsecase.NewActivityShareUsecase(activityRepository, shareRepository, workspaceRepository, userRepository)
+	activityHandler.NewActivityShareHttpHandler(app, shares, requireAuth).RegisterRoutes()
+	dependencies := activityUsecase.NewActivityDependencyUsecase(activityRepository, activityRepo.NewActivityDependencyRepository(db.GetDB()), shareRepository, workspaceRepository)
+	activityHandler.NewActivityDependencyHttpHandler(app, dependencies, requireAuth).RegisterRoutes()
+	timeEntries := activityUsecase.NewTimeEntryUsecase(activityRepository, activityRepo.NewTimeEntryRepository(db.GetDB()), shareRepository, workspaceRepository)
+	activityHandler.NewTimeEntryHttpHandler(app, timeEntries, requireAuth).RegisterRoutes()
+	savedFilters := activityUsecase.NewSavedFilterUsecase(activityRepository, activityRepo.NewSavedFilterRepository(db.GetDB()), shareRepository, workspaceRepository)
+	activityHandler.NewSavedFilterHttpHandler(app, savedFilters, requireAuth).RegisterRoutes()
+	comments := activityUsecase.NewActivityCommentUsecase(activityRepository, activityRepo.NewActivityCommentRepository(db.GetDB()), shareRepository, workspaceRepository, userRepository, bus)
+	activityHandler.NewActivityCommentHttpHandler(app, comments, requireAuth).RegisterRoutes()
+
+	store, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+	attachments := activityUsecase.NewActivityAttachmentUsecase(activityRepository, activityRepo.NewActivityAttachmentRepository(db.GetDB()), shareRepository, workspaceRepository, store, cfg)
+	activityHandler.NewActivityAttachmentHttpHandler(app, attachments, requireAuth).RegisterRoutes()
+	bus.Subscribe(activityEntities.EventActivityPurged, func(event events.Event) {
+		if err := attachments.RemoveOrphans(); err != nil {
+			t.Errorf("Failed to remove attachments of a purged activity: %v", err)
+		}
+	})
+
+	sentNotifications := &recordingNotifier{}
+	reminders := reminderUsecase.NewReminderUsecase(reminderRepo.NewReminderRepository(db.GetDB()), activityRepository, sentNotifications, cfg)
+	reminderHandler.NewReminderHttpHandler(app, reminders, requireAuth).RegisterRoutes()
+	digests := digestUsecase.NewDigestUsecase(digestRepo.NewDigestSubscriptionRepository(db.GetDB()), activityRepository, sentNotifications, cfg)
+	digestHandler.NewDigestHttpHandler(app, digests, requireAuth).RegisterRoutes()
+	calendar := calendarUsecase.NewCalendarUsecase(activityRepository, workspaceRepository)
+	calendarHandler.NewCalendarHttpHandler(app, calendar, requireAuth).RegisterRoutes()
+	projects := projectUsecase.NewProjectUsecase(projectRepository, activityRepository, workspaceRepository)
+	projectHandler.NewProjectHttpHandler(app, projects, requireAuth).RegisterRoutes()
+	templates := templateUsecase.NewTemplateUsecase(templateRepo.NewTemplateRepository(db.GetDB()), activities, workspaceRepository, userRepository)
+	templateHandler.NewTemplateHttpHandler(app, templates, requireAuth).RegisterRoutes()
+	stats := statsUsecase.NewStatsUsecase(activityRepo.NewActivityStatsRepository(db.GetDB()), cfg)
+	statsHandler.NewStatsHttpHandler(app, stats, requireAuth).RegisterRoutes()
+	bus.Subscribe(events.AllEvents, func(event events.Event) {
+		stats.InvalidateActivity(event.ActorId, event.ActivityId)
+	})
+
+	return &testApp{
+		app:          app,
+		db:           db,
+		cfg:          cfg,
+		auth:         auth,
+		apiKeys:      apiKeys,
+		activities:   activities,
+		dependencies: dependencies,
+		events:       bus,
+		blobs:        store,
+		reminders:    reminders,
+		digests:      digests,
+		calendar:     calendar,
+		projects:     projects,
+
+		notifications: sentNotifications,
+	}
+}
+
+// registerUser creates a user and returns its id and an access token.
+func (testApp *testApp) registerUser(t *testing.T, email string) (int, string) {
+	user, err := testApp.auth.Register(authEntities.User{Email: email, Name: email}, "password123")
+	if err != nil {
+		t.Fatalf("Failed to register test user: %v", err)
+	}
+	pair, err := testApp.auth.Login(email, "password123")
+	if err != nil {
+		t.Fatalf("Failed to log in test user: %v", err)
+	}
+	return user.Id, pair.AccessToken
+}
+
+// send performs a JSON request as the holder of token, or anonymously when
+// token is empty, and returns the status code and the decoded body.
+func (testApp *testApp) send(t *testing.T, method string, url string, body string, token string) (int, map[string]interface{}) {
+	req, _ := http.NewRequest(method, url, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := testApp.app.Test(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	respBody, _ := io.ReadAll(resp.Body)
+	var result map[string]interface{}
+	json.Unmarshal(respBody, &result)
+	return resp.StatusCode, result
+}
+
+// recordingNotifier keeps notifications in memory instead of delivering them.
+type recordingNotifier struct {
+	mutex sync.Mutex
+	sent  []notifier.Notification
+}
+
+func (recorder *recordingNotifier) Notify(notification notifier.Notification) error {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.sent = append(recorder.sent, notification)
+	return nil
+}
+
+// take returns the notifications recorded so far and forgets them.
+func (recorder *recordingNotifier) take() []notifier.Notification {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	sent := recorder.sent
+	recorder.sent = nil
+	return sent
+}
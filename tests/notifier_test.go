@@ -0,0 +1,200 @@
+package tests
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"todolist-v1/pkg/notifier"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSMTP accepts a single SMTP session on a local port and reports the
+// envelope and message it received.
+type fakeSMTP struct {
+	listener net.Listener
+	received chan fakeMail
+}
+
+type fakeMail struct {
+	from string
+	to   []string
+	data string
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	server := &fakeSMTP{listener: listener, received: make(chan fakeMail, 1)}
+	go server.serve()
+	return server
+}
+
+func (server *fakeSMTP) port() int {
+	return server.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (server *fakeSMTP) serve() {
+	conn, err := server.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+	var mail fakeMail
+	reply("220 localhost fake SMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			mail.from = strings.Trim(strings.TrimSpace(line)[len("MAIL FROM:"):], "<>")
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			mail.to = append(mail.to, strings.Trim(strings.TrimSpace(line)[len("RCPT TO:"):], "<>"))
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			mail.data = data.String()
+			reply("250 OK")
+		case command == "QUIT":
+			reply("221 Bye")
+			server.received <- mail
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	server := newFakeSMTP(t)
+	defer server.listener.Close()
+
+	smtpNotifier := notifier.NewSMTPNotifier(notifier.SMTPOptions{
+		Host: "127.0.0.1",
+		Port: server.port(),
+		From: "todolist@test.local",
+	})
+	err := smtpNotifier.Notify(notifier.Notification{
+		To:       "user@test.local",
+		Subject:  "Reminder: Dentist",
+		Body:     "Dentist is scheduled for today.",
+		HTMLBody: "<p>Dentist is scheduled for today.</p>",
+		Key:      "reminder-7-1792400400",
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	mail := <-server.received
+	assert.Equal(t, "todolist@test.local", mail.from)
+	assert.Equal(t, []string{"user@test.local"}, mail.to)
+	assert.Contains(t, mail.data, "Subject: Reminder: Dentist")
+	assert.Contains(t, mail.data, "multipart/alternative")
+	assert.Contains(t, mail.data, "<p>Dentist is scheduled for today.</p>")
+	assert.Contains(t, mail.data, "Message-ID: <reminder-7-1792400400@test.local>")
+	assert.Contains(t, mail.data, "Idempotency-Key: reminder-7-1792400400")
+}
+
+func TestSMTPNotifier_TimesOutOnSilentServer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		// Accept the connection and never greet.
+		conn, err := listener.Accept()
+		if err == nil {
+			defer conn.Close()
+			io.Copy(io.Discard, conn)
+		}
+	}()
+
+	smtpNotifier := notifier.NewSMTPNotifier(notifier.SMTPOptions{
+		Host:    "127.0.0.1",
+		Port:    listener.Addr().(*net.TCPAddr).Port,
+		From:    "todolist@test.local",
+		Timeout: 100 * time.Millisecond,
+	})
+	started := time.Now()
+	err = smtpNotifier.Notify(notifier.Notification{To: "user@test.local", Subject: "Reminder", Body: "Soon."})
+	assert.Error(t, err)
+	assert.Less(t, time.Since(started), 5*time.Second)
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var body []byte
+	var signature, key string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(notifier.SignatureHeader)
+		key = r.Header.Get(notifier.IdempotencyHeader)
+	}))
+	defer server.Close()
+
+	err := notifier.NewWebhookNotifier(server.URL, "secret").Notify(notifier.Notification{
+		Kind:    "reminder",
+		To:      "user@test.local",
+		Subject: "Reminder: Dentist",
+		Body:    "Dentist is scheduled for today.",
+		Key:     "reminder-7-1792400400",
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "reminder-7-1792400400", key)
+
+	var payload map[string]string
+	assert.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, "reminder", payload["kind"])
+	assert.Equal(t, "user@test.local", payload["to"])
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), signature)
+}
+
+func TestWebhookNotifier_ReportsErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := notifier.NewWebhookNotifier(server.URL, "").Notify(notifier.Notification{To: "user@test.local"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), strconv.Itoa(http.StatusServiceUnavailable))
+	}
+}
@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProjectTestSuite struct {
+	suite.Suite
+	*testApp
+	token string
+}
+
+func (suite *ProjectTestSuite) SetupSuite() {
+	suite.testApp = newTestApp(suite.T())
+}
+
+func (suite *ProjectTestSuite) SetupTest() {
+	suite.db.GetDB().Exec("TRUNCATE TABLE users RESTART IDENTITY CASCADE")
+	_, suite.token = suite.registerUser(suite.T(), "projects@test.local")
+}
+
+func TestProjectAPI(t *testing.T) {
+	suite.Run(t, new(ProjectTestSuite))
+}
+
+func (suite *ProjectTestSuite) createProject(name string) int {
+	status, result := suite.send(suite.T(), "POST", "/api/projects", fmt.Sprintf(`{"name": %q}`, name), suite.token)
+	suite.Require().Equal(fiber.StatusCreated, status, result["message"])
+	return int(result["data"].(map[string]interface{})["id"].(float64))
+}
+
+func (suite *ProjectTestSuite) createCard(projectId int, title string) int {
+	body := fmt.Sprintf(`{"project_id": %d, "title": %q, "category": "TASK", "description": "Card", "due_at": "2026-03-04T17:00:00Z"}`, projectId, title)
+	status, result := suite.send(suite.T(), "POST", "/api/activities", body, suite.token)
+	suite.Require().Equal(fiber.StatusCreated, status, result["message"])
+	return int(result["data"].(map[string]interface{})["id"].(float64))
+}
+
+// boardTitles returns the titles in each column of the project's board.
+func (suite *ProjectTestSuite) boardTitles(projectId int) map[string][]string {
+	status, result := suite.send(suite.T(), "GET", fmt.Sprintf("/api/projects/%d/board", projectId), "", suite.token)
+	suite.Require().Equal(fiber.StatusOK, status, result["message"])
+
+	titles := make(map[string][]string)
+	for _, column := range result["data"].(map[string]interface{})["columns"].([]interface{}) {
+		column := column.(map[string]interface{})
+		status := column["status"].(string)
+		titles[status] = []string{}
+		for _, card := range column["cards"].([]interface{}) {
+			titles[status] = append(titles[status], card.(map[string]interface{})["title"].(string))
+		}
+	}
+	return titles
+}
+
+func (suite *ProjectTestSuite) TestMoveReordersAndChangesColumn() {
+	projectId := suite.createProject("Launch")
+	design := suite.createCard(projectId, "Design")
+	build := suite.createCard(projectId, "Build")
+	ship := suite.createCard(projectId, "Ship")
+
+	assert.Equal(suite.T(), []string{"Design", "Build", "Ship"}, suite.boardTitles(projectId)["NEW"])
+
+	status, result := suite.send(suite.T(), "POST", fmt.Sprintf("/api/activities/%d/move", ship), fmt.Sprintf(`{"status": "NEW", "before_id": %d}`, design), suite.token)
+	assert.Equal(suite.T(), fiber.StatusOK, status, result["message"])
+
+	status, result = suite.send(suite.T(), "POST", fmt.Sprintf("/api/activities/%d/move", build), `{"status": "DONE"}`, suite.token)
+	assert.Equal(suite.T(), fiber.StatusOK, status, result["message"])
+	assert.Equal(suite.T(), "DONE", result["data"].(map[string]interface{})["status"])
+
+	status, _ = suite.send(suite.T(), "POST", fmt.Sprintf("/api/activities/%d/move", design), fmt.Sprintf(`{"status": "DONE", "before_id": %d}`, build), suite.token)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+
+	board := suite.boardTitles(projectId)
+	assert.Equal(suite.T(), []string{"Ship"}, board["NEW"])
+	assert.Equal(suite.T(), []string{"Design", "Build"}, board["DONE"])
+	assert.Empty(suite.T(), board["ON PROGRESS"])
+}
+
+func (suite *ProjectTestSuite) TestMoveRejectsNeighbourOutsideColumn() {
+	projectId := suite.createProject("Launch")
+	design := suite.createCard(projectId, "Design")
+	build := suite.createCard(projectId, "Build")
+
+	status, _ := suite.send(suite.T(), "POST", fmt.Sprintf("/api/activities/%d/move", build), fmt.Sprintf(`{"status": "DONE", "after_id": %d}`, design), suite.token)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, status)
+	assert.Equal(suite.T(), []string{"Design", "Build"}, suite.boardTitles(projectId)["NEW"])
+}
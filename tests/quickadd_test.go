@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"testing"
+	"time"
+	"todolist-v1/pkg/quickadd"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuickAddParse(t *testing.T) {
+	zone := time.FixedZone("WIB", 7*60*60)
+	// A Monday morning.
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, zone)
+	at := func(month time.Month, day int, hour int, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, zone)
+	}
+
+	tests := []struct {
+		text string
+		want quickadd.Activity
+	}{
+		{
+			text: "Dentist appointment next Tuesday 3pm #health !p1",
+			want: quickadd.Activity{Title: "Dentist appointment", Category: "EVENT", Priority: "P1", Tags: []string{"health"}, At: at(3, 3, 15, 0)},
+		},
+		{
+			text: "Buy milk",
+			want: quickadd.Activity{Title: "Buy milk", Category: "TASK", At: at(3, 2, 0, 0), AllDay: true},
+		},
+		{
+			text: "Call mom tomorrow",
+			want: quickadd.Activity{Title: "Call mom", Category: "TASK", At: at(3, 3, 0, 0), AllDay: true},
+		},
+		{
+			text: "Standup at 9:30am",
+			want: quickadd.Activity{Title: "Standup", Category: "EVENT", At: at(3, 3, 9, 30)},
+		},
+		{
+			text: "Lunch with Sam at noon",
+			want: quickadd.Activity{Title: "Lunch with Sam", Category: "EVENT", At: at(3, 2, 12, 0)},
+		},
+		{
+			text: "Team sync monday 10 am",
+			want: quickadd.Activity{Title: "Team sync", Category: "EVENT", At: at(3, 2, 10, 0)},
+		},
+		{
+			text: "Retro next monday 14:00",
+			want: quickadd.Activity{Title: "Retro", Category: "EVENT", At: at(3, 9, 14, 0)},
+		},
+		{
+			text: "Submit report on friday 5pm @task !P2",
+			want: quickadd.Activity{Title: "Submit report", Category: "TASK", Priority: "P2", At: at(3, 6, 17, 0)},
+		},
+		{
+			text: "Renew passport in 2 weeks #Admin #travel",
+			want: quickadd.Activity{Title: "Renew passport", Category: "TASK", Tags: []string{"admin", "travel"}, At: at(3, 16, 0, 0), AllDay: true},
+		},
+		{
+			text: "Plan budget next month",
+			want: quickadd.Activity{Title: "Plan budget", Category: "TASK", At: at(4, 1, 0, 0), AllDay: true},
+		},
+		{
+			text: "Conference 2026-04-14 @event",
+			want: quickadd.Activity{Title: "Conference", Category: "EVENT", At: at(4, 14, 0, 0), AllDay: true},
+		},
+		{
+			text: "Pay rent on March 1st",
+			want: quickadd.Activity{Title: "Pay rent", Category: "TASK", At: time.Date(2027, 3, 1, 0, 0, 0, 0, zone), AllDay: true},
+		},
+		{
+			text: "Party 29 feb",
+			want: quickadd.Activity{Title: "Party", Category: "TASK", At: time.Date(2028, 2, 29, 0, 0, 0, 0, zone), AllDay: true},
+		},
+		{
+			text: "Release, 14 April 2027 at 8:15",
+			want: quickadd.Activity{Title: "Release", Category: "EVENT", At: time.Date(2027, 4, 14, 8, 15, 0, 0, zone)},
+		},
+		{
+			text: "Read 3 chapters",
+			want: quickadd.Activity{Title: "Read 3 chapters", Category: "TASK", At: at(3, 2, 0, 0), AllDay: true},
+		},
+		{
+			text: "May the 4th be with you",
+			want: quickadd.Activity{Title: "May the 4th be with you", Category: "TASK", At: at(3, 2, 0, 0), AllDay: true},
+		},
+		{
+			text: "Meet at 25:00 on the roof",
+			want: quickadd.Activity{Title: "Meet at 25:00 on the roof", Category: "TASK", At: at(3, 2, 0, 0), AllDay: true},
+		},
+		{
+			text: "Call Ana tomorrow or friday",
+			want: quickadd.Activity{Title: "Call Ana or friday", Category: "TASK", At: at(3, 3, 0, 0), AllDay: true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			got, err := quickadd.Parse(test.text, now)
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestQuickAddNeedsTitle(t *testing.T) {
+	_, err := quickadd.Parse("tomorrow 3pm #health !p1", time.Now())
+	assert.ErrorIs(t, err, quickadd.ErrNoTitle)
+}
@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"testing"
+	"todolist-v1/pkg/rank"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankBetween(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want string
+	}{
+		{"", "", "i"},
+		{"i", "", "i1"},
+		{"iz", "", "j"},
+		{"", "i", "hz"},
+		{"a", "b", "ai"},
+		{"a", "c", "b"},
+		{"az", "b", "azi"},
+		{"a1", "a2", "a1i"},
+		{"", "01", "00z"},
+		{"zz", "", "zzi"},
+		{"a", "a01", "a00i"},
+	}
+	for _, c := range cases {
+		got, err := rank.Between(c.a, c.b)
+		if assert.NoError(t, err, "between %q and %q", c.a, c.b) {
+			assert.Equal(t, c.want, got, "between %q and %q", c.a, c.b)
+			assert.Greater(t, got, c.a)
+			if c.b != "" {
+				assert.Less(t, got, c.b)
+			}
+		}
+	}
+}
+
+func TestRankBetween_RejectsBadInput(t *testing.T) {
+	_, err := rank.Between("b", "a")
+	assert.ErrorIs(t, err, rank.ErrOutOfOrder)
+	_, err = rank.Between("a", "a")
+	assert.ErrorIs(t, err, rank.ErrOutOfOrder)
+	_, err = rank.Between("a0", "")
+	assert.ErrorIs(t, err, rank.ErrInvalidRank)
+	_, err = rank.Between("A", "")
+	assert.ErrorIs(t, err, rank.ErrInvalidRank)
+}
+
+// Inserting repeatedly at the same spot keeps producing ordered ranks.
+func TestRankBetween_RepeatedInsertsStayOrdered(t *testing.T) {
+	low, high := "", ""
+	ranks := []string{}
+	for i := 0; i < 200; i++ {
+		r, err := rank.Between(low, high)
+		if !assert.NoError(t, err) {
+			return
+		}
+		ranks = append(ranks, r)
+		if i%2 == 0 {
+			low = r
+		} else {
+			high = r
+		}
+	}
+	assert.Less(t, len(ranks[len(ranks)-1]), 100)
+}
+
+// Appending and prepending thousands of times, renumbering once ranks get
+// too long, keeps ranks ordered and bounded.
+func TestRankBetween_AppendsAndPrependsStayBounded(t *testing.T) {
+	ranks := []string{}
+	longest := 0
+	for i := 0; i < 5000; i++ {
+		var low, high string
+		if i%3 == 0 {
+			if len(ranks) > 0 {
+				high = ranks[0]
+			}
+		} else if len(ranks) > 0 {
+			low = ranks[len(ranks)-1]
+		}
+		r, err := rank.Between(low, high)
+		if !assert.NoError(t, err) {
+			return
+		}
+		if i%3 == 0 {
+			ranks = append([]string{r}, ranks...)
+		} else {
+			ranks = append(ranks, r)
+		}
+		longest = max(longest, len(r))
+		if len(r) > rank.MaxLength {
+			ranks = rank.Spread(len(ranks))
+		}
+	}
+
+	assert.LessOrEqual(t, longest, rank.MaxLength+rank.MaxLength/8)
+	for i := 1; i < len(ranks); i++ {
+		assert.Less(t, ranks[i-1], ranks[i])
+	}
+}
+
+func TestRankSpread(t *testing.T) {
+	ranks := rank.Spread(2000)
+	assert.Len(t, ranks, 2000)
+	for i, r := range ranks {
+		assert.LessOrEqual(t, len(r), 4)
+		if i > 0 {
+			between, err := rank.Between(ranks[i-1], r)
+			assert.NoError(t, err)
+			assert.LessOrEqual(t, len(between), 5)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+	"todolist-v1/modules/activity/entities"
+	reminderEntities "todolist-v1/modules/reminder/entities"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReminderTestSuite struct {
+	suite.Suite
+	*testApp
+	userId int
+	token  string
+}
+
+func (suite *ReminderTestSuite) SetupSuite() {
+	suite.testApp = newTestApp(suite.T())
+}
+
+func (suite *ReminderTestSuite) SetupTest() {
+	suite.db.GetDB().Exec("TRUNCATE TABLE users RESTART IDENTITY CASCADE")
+	suite.userId, suite.token = suite.registerUser(suite.T(), "reminded@test.local")
+	suite.notifications.take()
+}
+
+func TestReminderAPI(t *testing.T) {
+	suite.Run(t, new(ReminderTestSuite))
+}
+
+func (suite *ReminderTestSuite) createActivity(date time.Time) int {
+	activity, _, err := suite.activities.Create(suite.userId, entities.Activity{
+		Title:        "Dentist",
+		Category:     "EVENT",
+		Description:  "Check-up",
+		ActivityDate: date,
+	}, entities.ConflictsWarn)
+	assert.NoError(suite.T(), err)
+	return activity.Id
+}
+
+func (suite *ReminderTestSuite) TestRelativeReminderFiresOnce() {
+	activityId := suite.createActivity(time.Now().Add(10 * time.Minute))
+
+	status, result := suite.send(suite.T(), "POST", fmt.Sprintf("/api/activities/%d/reminders", activityId),
+		`{"offset_minutes": 15}`, suite.token)
+	assert.Equal(suite.T(), fiber.StatusCreated, status)
+	assert.NotNil(suite.T(), result["data"].(map[string]interface{})["due_at"])
+
+	sent, err := suite.reminders.DispatchDue()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, sent)
+
+	sent, err = suite.reminders.DispatchDue()
+	assert.NoError(suite.T(), err)
+	assert.Zero(suite.T(), sent)
+
+	notifications := suite.notifications.take()
+	if assert.Len(suite.T(), notifications, 1) {
+		assert.Equal(suite.T(), "reminded@test.local", notifications[0].To)
+		assert.Equal(suite.T(), "Reminder: Dentist", notifications[0].Subject)
+	}
+}
+
+func (suite *ReminderTestSuite) TestFutureReminderWaits() {
+	activityId := suite.createActivity(time.Now().Add(2 * time.Hour))
+
+	status, _ := suite.send(suite.T(), "POST", fmt.Sprintf("/api/activities/%d/reminders", activityId),
+		`{"offset_minutes": 15}`, suite.token)
+	assert.Equal(suite.T(), fiber.StatusCreated, status)
+
+	sent, err := suite.reminders.DispatchDue()
+	assert.NoError(suite.T(), err)
+	assert.Zero(suite.T(), sent)
+}
+
+func (suite *ReminderTestSuite) TestRequestNeedsExactlyOneTime() {
+	activityId := suite.createActivity(time.Now().Add(time.Hour))
+
+	status, _ := suite.send(suite.T(), "POST", fmt.Sprintf("/api/activities/%d/reminders", activityId),
+		`{"offset_minutes": 15, "remind_at": "2030-01-01T09:00:00Z"}`, suite.token)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, status)
+
+	status, _ = suite.send(suite.T(), "POST", fmt.Sprintf("/api/activities/%d/reminders", activityId), `{}`, suite.token)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, status)
+}
+
+func (suite *ReminderTestSuite) TestConcurrentDispatchersDeliverEachReminderOnce() {
+	activityId := suite.createActivity(time.Now().Add(time.Hour))
+	past := time.Now().Add(-time.Minute)
+	for i := 0; i < 20; i++ {
+		_, err := suite.reminders.Create(suite.userId, activityId, reminderEntities.Reminder{RemindAt: &past})
+		assert.NoError(suite.T(), err)
+	}
+
+	var wait sync.WaitGroup
+	var mutex sync.Mutex
+	total := 0
+	for i := 0; i < 4; i++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			sent, err := suite.reminders.DispatchDue()
+			assert.NoError(suite.T(), err)
+			mutex.Lock()
+			total += sent
+			mutex.Unlock()
+		}()
+	}
+	wait.Wait()
+
+	assert.Equal(suite.T(), 20, total)
+	assert.Len(suite.T(), suite.notifications.take(), 20)
+}
@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type SavedFilterTestSuite struct {
+	suite.Suite
+	*testApp
+}
+
+func (suite *SavedFilterTestSuite) SetupSuite() {
+	suite.testApp = newTestApp(suite.T())
+}
+
+func (suite *SavedFilterTestSuite) SetupTest() {
+	suite.db.GetDB().Exec("TRUNCATE TABLE users RESTART IDENTITY CASCADE")
+}
+
+func TestSavedFilterAPI(t *testing.T) {
+	suite.Run(t, new(SavedFilterTestSuite))
+}
+
+func (suite *SavedFilterTestSuite) TestRunsFilter() {
+	_, token := suite.registerUser(suite.T(), "filters@test.local")
+	for _, body := range []string{
+		`{"title": "Fix login", "category": "TASK", "description": "Overdue", "priority": "P1", "due_at": "2020-01-01T10:00:00Z", "tags": ["backend"]}`,
+		`{"title": "Fix signup", "category": "TASK", "description": "Not tagged", "priority": "P1", "due_at": "2020-01-01T10:00:00Z"}`,
+		`{"title": "Fix search", "category": "TASK", "description": "Not urgent", "priority": "P3", "due_at": "2020-01-01T10:00:00Z", "tags": ["backend"]}`,
+		`{"title": "Fix cache", "category": "TASK", "description": "Not due yet", "priority": "P1", "due_at": "2099-01-01T10:00:00Z", "tags": ["backend"]}`,
+	} {
+		status, result := suite.send(suite.T(), "POST", "/api/activities", body, token)
+		suite.Require().Equal(fiber.StatusCreated, status, result["message"])
+	}
+
+	status, result := suite.send(suite.T(), "POST", "/api/filters", `{
+		"name": "Overdue P1 backend tasks",
+		"filter": {"categories": ["TASK"], "priorities": ["P1"], "tags": ["#Backend"], "overdue": true}
+	}`, token)
+	suite.Require().Equal(fiber.StatusCreated, status, result["message"])
+	filter := result["data"].(map[string]interface{})
+	assert.Equal(suite.T(), []interface{}{"backend"}, filter["filter"].(map[string]interface{})["tags"])
+	id := int(filter["id"].(float64))
+
+	status, result = suite.send(suite.T(), "GET", fmt.Sprintf("/api/filters/%d/activities", id), "", token)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+	suite.Require().Len(result["data"], 1)
+	assert.Equal(suite.T(), "Fix login", result["data"].([]interface{})[0].(map[string]interface{})["title"])
+
+	status, _ = suite.send(suite.T(), "POST", "/api/filters", `{"name": "Bad", "filter": {"priorities": ["P9"]}}`, token)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, status)
+}
+
+func (suite *SavedFilterTestSuite) TestSharedWithinWorkspace() {
+	_, ownerToken := suite.registerUser(suite.T(), "owner@test.local")
+	_, viewerToken := suite.registerUser(suite.T(), "viewer@test.local")
+	_, outsiderToken := suite.registerUser(suite.T(), "outsider@test.local")
+
+	status, result := suite.send(suite.T(), "POST", "/api/workspaces", `{"name": "Team"}`, ownerToken)
+	suite.Require().Equal(fiber.StatusCreated, status)
+	workspaceId := int(result["data"].(map[string]interface{})["id"].(float64))
+	status, result = suite.send(suite.T(), "POST", fmt.Sprintf("/api/workspaces/%d/invitations", workspaceId),
+		`{"email": "viewer@test.local", "role": "viewer"}`, ownerToken)
+	suite.Require().Equal(fiber.StatusCreated, status)
+	invitation := result["data"].(map[string]interface{})["token"].(string)
+	status, _ = suite.send(suite.T(), "POST", "/api/invitations/accept", fmt.Sprintf(`{"token": %q}`, invitation), viewerToken)
+	suite.Require().Equal(fiber.StatusOK, status)
+
+	status, result = suite.send(suite.T(), "POST", "/api/filters", fmt.Sprintf(`{
+		"workspace_id": %d,
+		"name": "Team tasks",
+		"filter": {"workspace_id": %d, "categories": ["TASK"]}
+	}`, workspaceId, workspaceId), ownerToken)
+	suite.Require().Equal(fiber.StatusCreated, status, result["message"])
+	id := int(result["data"].(map[string]interface{})["id"].(float64))
+
+	status, result = suite.send(suite.T(), "GET", "/api/filters", "", viewerToken)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+	assert.Len(suite.T(), result["data"], 1)
+
+	status, _ = suite.send(suite.T(), "GET", fmt.Sprintf("/api/filters/%d/activities", id), "", viewerToken)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+
+	status, _ = suite.send(suite.T(), "PUT", fmt.Sprintf("/api/filters/%d", id), `{"name": "Mine now", "filter": {}}`, viewerToken)
+	assert.Equal(suite.T(), fiber.StatusForbidden, status)
+
+	status, _ = suite.send(suite.T(), "GET", fmt.Sprintf("/api/filters/%d", id), "", outsiderToken)
+	assert.Equal(suite.T(), fiber.StatusNotFound, status)
+}
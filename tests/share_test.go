@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+	"time"
+	"todolist-v1/modules/activity/entities"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ShareTestSuite struct {
+	suite.Suite
+	*testApp
+	ownerId     int
+	ownerToken  string
+	friendToken string
+	activityId  int
+}
+
+func (suite *ShareTestSuite) SetupSuite() {
+	suite.testApp = newTestApp(suite.T())
+}
+
+func (suite *ShareTestSuite) SetupTest() {
+	suite.db.GetDB().Exec("TRUNCATE TABLE users RESTART IDENTITY CASCADE")
+	suite.ownerId, suite.ownerToken = suite.registerUser(suite.T(), "owner@test.local")
+	_, suite.friendToken = suite.registerUser(suite.T(), "friend@test.local")
+
+	activity, _, err := suite.activities.Create(suite.ownerId, entities.Activity{
+		Title:        "Shared task",
+		Category:     "TASK",
+		Description:  "Shared with a colleague",
+		ActivityDate: time.Now(),
+	}, entities.ConflictsWarn)
+	assert.NoError(suite.T(), err)
+	suite.activityId = activity.Id
+}
+
+func TestShareAPI(t *testing.T) {
+	suite.Run(t, new(ShareTestSuite))
+}
+
+func (suite *ShareTestSuite) update(token string) int {
+	status, _ := suite.send(suite.T(), "PUT", fmt.Sprintf("/api/activities/%d", suite.activityId), `{
+		"title": "Edited by a colleague",
+		"category": "TASK",
+		"description": "Updated through a share",
+		"activity_date": "2026-11-11T11:00:00Z",
+		"status": "ON PROGRESS"
+	}`, token)
+	return status
+}
+
+func (suite *ShareTestSuite) TestReadShare() {
+	assert.Equal(suite.T(), fiber.StatusNotFound, suite.update(suite.friendToken))
+
+	status, _ := suite.send(suite.T(), "POST", fmt.Sprintf("/api/activities/%d/shares", suite.activityId),
+		`{"email": "friend@test.local", "permission": "read"}`, suite.ownerToken)
+	assert.Equal(suite.T(), fiber.StatusCreated, status)
+
+	status, result := suite.send(suite.T(), "GET", "/api/activities", "", suite.friendToken)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+	assert.Len(suite.T(), result["data"], 1)
+
+	assert.Equal(suite.T(), fiber.StatusForbidden, suite.update(suite.friendToken))
+}
+
+func (suite *ShareTestSuite) TestEditShare() {
+	status, _ := suite.send(suite.T(), "POST", fmt.Sprintf("/api/activities/%d/shares", suite.activityId),
+		`{"email": "friend@test.local", "permission": "edit"}`, suite.ownerToken)
+	assert.Equal(suite.T(), fiber.StatusCreated, status)
+
+	assert.Equal(suite.T(), fiber.StatusOK, suite.update(suite.friendToken))
+
+	status, _ = suite.send(suite.T(), "DELETE", fmt.Sprintf("/api/activities/%d", suite.activityId), "", suite.friendToken)
+	assert.Equal(suite.T(), fiber.StatusForbidden, status)
+
+	status, _ = suite.send(suite.T(), "DELETE", fmt.Sprintf("/api/activities/%d/shares/2", suite.activityId), "", suite.ownerToken)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+	assert.Equal(suite.T(), fiber.StatusNotFound, suite.update(suite.friendToken))
+}
+
+func (suite *ShareTestSuite) TestUndoRefusedAfterAnotherEdit() {
+	status, _ := suite.send(suite.T(), "POST", fmt.Sprintf("/api/activities/%d/shares", suite.activityId),
+		`{"email": "friend@test.local", "permission": "edit"}`, suite.ownerToken)
+	assert.Equal(suite.T(), fiber.StatusCreated, status)
+
+	assert.Equal(suite.T(), fiber.StatusOK, suite.update(suite.ownerToken))
+	assert.Equal(suite.T(), fiber.StatusOK, suite.update(suite.friendToken))
+
+	status, _ = suite.send(suite.T(), "POST", "/api/undo", "", suite.ownerToken)
+	assert.Equal(suite.T(), fiber.StatusConflict, status)
+}
+
+func (suite *ShareTestSuite) TestPublicLink() {
+	status, result := suite.send(suite.T(), "POST", fmt.Sprintf("/api/activities/%d/share-links", suite.activityId),
+		`{"expires_in_hours": 1}`, suite.ownerToken)
+	assert.Equal(suite.T(), fiber.StatusCreated, status)
+	link := result["data"].(map[string]interface{})
+
+	status, result = suite.send(suite.T(), "GET", fmt.Sprintf("/api/public/activities/%s", link["token"]), "", "")
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+	assert.Equal(suite.T(), "Shared task", result["data"].(map[string]interface{})["title"])
+
+	status, _ = suite.send(suite.T(), "DELETE", fmt.Sprintf("/api/activities/%d/share-links/%v", suite.activityId, link["id"]), "", suite.ownerToken)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+
+	status, _ = suite.send(suite.T(), "GET", fmt.Sprintf("/api/public/activities/%s", link["token"]), "", "")
+	assert.Equal(suite.T(), fiber.StatusNotFound, status)
+}
+
+func (suite *ShareTestSuite) TestOnlyManagersCanShare() {
+	status, _ := suite.send(suite.T(), "POST", fmt.Sprintf("/api/activities/%d/share-links", suite.activityId), "", suite.friendToken)
+	assert.Equal(suite.T(), fiber.StatusNotFound, status)
+}
@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type StatsTestSuite struct {
+	suite.Suite
+	*testApp
+	token string
+}
+
+func (suite *StatsTestSuite) SetupSuite() {
+	suite.testApp = newTestApp(suite.T())
+}
+
+func (suite *StatsTestSuite) SetupTest() {
+	suite.db.GetDB().Exec("TRUNCATE TABLE users RESTART IDENTITY CASCADE")
+	_, suite.token = suite.registerUser(suite.T(), "stats@test.local")
+}
+
+func TestStatsAPI(t *testing.T) {
+	suite.Run(t, new(StatsTestSuite))
+}
+
+func (suite *StatsTestSuite) createActivity(category string, dueAt time.Time) int {
+	body := fmt.Sprintf(`{"title": "Report", "category": %q, "description": "Weekly", "due_at": %q}`, category, dueAt.Format(time.RFC3339))
+	status, result := suite.send(suite.T(), "POST", "/api/activities", body, suite.token)
+	suite.Require().Equal(fiber.StatusCreated, status, result["message"])
+	return int(result["data"].(map[string]interface{})["id"].(float64))
+}
+
+func (suite *StatsTestSuite) stats() map[string]interface{} {
+	status, result := suite.send(suite.T(), "GET", "/api/stats", "", suite.token)
+	suite.Require().Equal(fiber.StatusOK, status, result["message"])
+	return result["data"].(map[string]interface{})
+}
+
+func (suite *StatsTestSuite) TestStatsFollowCompletions() {
+	yesterday := time.Now().UTC().AddDate(0, 0, -1).Truncate(time.Hour)
+	done := suite.createActivity("TASK", yesterday)
+	suite.createActivity("TASK", yesterday)
+	suite.createActivity("EVENT", yesterday.AddDate(0, 0, -1))
+
+	stats := suite.stats()
+	assert.Equal(suite.T(), float64(3), stats["total"])
+	assert.Equal(suite.T(), float64(3), stats["overdue"])
+	assert.Equal(suite.T(), float64(0), stats["completion_rate"])
+	assert.Equal(suite.T(), map[string]interface{}{"TASK": float64(2), "EVENT": float64(1)}, stats["by_category"])
+	assert.Len(suite.T(), stats["days"], 30)
+
+	status, _ := suite.send(suite.T(), "POST", fmt.Sprintf("/api/activities/%d/move", done), `{"status": "DONE"}`, suite.token)
+	suite.Require().Equal(fiber.StatusOK, status)
+
+	stats = suite.stats()
+	assert.Equal(suite.T(), float64(1), stats["done"])
+	assert.Equal(suite.T(), float64(2), stats["overdue"])
+	assert.InDelta(suite.T(), 1.0/3, stats["completion_rate"], 0.001)
+	completions := stats["completions"].(map[string]interface{})
+	assert.Equal(suite.T(), float64(1), completions["count"])
+	assert.NotNil(suite.T(), completions["average_seconds"])
+
+	days := stats["days"].([]interface{})
+	lastDay := days[len(days)-2].(map[string]interface{})
+	assert.Equal(suite.T(), yesterday.Format(time.DateOnly), lastDay["date"])
+	assert.Equal(suite.T(), float64(2), lastDay["total"])
+	assert.Equal(suite.T(), 0.5, lastDay["completion_rate"])
+}
+
+func (suite *StatsTestSuite) TestRangeIsBounded() {
+	status, _ := suite.send(suite.T(), "GET", "/api/stats?from=2026-03-10&to=2026-03-01", "", suite.token)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, status)
+
+	status, _ = suite.send(suite.T(), "GET", "/api/stats?from=2025-01-01&to=2026-03-01", "", suite.token)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, status)
+}
+
+func (suite *StatsTestSuite) TestStatsFollowChangesBySharingUsers() {
+	id := suite.createActivity("TASK", time.Now().UTC().AddDate(0, 0, -1).Truncate(time.Hour))
+	_, friendToken := suite.registerUser(suite.T(), "friend@test.local")
+	status, _ := suite.send(suite.T(), "POST", fmt.Sprintf("/api/activities/%d/shares", id),
+		`{"email": "friend@test.local", "permission": "read"}`, suite.token)
+	suite.Require().Equal(fiber.StatusCreated, status)
+
+	friendStats := func() map[string]interface{} {
+		status, result := suite.send(suite.T(), "GET", "/api/stats", "", friendToken)
+		suite.Require().Equal(fiber.StatusOK, status, result["message"])
+		return result["data"].(map[string]interface{})
+	}
+	assert.Equal(suite.T(), float64(0), friendStats()["done"])
+
+	status, _ = suite.send(suite.T(), "POST", fmt.Sprintf("/api/activities/%d/move", id), `{"status": "DONE"}`, suite.token)
+	suite.Require().Equal(fiber.StatusOK, status)
+	assert.Equal(suite.T(), float64(1), friendStats()["done"])
+}
@@ -0,0 +1,132 @@
+package tests
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"todolist-v1/pkg/storage"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeS3 is a minimal stand-in for an S3-compatible service. It serves
+// path-style object requests from memory and ignores signatures.
+type fakeS3 struct {
+	mutex   sync.Mutex
+	objects map[string][]byte
+}
+
+func (fake *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		body, err := readS3Payload(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fake.objects[r.URL.Path] = body
+		w.Header().Set("ETag", `"fake"`)
+	case http.MethodGet, http.MethodHead:
+		body, ok := fake.objects[r.URL.Path]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.Header().Set("ETag", `"fake"`)
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		if r.Method == http.MethodGet {
+			w.Write(body)
+		}
+	case http.MethodDelete:
+		delete(fake.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+// readS3Payload returns the object data of a PUT request, decoding the
+// aws-chunked encoding the client uses for signed streaming uploads.
+func readS3Payload(r *http.Request) ([]byte, error) {
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return io.ReadAll(r.Body)
+	}
+
+	var payload bytes.Buffer
+	reader := bufio.NewReader(r.Body)
+	for {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.ParseInt(strings.SplitN(strings.TrimSpace(header), ";", 2)[0], 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		if size == 0 {
+			return payload.Bytes(), nil
+		}
+		if _, err := io.CopyN(&payload, reader, size); err != nil {
+			return nil, err
+		}
+		if _, err := reader.Discard(2); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func testBlobStore(t *testing.T, store storage.BlobStore) {
+	content := "attachment content"
+	assert.NoError(t, store.Put("activities/1/blob", strings.NewReader(content), int64(len(content)), "text/plain"))
+
+	reader, err := store.Get("activities/1/blob")
+	if assert.NoError(t, err) {
+		stored, _ := io.ReadAll(reader)
+		reader.Close()
+		assert.Equal(t, content, string(stored))
+	}
+
+	assert.NoError(t, store.Delete("activities/1/blob"))
+	_, err = store.Get("activities/1/blob")
+	assert.ErrorIs(t, err, storage.ErrBlobNotFound)
+	assert.NoError(t, store.Delete("activities/1/blob"))
+}
+
+func TestLocalBlobStore(t *testing.T) {
+	store, err := storage.NewLocalBlobStore(t.TempDir())
+	if !assert.NoError(t, err) {
+		return
+	}
+	testBlobStore(t, store)
+
+	assert.Error(t, store.Put("../outside", strings.NewReader("x"), 1, "text/plain"))
+}
+
+func TestS3BlobStore(t *testing.T) {
+	server := httptest.NewServer(&fakeS3{objects: make(map[string][]byte)})
+	defer server.Close()
+
+	store, err := storage.NewS3BlobStore(storage.S3Options{
+		Endpoint:  strings.TrimPrefix(server.URL, "http://"),
+		Region:    "us-east-1",
+		Bucket:    "attachments",
+		AccessKey: "test",
+		SecretKey: "test-secret",
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	testBlobStore(t, store)
+}
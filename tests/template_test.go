@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type TemplateTestSuite struct {
+	suite.Suite
+	*testApp
+	token string
+}
+
+func (suite *TemplateTestSuite) SetupSuite() {
+	suite.testApp = newTestApp(suite.T())
+}
+
+func (suite *TemplateTestSuite) SetupTest() {
+	suite.db.GetDB().Exec("TRUNCATE TABLE users RESTART IDENTITY CASCADE")
+	_, suite.token = suite.registerUser(suite.T(), "release@test.local")
+}
+
+func TestTemplateAPI(t *testing.T) {
+	suite.Run(t, new(TemplateTestSuite))
+}
+
+func (suite *TemplateTestSuite) TestInstantiateFillsPlaceholdersAndCreatesChecklist() {
+	status, result := suite.send(suite.T(), "POST", "/api/templates", `{
+		"name": "Release checklist", "title_pattern": "Release {{date}}", "category": "EVENT",
+		"description": "Run by {{ user }}", "checklist": ["Tag {{date}}", "Publish notes"]
+	}`, suite.token)
+	suite.Require().Equal(fiber.StatusCreated, status, result["message"])
+	id := int(result["data"].(map[string]interface{})["id"].(float64))
+
+	status, result = suite.send(suite.T(), "POST", fmt.Sprintf("/api/templates/%d/instantiate", id), `{"at": "2026-03-04T23:30:00Z"}`, suite.token)
+	suite.Require().Equal(fiber.StatusCreated, status, result["message"])
+	instance := result["data"].(map[string]interface{})
+	activity := instance["activity"].(map[string]interface{})
+	assert.Equal(suite.T(), "Release 2026-03-04", activity["title"])
+	assert.Equal(suite.T(), "Run by release@test.local", activity["description"])
+	children := instance["children"].([]interface{})
+	if assert.Len(suite.T(), children, 2) {
+		assert.Equal(suite.T(), "Tag 2026-03-04", children[0].(map[string]interface{})["title"])
+		assert.Equal(suite.T(), activity["id"], children[1].(map[string]interface{})["parent_id"])
+	}
+
+	status, result = suite.send(suite.T(), "GET", fmt.Sprintf("/api/activities?parent_id=%d", int(activity["id"].(float64))), "", suite.token)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+	assert.Len(suite.T(), result["data"], 2)
+}
+
+func (suite *TemplateTestSuite) TestInstantiateReportsOverlappingEvents() {
+	status, result := suite.send(suite.T(), "POST", "/api/templates", `{
+		"name": "Standup", "title_pattern": "Standup {{date}}", "category": "EVENT"
+	}`, suite.token)
+	suite.Require().Equal(fiber.StatusCreated, status, result["message"])
+	url := fmt.Sprintf("/api/templates/%d/instantiate", int(result["data"].(map[string]interface{})["id"].(float64)))
+
+	status, result = suite.send(suite.T(), "POST", url, `{"at": "2026-03-04T09:00:00Z"}`, suite.token)
+	suite.Require().Equal(fiber.StatusCreated, status, result["message"])
+	assert.NotContains(suite.T(), result["data"], "conflicts")
+
+	status, result = suite.send(suite.T(), "POST", url, `{"at": "2026-03-04T09:00:00Z"}`, suite.token)
+	suite.Require().Equal(fiber.StatusCreated, status, result["message"])
+	assert.Len(suite.T(), result["data"].(map[string]interface{})["conflicts"], 1)
+
+	status, result = suite.send(suite.T(), "POST", url, `{"at": "2026-03-04T09:00:00Z", "reject_conflicts": true}`, suite.token)
+	assert.Equal(suite.T(), fiber.StatusConflict, status)
+	assert.Len(suite.T(), result["data"].(map[string]interface{})["conflicts"], 2)
+}
+
+func (suite *TemplateTestSuite) TestUnknownPlaceholdersAreRejected() {
+	status, _ := suite.send(suite.T(), "POST", "/api/templates", `{
+		"name": "Onboarding", "title_pattern": "Welcome {{name}}", "category": "TASK"
+	}`, suite.token)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, status)
+}
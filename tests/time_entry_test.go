@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type TimeEntryTestSuite struct {
+	suite.Suite
+	*testApp
+	token string
+}
+
+func (suite *TimeEntryTestSuite) SetupSuite() {
+	suite.testApp = newTestApp(suite.T())
+}
+
+func (suite *TimeEntryTestSuite) SetupTest() {
+	suite.db.GetDB().Exec("TRUNCATE TABLE users RESTART IDENTITY CASCADE")
+	_, suite.token = suite.registerUser(suite.T(), "timesheet@test.local")
+}
+
+func TestTimeEntryAPI(t *testing.T) {
+	suite.Run(t, new(TimeEntryTestSuite))
+}
+
+func (suite *TimeEntryTestSuite) createActivity(body string) int {
+	status, result := suite.send(suite.T(), "POST", "/api/activities", body, suite.token)
+	suite.Require().Equal(fiber.StatusCreated, status, result["message"])
+	return int(result["data"].(map[string]interface{})["id"].(float64))
+}
+
+func (suite *TimeEntryTestSuite) logTime(activityId int, startedAt string, endedAt string) (int, map[string]interface{}) {
+	body := fmt.Sprintf(`{"started_at": %q, "ended_at": %q}`, startedAt, endedAt)
+	return suite.send(suite.T(), "POST", fmt.Sprintf("/api/activities/%d/time-entries", activityId), body, suite.token)
+}
+
+func (suite *TimeEntryTestSuite) TestOnlyOneTimerRuns() {
+	first := suite.createActivity(`{"title": "Invoice", "category": "TASK", "description": "Client A", "due_at": "2026-03-04T17:00:00Z"}`)
+	second := suite.createActivity(`{"title": "Review", "category": "TASK", "description": "Client B", "due_at": "2026-03-04T17:00:00Z"}`)
+
+	status, result := suite.send(suite.T(), "POST", fmt.Sprintf("/api/activities/%d/timer/start", first), "", suite.token)
+	assert.Equal(suite.T(), fiber.StatusCreated, status, result["message"])
+
+	status, _ = suite.send(suite.T(), "POST", fmt.Sprintf("/api/activities/%d/timer/start", second), "", suite.token)
+	assert.Equal(suite.T(), fiber.StatusConflict, status)
+
+	status, result = suite.send(suite.T(), "POST", "/api/timer/stop", "", suite.token)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+	assert.Equal(suite.T(), float64(first), result["data"].(map[string]interface{})["activity_id"])
+	assert.NotNil(suite.T(), result["data"].(map[string]interface{})["ended_at"])
+
+	status, _ = suite.send(suite.T(), "POST", "/api/timer/stop", "", suite.token)
+	assert.Equal(suite.T(), fiber.StatusNotFound, status)
+}
+
+func (suite *TimeEntryTestSuite) TestManualEntriesMustNotOverlap() {
+	id := suite.createActivity(`{"title": "Invoice", "category": "TASK", "description": "Client A", "due_at": "2026-03-04T17:00:00Z"}`)
+
+	status, result := suite.logTime(id, "2026-03-02T09:00:00Z", "2026-03-02T10:30:00Z")
+	assert.Equal(suite.T(), fiber.StatusCreated, status, result["message"])
+	assert.Equal(suite.T(), float64(5400), result["data"].(map[string]interface{})["duration_seconds"])
+
+	status, _ = suite.logTime(id, "2026-03-02T10:00:00Z", "2026-03-02T11:00:00Z")
+	assert.Equal(suite.T(), fiber.StatusConflict, status)
+
+	status, _ = suite.logTime(id, "2026-03-02T10:30:00Z", "2026-03-02T11:00:00Z")
+	assert.Equal(suite.T(), fiber.StatusCreated, status)
+
+	status, result = suite.send(suite.T(), "GET", "/api/activities", "", suite.token)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+	assert.Equal(suite.T(), float64(7200), result["data"].([]interface{})[0].(map[string]interface{})["tracked_seconds"])
+}
+
+func (suite *TimeEntryTestSuite) TestTimesheetGroupsByDayAndTag() {
+	billing := suite.createActivity(`{"title": "Invoice", "category": "TASK", "description": "Client A", "due_at": "2026-03-04T17:00:00Z", "tags": ["#Acme", "billing"]}`)
+	untagged := suite.createActivity(`{"title": "Inbox", "category": "TASK", "description": "Mail", "due_at": "2026-03-04T17:00:00Z"}`)
+	suite.logTime(billing, "2026-03-02T09:00:00Z", "2026-03-02T10:00:00Z")
+	suite.logTime(untagged, "2026-03-02T10:00:00Z", "2026-03-02T10:30:00Z")
+	suite.logTime(billing, "2026-03-03T09:00:00Z", "2026-03-03T11:00:00Z")
+
+	status, result := suite.send(suite.T(), "GET", "/api/timesheet?from=2026-03-02&to=2026-03-03&group_by=day,tag", "", suite.token)
+	assert.Equal(suite.T(), fiber.StatusOK, status, result["message"])
+
+	timesheet := result["data"].(map[string]interface{})
+	assert.Equal(suite.T(), float64(12600), timesheet["total_seconds"])
+	var rows []string
+	for _, row := range timesheet["rows"].([]interface{}) {
+		row := row.(map[string]interface{})
+		rows = append(rows, fmt.Sprintf("%v %v %v", row["day"], row["tag"], row["seconds"]))
+	}
+	assert.Equal(suite.T(), []string{
+		"2026-03-02 acme 3600",
+		"2026-03-02 billing 3600",
+		"2026-03-02 <nil> 1800",
+		"2026-03-03 acme 7200",
+		"2026-03-03 billing 7200",
+	}, rows)
+}
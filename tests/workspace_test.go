@@ -0,0 +1,178 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type WorkspaceTestSuite struct {
+	suite.Suite
+	*testApp
+}
+
+func (suite *WorkspaceTestSuite) SetupSuite() {
+	suite.testApp = newTestApp(suite.T())
+}
+
+func (suite *WorkspaceTestSuite) TearDownTest() {
+	suite.db.GetDB().Exec("TRUNCATE TABLE users RESTART IDENTITY CASCADE")
+}
+
+func TestWorkspaceAPI(t *testing.T) {
+	suite.Run(t, new(WorkspaceTestSuite))
+}
+
+func (suite *WorkspaceTestSuite) login(email string) string {
+	_, token := suite.registerUser(suite.T(), email)
+	return token
+}
+
+func (suite *WorkspaceTestSuite) send(method string, url string, body string, token string) (int, map[string]interface{}) {
+	req, _ := http.NewRequest(method, url, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := suite.app.Test(req)
+	assert.NoError(suite.T(), err)
+
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	var result map[string]interface{}
+	json.Unmarshal(respBody, &result)
+	return resp.StatusCode, result
+}
+
+// join creates a workspace owned by ownerToken and adds email to it with role.
+func (suite *WorkspaceTestSuite) join(ownerToken string, email string, role string) (int, string) {
+	status, result := suite.send("POST", "/api/workspaces", `{"name": "Team"}`, ownerToken)
+	assert.Equal(suite.T(), fiber.StatusCreated, status)
+	workspaceId := int(result["data"].(map[string]interface{})["id"].(float64))
+
+	status, result = suite.send("POST", fmt.Sprintf("/api/workspaces/%d/invitations", workspaceId),
+		fmt.Sprintf(`{"email": %q, "role": %q}`, email, role), ownerToken)
+	assert.Equal(suite.T(), fiber.StatusCreated, status)
+	token := result["data"].(map[string]interface{})["token"].(string)
+
+	memberToken := suite.login(email)
+	status, _ = suite.send("POST", "/api/invitations/accept", fmt.Sprintf(`{"token": %q}`, token), memberToken)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+
+	return workspaceId, memberToken
+}
+
+func (suite *WorkspaceTestSuite) TestSharedActivities_VisibleToMembers() {
+	ownerToken := suite.login("owner@test.local")
+	workspaceId, memberToken := suite.join(ownerToken, "member@test.local", "member")
+
+	status, _ := suite.send("POST", "/api/activities", fmt.Sprintf(`{
+		"workspace_id": %d,
+		"title": "Shared task",
+		"category": "TASK",
+		"description": "Visible to the whole team",
+		"activity_date": "2026-10-10T10:00:00Z"
+	}`, workspaceId), memberToken)
+	assert.Equal(suite.T(), fiber.StatusCreated, status)
+
+	status, result := suite.send("GET", fmt.Sprintf("/api/activities?workspace_id=%d", workspaceId), "", ownerToken)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+	assert.Len(suite.T(), result["data"], 1)
+}
+
+func (suite *WorkspaceTestSuite) TestViewer_CannotWrite() {
+	ownerToken := suite.login("owner@test.local")
+	workspaceId, viewerToken := suite.join(ownerToken, "viewer@test.local", "viewer")
+
+	status, _ := suite.send("GET", fmt.Sprintf("/api/activities?workspace_id=%d", workspaceId), "", viewerToken)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+
+	status, _ = suite.send("POST", "/api/activities", fmt.Sprintf(`{
+		"workspace_id": %d,
+		"title": "Not allowed",
+		"category": "TASK",
+		"description": "Viewers are read-only",
+		"activity_date": "2026-10-10T10:00:00Z"
+	}`, workspaceId), viewerToken)
+	assert.Equal(suite.T(), fiber.StatusForbidden, status)
+
+	status, _ = suite.send("POST", fmt.Sprintf("/api/workspaces/%d/invitations", workspaceId),
+		`{"email": "friend@test.local", "role": "viewer"}`, viewerToken)
+	assert.Equal(suite.T(), fiber.StatusForbidden, status)
+}
+
+func (suite *WorkspaceTestSuite) TestOutsider_GetsNotFound() {
+	ownerToken := suite.login("owner@test.local")
+	status, result := suite.send("POST", "/api/workspaces", `{"name": "Private"}`, ownerToken)
+	assert.Equal(suite.T(), fiber.StatusCreated, status)
+	workspaceId := int(result["data"].(map[string]interface{})["id"].(float64))
+
+	outsiderToken := suite.login("outsider@test.local")
+	status, _ = suite.send("GET", fmt.Sprintf("/api/workspaces/%d/members", workspaceId), "", outsiderToken)
+	assert.Equal(suite.T(), fiber.StatusNotFound, status)
+
+	status, _ = suite.send("GET", fmt.Sprintf("/api/activities?workspace_id=%d", workspaceId), "", outsiderToken)
+	assert.Equal(suite.T(), fiber.StatusNotFound, status)
+}
+
+func (suite *WorkspaceTestSuite) TestLastOwner_CannotLeave() {
+	ownerToken := suite.login("owner@test.local")
+	status, result := suite.send("POST", "/api/workspaces", `{"name": "Solo"}`, ownerToken)
+	assert.Equal(suite.T(), fiber.StatusCreated, status)
+	workspaceId := int(result["data"].(map[string]interface{})["id"].(float64))
+
+	status, _ = suite.send("DELETE", fmt.Sprintf("/api/workspaces/%d/members/1", workspaceId), "", ownerToken)
+	assert.Equal(suite.T(), fiber.StatusConflict, status)
+}
+
+func (suite *WorkspaceTestSuite) TestInvitation_WrongEmail() {
+	ownerToken := suite.login("owner@test.local")
+	status, result := suite.send("POST", "/api/workspaces", `{"name": "Team"}`, ownerToken)
+	assert.Equal(suite.T(), fiber.StatusCreated, status)
+	workspaceId := int(result["data"].(map[string]interface{})["id"].(float64))
+
+	status, result = suite.send("POST", fmt.Sprintf("/api/workspaces/%d/invitations", workspaceId),
+		`{"email": "invitee@test.local", "role": "member"}`, ownerToken)
+	assert.Equal(suite.T(), fiber.StatusCreated, status)
+	token := result["data"].(map[string]interface{})["token"].(string)
+
+	intruderToken := suite.login("intruder@test.local")
+	status, _ = suite.send("POST", "/api/invitations/accept", fmt.Sprintf(`{"token": %q}`, token), intruderToken)
+	assert.Equal(suite.T(), fiber.StatusForbidden, status)
+}
+
+func (suite *WorkspaceTestSuite) TestOwners_DemotingEachOtherKeepsOneOwner() {
+	ownerToken := suite.login("owner@test.local")
+	workspaceId, coOwnerToken := suite.join(ownerToken, "coowner@test.local", "owner")
+
+	statuses := make(chan int, 2)
+	demote := func(userId int, token string) {
+		status, _ := suite.send("PUT", fmt.Sprintf("/api/workspaces/%d/members/%d", workspaceId, userId),
+			`{"role": "member"}`, token)
+		statuses <- status
+	}
+	go demote(2, ownerToken)
+	go demote(1, coOwnerToken)
+
+	succeeded := 0
+	for i := 0; i < 2; i++ {
+		if <-statuses == fiber.StatusOK {
+			succeeded++
+		}
+	}
+	assert.Equal(suite.T(), 1, succeeded)
+
+	status, result := suite.send("GET", fmt.Sprintf("/api/workspaces/%d/members", workspaceId), "", ownerToken)
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+	owners := 0
+	for _, member := range result["data"].([]interface{}) {
+		if member.(map[string]interface{})["role"] == "owner" {
+			owners++
+		}
+	}
+	assert.Equal(suite.T(), 1, owners)
+}